@@ -7,10 +7,14 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"go.opentelemetry.io/otel"
 
 	v1 "github.com/jakobmoellerdev/octi-sync-server/api/v1"
 	"github.com/jakobmoellerdev/octi-sync-server/config"
+	"github.com/jakobmoellerdev/octi-sync-server/metrics"
 	"github.com/jakobmoellerdev/octi-sync-server/middleware/logging"
+	requestMetrics "github.com/jakobmoellerdev/octi-sync-server/middleware/metrics"
+	"github.com/jakobmoellerdev/octi-sync-server/middleware/tracing"
 )
 
 const (
@@ -25,6 +29,12 @@ func New(ctx context.Context, config *config.Config) http.Handler {
 
 	router.Pre(middleware.RemoveTrailingSlash())
 
+	// Request Metrics in Prometheus Format
+	if config.Metrics != nil {
+		router.Use(requestMetrics.RequestMetrics(config.Metrics))
+		router.GET(metrics.Path, echo.WrapHandler(config.Metrics.Handler()))
+	}
+
 	// CORS Configuration based on config
 	router.Use(
 		middleware.CORSWithConfig(
@@ -43,28 +53,39 @@ func New(ctx context.Context, config *config.Config) http.Handler {
 
 	// Request ID Tracking for traceability
 	router.Use(middleware.RequestID())
+
+	// Tracing of every request, spans are only exported if tracing is configured
+	router.Use(tracing.RequestTracing(otel.GetTracerProvider(), otel.GetTextMapPropagator()))
+
+	// Event streams stay open beyond any request timeout and must not be buffered by compression
 	router.Use(
-		RequestContextTimeout(config.Server.Timeout.Request),
-		MapRequestTimeoutToResponseCode(http.StatusServiceUnavailable),
+		RequestContextTimeout(config.Server.Timeout.Request, v1.IsStreaming),
+		MapRequestTimeoutToResponseCode(http.StatusServiceUnavailable, v1.IsStreaming),
 	)
 
-	// Compression Handlers
+	// Compression Handlers, modules compressed at rest are encoded by their handlers
 	router.Use(
-		middleware.Gzip(),
+		middleware.GzipWithConfig(middleware.GzipConfig{Skipper: func(ctx echo.Context) bool {
+			return v1.IsStreaming(ctx) || v1.EncodesResponse(ctx)
+		}}),
 		middleware.Decompress(),
 	)
 
 	// Global Middleware for Error Recovery and Request Logging
 	router.Use(
 		middleware.Recover(),
-		logging.RequestLogging(config.Logger),
+		logging.RequestLogging(config.Logger, config.LogSettings.Access),
+		logging.ContextLogger(config.Logger),
 	)
 
 	if config.Server.MaxRequestBodySize == "" {
 		config.Server.MaxRequestBodySize = DefaultMaxRequestBodySize
 	}
-	// Body Size Limitation to avoid Request DOS
-	router.Use(middleware.BodyLimit(config.Server.MaxRequestBodySize))
+	// Body Size Limitation to avoid Request DOS, upload chunks are limited on their own
+	router.Use(middleware.BodyLimitWithConfig(middleware.BodyLimitConfig{
+		Skipper: v1.IsUploadChunk,
+		Limit:   config.Server.MaxRequestBodySize,
+	}))
 
 	// Inject V1
 	v1.New(ctx, router, config)
@@ -72,13 +93,17 @@ func New(ctx context.Context, config *config.Config) http.Handler {
 	return router
 }
 
-func RequestContextTimeout(timeout time.Duration) echo.MiddlewareFunc {
+func RequestContextTimeout(timeout time.Duration, skipper middleware.Skipper) echo.MiddlewareFunc {
 	if timeout == 0 {
 		timeout = DefaultTimeoutSeconds * time.Second
 	}
 
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
+			if skipper(c) {
+				return next(c)
+			}
+
 			timeoutCtx, cancel := context.WithTimeout(c.Request().Context(), timeout)
 
 			c.SetRequest(c.Request().WithContext(timeoutCtx))
@@ -90,9 +115,13 @@ func RequestContextTimeout(timeout time.Duration) echo.MiddlewareFunc {
 	}
 }
 
-func MapRequestTimeoutToResponseCode(targetCode int) echo.MiddlewareFunc {
+func MapRequestTimeoutToResponseCode(targetCode int, skipper middleware.Skipper) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(ctx echo.Context) error {
+			if skipper(ctx) {
+				return next(ctx)
+			}
+
 			doneCh := make(chan error)
 
 			run := func(ctx echo.Context) {
@@ -24,16 +24,166 @@ const (
 	DeviceAuthScopes = "deviceAuth.Scopes"
 )
 
+// Defines values for ChangeOperation.
+const (
+	Created ChangeOperation = "created"
+	Deleted ChangeOperation = "deleted"
+	Updated ChangeOperation = "updated"
+)
+
+// Defines values for DeviceStatus.
+const (
+	Approved DeviceStatus = "approved"
+	Pending  DeviceStatus = "pending"
+)
+
+// Defines values for EventType.
+const (
+	DeviceAdded   EventType = "device-added"
+	DeviceRemoved EventType = "device-removed"
+	ModuleChanged EventType = "module-changed"
+	ShareRedeemed EventType = "share-redeemed"
+)
+
 // Defines values for HealthResult.
 const (
 	Down HealthResult = "Down"
 	Up   HealthResult = "Up"
 )
 
+// Defines values for JSONPatchOperationOp.
+const (
+	Add     JSONPatchOperationOp = "add"
+	Copy    JSONPatchOperationOp = "copy"
+	Move    JSONPatchOperationOp = "move"
+	Remove  JSONPatchOperationOp = "remove"
+	Replace JSONPatchOperationOp = "replace"
+	Test    JSONPatchOperationOp = "test"
+)
+
+// Defines values for ModuleType.
+const (
+	LwwMap ModuleType = "lww-map"
+)
+
+// Defines values for QuotaResource.
+const (
+	Bytes   QuotaResource = "bytes"
+	Devices QuotaResource = "devices"
+	Modules QuotaResource = "modules"
+)
+
+// Defines values for WebSocketMessageType.
+const (
+	WebSocketMessageTypeAck       WebSocketMessageType = "ack"
+	WebSocketMessageTypeEvent     WebSocketMessageType = "event"
+	WebSocketMessageTypeGet       WebSocketMessageType = "get"
+	WebSocketMessageTypeHeartbeat WebSocketMessageType = "heartbeat"
+	WebSocketMessageTypePut       WebSocketMessageType = "put"
+	WebSocketMessageTypeSubscribe WebSocketMessageType = "subscribe"
+)
+
+// AccountUsage storage used by an account
+type AccountUsage struct {
+	// Bytes Size of the Data of all Modules
+	Bytes int64 `json:"bytes"`
+
+	// Devices Amount of Devices
+	Devices int64 `json:"devices"`
+
+	// Modules Amount of Modules
+	Modules int64 `json:"modules"`
+
+	// Quota limits of the storage of an account, limits that are not present are not enforced
+	Quota Quota `json:"quota"`
+}
+
+// BatchGet modules to read in a batch
+type BatchGet struct {
+	// Device Device ID is the unique identifier for a remote device
+	Device *DeviceID    `json:"device,omitempty"`
+	Names  []ModuleName `json:"names"`
+}
+
+// BatchItemResult result of a single module in a batch
+type BatchItemResult struct {
+	// Etag ETag of the written Module
+	Etag *string `json:"etag,omitempty"`
+
+	// Message Reason of a failed Module
+	Message *string `json:"message,omitempty"`
+
+	// Name Module Name, modules can only be written with names matching the pattern
+	Name ModuleName `json:"name"`
+
+	// Status Result as HTTP Status Code of the corresponding single Module Request
+	Status int `json:"status"`
+}
+
+// BatchResult results of a batch, in the order of the request
+type BatchResult struct {
+	// Count Amount of Items contained in List
+	Count ListItemCount     `json:"count"`
+	Items []BatchItemResult `json:"items"`
+}
+
+// Change a write to a module
+type Change struct {
+	// Cursor Cursor of the Change, passing it as since lists the Changes after it
+	Cursor string `json:"cursor"`
+
+	// Device Device ID is the unique identifier for a remote device
+	Device DeviceID `json:"device"`
+
+	// ModifiedAt A Timestamp indicating when a datum was last modified
+	ModifiedAt ModifiedAtTimestamp `json:"modifiedAt"`
+
+	// Name Module Name, modules can only be written with names matching the pattern
+	Name      ModuleName      `json:"name"`
+	Operation ChangeOperation `json:"operation"`
+}
+
+// ChangeList changes of modules, oldest first
+type ChangeList struct {
+	// Count Amount of Items contained in List
+	Count ListItemCount `json:"count"`
+
+	// Cursor Cursor to pass as since to list later Changes, not present if no Changes were ever listed
+	Cursor *string  `json:"cursor,omitempty"`
+	Items  []Change `json:"items"`
+}
+
+// ChangeOperation defines model for ChangeOperation.
+type ChangeOperation string
+
 // Device a device
 type Device struct {
+	// ClientVersion the client version the device reported during registration
+	ClientVersion *string `json:"clientVersion,omitempty"`
+
 	// Id Device ID is the unique identifier for a remote device
 	Id DeviceID `json:"id"`
+
+	// Ip the remote address the device registered from
+	Ip *string `json:"ip,omitempty"`
+
+	// LastSeenAt when the device last authenticated against the server
+	LastSeenAt *time.Time `json:"lastSeenAt,omitempty"`
+
+	// Name Human-readable name of a device
+	Name *DeviceName `json:"name,omitempty"`
+
+	// Online whether the device is currently connected over a WebSocket
+	Online *bool `json:"online,omitempty"`
+
+	// Platform the platform the device reported during registration
+	Platform *string `json:"platform,omitempty"`
+
+	// RegisteredAt when the device was first registered
+	RegisteredAt *time.Time `json:"registeredAt,omitempty"`
+
+	// Status Whether a device has access to the account or still awaits approval
+	Status DeviceStatus `json:"status"`
 }
 
 // DeviceID Device ID is the unique identifier for a remote device
@@ -48,6 +198,38 @@ type DeviceList struct {
 	Items []Device `json:"items"`
 }
 
+// DeviceName Human-readable name of a device
+type DeviceName = string
+
+// DeviceStatus Whether a device has access to the account or still awaits approval
+type DeviceStatus string
+
+// DeviceUpdate mutable attributes of a device
+type DeviceUpdate struct {
+	// Name Human-readable name of a device
+	Name *DeviceName `json:"name,omitempty"`
+}
+
+// Event a change in an account
+type Event struct {
+	// At When the Event happened
+	At time.Time `json:"at"`
+
+	// Cursor Change Cursor of module-changed Events
+	Cursor *string `json:"cursor,omitempty"`
+
+	// Device Device ID is the unique identifier for a remote device
+	Device DeviceID `json:"device"`
+
+	// Module Module Name, modules can only be written with names matching the pattern
+	Module    *ModuleName      `json:"module,omitempty"`
+	Operation *ChangeOperation `json:"operation,omitempty"`
+	Type      EventType        `json:"type"`
+}
+
+// EventType defines model for EventType.
+type EventType string
+
 // HealthAggregation defines model for HealthAggregation.
 type HealthAggregation struct {
 	// Components The different Components of the Server
@@ -69,6 +251,47 @@ type HealthAggregationComponent struct {
 // HealthResult A Health Check Result
 type HealthResult string
 
+// JSONMergePatch a JSON Merge Patch (RFC 7396), members set to null are removed from the Module
+type JSONMergePatch = interface{}
+
+// JSONPatch a JSON Patch (RFC 6902), its operations are applied in order and all or none of them are applied
+type JSONPatch = []JSONPatchOperation
+
+// JSONPatchOperation defines model for JSONPatchOperation.
+type JSONPatchOperation struct {
+	// From JSON Pointer to the value moved or copied
+	From *string              `json:"from,omitempty"`
+	Op   JSONPatchOperationOp `json:"op"`
+
+	// Path JSON Pointer to the value the operation applies to
+	Path string `json:"path"`
+
+	// Value value to add, replace or test
+	Value *interface{} `json:"value,omitempty"`
+}
+
+// JSONPatchOperationOp defines model for JSONPatchOperation.Op.
+type JSONPatchOperationOp string
+
+// LWWMap state of a last-writer-wins element map, every key holds the entry with the latest timestamp.
+// Ties are broken by the device, removals and then the value.
+type LWWMap map[string]LWWMapEntry
+
+// LWWMapEntry defines model for LWWMapEntry.
+type LWWMapEntry struct {
+	// Deleted removed keys are kept so that the removal wins over older writes
+	Deleted *bool `json:"deleted,omitempty"`
+
+	// Device device that wrote the entry
+	Device *string `json:"device,omitempty"`
+
+	// Timestamp orders the writes of the key, e.g. the unix milliseconds when the device changed the key
+	Timestamp int64 `json:"timestamp"`
+
+	// Value value of the key, not present if the key was removed
+	Value *interface{} `json:"value,omitempty"`
+}
+
 // ListItemCount Amount of Items contained in List
 type ListItemCount = int
 
@@ -78,38 +301,341 @@ type ModifiedAtTimestamp = time.Time
 // ModuleDataStream Module Data Stream
 type ModuleDataStream = openapi_types.File
 
-// ModuleName Module Name
+// ModuleInfo a stored module without its data
+type ModuleInfo struct {
+	// ContentType Content-Type the Module Data was written with, not present if it was written without one
+	ContentType *string `json:"contentType,omitempty"`
+
+	// Device Device ID is the unique identifier for a remote device
+	Device DeviceID `json:"device"`
+
+	// ExpiresAt When the Module expires, not present if the Module does not expire
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	// ModifiedAt A Timestamp indicating when a datum was last modified
+	ModifiedAt *ModifiedAtTimestamp `json:"modifiedAt,omitempty"`
+
+	// Name Module Name, modules can only be written with names matching the pattern
+	Name ModuleName `json:"name"`
+
+	// Size Size of the Module Data in Bytes
+	Size int64 `json:"size"`
+
+	// Type type of a CRDT module whose writes are merged, modules without a type are replaced by writes
+	Type *ModuleType `json:"type,omitempty"`
+}
+
+// ModuleList page of modules
+type ModuleList struct {
+	// Count Amount of Items contained in List
+	Count ListItemCount `json:"count"`
+	Items []ModuleInfo  `json:"items"`
+
+	// Next Cursor for the next Page, not present on the last Page
+	Next *string `json:"next,omitempty"`
+}
+
+// ModuleName Module Name, modules can only be written with names matching the pattern
 type ModuleName = string
 
+// ModuleType type of a CRDT module whose writes are merged, modules without a type are replaced by writes
+type ModuleType string
+
+// ModuleVersion a retained version of a module
+type ModuleVersion struct {
+	// Etag ETag of the Module Data of the Version
+	Etag *string `json:"etag,omitempty"`
+
+	// ModifiedAt A Timestamp indicating when a datum was last modified
+	ModifiedAt ModifiedAtTimestamp `json:"modifiedAt"`
+
+	// Size Size of the Module Data in Bytes
+	Size int64 `json:"size"`
+
+	// Version Version of a Module, increased with every write
+	Version ModuleVersionNumber `json:"version"`
+}
+
+// ModuleVersionList list of module versions, newest first
+type ModuleVersionList struct {
+	// Count Amount of Items contained in List
+	Count ListItemCount   `json:"count"`
+	Items []ModuleVersion `json:"items"`
+}
+
+// ModuleVersionNumber Version of a Module, increased with every write
+type ModuleVersionNumber = int64
+
+// Quota limits of the storage of an account, limits that are not present are not enforced
+type Quota struct {
+	// Bytes Maximum size of the Data of all Modules
+	Bytes *int64 `json:"bytes,omitempty"`
+
+	// Devices Maximum amount of Devices
+	Devices *int64 `json:"devices,omitempty"`
+
+	// Modules Maximum amount of Modules
+	Modules *int64 `json:"modules,omitempty"`
+}
+
+// QuotaExceeded a write that was rejected as it would exceed a quota
+type QuotaExceeded struct {
+	// Limit Quota of the Resource
+	Limit   int64  `json:"limit"`
+	Message string `json:"message"`
+
+	// Requested Usage the Write would add to the Resource
+	Requested int64         `json:"requested"`
+	Resource  QuotaResource `json:"resource"`
+
+	// Usage Usage of the Resource before the Write
+	Usage int64 `json:"usage"`
+}
+
+// QuotaResource defines model for QuotaResource.
+type QuotaResource string
+
 // RegistrationResult defines model for RegistrationResult.
 type RegistrationResult struct {
 	Password string `json:"password"`
 	Username string `json:"username"`
 }
 
+// Schema a JSON Schema that the data of modules with a matching name has to validate against
+type Schema struct {
+	// Name name of the schema, the file it was loaded from without extension
+	Name string `json:"name"`
+
+	// Pattern pattern of the module names the schema applies to, * matches any sequence of characters
+	Pattern string `json:"pattern"`
+
+	// Schema the JSON Schema document
+	Schema map[string]interface{} `json:"schema"`
+}
+
+// SchemaList list of registered schemas
+type SchemaList struct {
+	// Count Amount of Items contained in List
+	Count ListItemCount `json:"count"`
+	Items []Schema      `json:"items"`
+}
+
+// SchemaValidationFailed a write that was rejected as its data does not validate against the schema of the module
+type SchemaValidationFailed struct {
+	Message string `json:"message"`
+
+	// Schema name of the schema the data was validated against
+	Schema     string            `json:"schema"`
+	Violations []SchemaViolation `json:"violations"`
+}
+
+// SchemaViolation a part of module data that does not validate against its schema
+type SchemaViolation struct {
+	// InstanceLocation JSON Pointer to the invalid value in the module data
+	InstanceLocation string `json:"instanceLocation"`
+
+	// KeywordLocation JSON Pointer to the schema keyword the value violates
+	KeywordLocation string `json:"keywordLocation"`
+	Message         string `json:"message"`
+}
+
 // ShareResponse defines model for ShareResponse.
 type ShareResponse struct {
 	ShareCode *string `json:"shareCode,omitempty"`
 }
 
+// Upload a resumable upload of module data
+type Upload struct {
+	// ExpiresAt When the Upload is dropped if it is not finished
+	ExpiresAt time.Time `json:"expiresAt"`
+
+	// Id Identifier of the Upload
+	Id string `json:"id"`
+
+	// Length Size of the complete Module Data in Bytes
+	Length int64 `json:"length"`
+
+	// Name Module Name, modules can only be written with names matching the pattern
+	Name ModuleName `json:"name"`
+
+	// Offset Amount of Bytes received so far
+	Offset int64 `json:"offset"`
+}
+
+// WebSocketMessage a message sent over the websocket sync channel
+type WebSocketMessage struct {
+	// ContentEncoding Content-Encoding of the Module Data of put requests and acks of get requests,
+	// the Data is stored and returned as is
+	ContentEncoding *string `json:"contentEncoding,omitempty"`
+
+	// ContentType Content-Type of the Module Data of put requests and acks of get requests
+	ContentType *string `json:"contentType,omitempty"`
+
+	// Data Module Data of put requests and acks of get requests
+	Data *[]byte `json:"data,omitempty"`
+
+	// Device Device ID is the unique identifier for a remote device
+	Device *DeviceID `json:"device,omitempty"`
+
+	// Etag ETag of the Module written or read
+	Etag *string `json:"etag,omitempty"`
+
+	// Event a change in an account
+	Event *Event `json:"event,omitempty"`
+
+	// ExpiresIn Seconds after which the Module of put requests expires, like X-Expires-In
+	ExpiresIn *int `json:"expiresIn,omitempty"`
+
+	// Id Identifier of a request chosen by the client, repeated in the ack of the request
+	Id *string `json:"id,omitempty"`
+
+	// IfMatch Only write the Module if its ETag matches, like If-Match
+	IfMatch *string `json:"ifMatch,omitempty"`
+
+	// Message Reason of failed requests
+	Message *string `json:"message,omitempty"`
+
+	// Module Module Name, modules can only be written with names matching the pattern
+	Module *ModuleName `json:"module,omitempty"`
+
+	// Status Result of the request as HTTP Status Code
+	Status *int                 `json:"status,omitempty"`
+	Type   WebSocketMessageType `json:"type"`
+}
+
+// WebSocketMessageType defines model for WebSocketMessageType.
+type WebSocketMessageType string
+
+// CursorQuery defines model for CursorQuery.
+type CursorQuery = string
+
+// DeviceIDPath Device ID is the unique identifier for a remote device
+type DeviceIDPath = DeviceID
+
 // DeviceIDQuery Device ID is the unique identifier for a remote device
 type DeviceIDQuery = DeviceID
 
+// DeviceStatusQuery Whether a device has access to the account or still awaits approval
+type DeviceStatusQuery = DeviceStatus
+
+// IfMatch defines model for IfMatch.
+type IfMatch = string
+
+// IfModifiedSince defines model for IfModifiedSince.
+type IfModifiedSince = string
+
+// IfNoneMatch defines model for IfNoneMatch.
+type IfNoneMatch = string
+
+// IfRange defines model for IfRange.
+type IfRange = string
+
+// IfUnmodifiedSince defines model for IfUnmodifiedSince.
+type IfUnmodifiedSince = string
+
+// LimitQuery defines model for LimitQuery.
+type LimitQuery = int
+
+// ModulePrefixQuery defines model for ModulePrefixQuery.
+type ModulePrefixQuery = string
+
+// ModuleVersionPath Version of a Module, increased with every write
+type ModuleVersionPath = ModuleVersionNumber
+
+// Range defines model for Range.
+type Range = string
+
 // ShareCode defines model for ShareCode.
 type ShareCode = string
 
+// SinceQuery defines model for SinceQuery.
+type SinceQuery = string
+
+// UploadChecksum defines model for UploadChecksum.
+type UploadChecksum = string
+
+// UploadIDPath defines model for UploadIDPath.
+type UploadIDPath = string
+
+// UploadLength defines model for UploadLength.
+type UploadLength = int64
+
+// UploadMetadata defines model for UploadMetadata.
+type UploadMetadata = string
+
+// UploadOffset defines model for UploadOffset.
+type UploadOffset = int64
+
+// XClientVersion defines model for XClientVersion.
+type XClientVersion = string
+
 // XDeviceID Device ID is the unique identifier for a remote device
 type XDeviceID = DeviceID
 
+// XDeviceName Human-readable name of a device
+type XDeviceName = DeviceName
+
+// XDevicePlatform defines model for XDevicePlatform.
+type XDevicePlatform = string
+
+// XExpiresIn defines model for XExpiresIn.
+type XExpiresIn = int
+
+// AccountUsageResponse storage used by an account
+type AccountUsageResponse = AccountUsage
+
+// BatchResultResponse results of a batch, in the order of the request
+type BatchResultResponse = BatchResult
+
+// ChangeListResponse changes of modules, oldest first
+type ChangeListResponse = ChangeList
+
 // DeviceListResponse list of devices
 type DeviceListResponse = DeviceList
 
+// DeviceResponse a device
+type DeviceResponse = Device
+
 // ModuleDataAccepted An Empty JSON
 type ModuleDataAccepted = interface{}
 
 // ModuleDeletionAccepted An Empty JSON
 type ModuleDeletionAccepted = interface{}
 
+// ModuleListResponse page of modules
+type ModuleListResponse = ModuleList
+
+// ModuleTooLarge a write that was rejected as it would exceed a quota
+type ModuleTooLarge = QuotaExceeded
+
+// ModuleVersionListResponse list of module versions, newest first
+type ModuleVersionListResponse = ModuleVersionList
+
+// SchemaListResponse list of registered schemas
+type SchemaListResponse = SchemaList
+
+// UploadCreated a resumable upload of module data
+type UploadCreated = Upload
+
+// UploadResponse a resumable upload of module data
+type UploadResponse = Upload
+
+// WebSocketResponse a message sent over the websocket sync channel
+type WebSocketResponse = WebSocketMessage
+
+// BatchGetRequest modules to read in a batch
+type BatchGetRequest = BatchGet
+
+// DeviceUpdateRequest mutable attributes of a device
+type DeviceUpdateRequest = DeviceUpdate
+
+// GetAccountUsageParams defines parameters for GetAccountUsage.
+type GetAccountUsageParams struct {
+	// XDeviceID Unique Identifier of the calling Device. If calling Data endpoints, must be presented in order
+	// to be properly authenticated.
+	XDeviceID XDeviceID `json:"X-Device-ID"`
+}
+
 // RegisterParams defines parameters for Register.
 type RegisterParams struct {
 	// Share The Share Code from the Share API. If presented in combination with a new Device ID,
@@ -119,6 +645,16 @@ type RegisterParams struct {
 	// XDeviceID Unique Identifier of the calling Device. If calling Data endpoints, must be presented in order
 	// to be properly authenticated.
 	XDeviceID XDeviceID `json:"X-Device-ID"`
+
+	// XDeviceName Human-readable name of the registering Device. If omitted during a re-registration,
+	// the previously registered name is kept.
+	XDeviceName *XDeviceName `json:"X-Device-Name,omitempty"`
+
+	// XDevicePlatform Platform (e.g. Operating System) of the registering Device
+	XDevicePlatform *XDevicePlatform `json:"X-Device-Platform,omitempty"`
+
+	// XClientVersion Version of the Client Software used on the registering Device
+	XClientVersion *XClientVersion `json:"X-Client-Version,omitempty"`
 }
 
 // ShareParams defines parameters for Share.
@@ -128,8 +664,52 @@ type ShareParams struct {
 	XDeviceID XDeviceID `json:"X-Device-ID"`
 }
 
+// GetChangesParams defines parameters for GetChanges.
+type GetChangesParams struct {
+	// Since Cursor returned by the previous Listing of Changes
+	Since *SinceQuery `form:"since,omitempty" json:"since,omitempty"`
+
+	// Limit Maximum amount of Items on a Page
+	Limit *LimitQuery `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// XDeviceID Unique Identifier of the calling Device. If calling Data endpoints, must be presented in order
+	// to be properly authenticated.
+	XDeviceID XDeviceID `json:"X-Device-ID"`
+}
+
 // GetDevicesParams defines parameters for GetDevices.
 type GetDevicesParams struct {
+	// Status Only list Devices with the given Status
+	Status *DeviceStatusQuery `form:"status,omitempty" json:"status,omitempty"`
+
+	// XDeviceID Unique Identifier of the calling Device. If calling Data endpoints, must be presented in order
+	// to be properly authenticated.
+	XDeviceID XDeviceID `json:"X-Device-ID"`
+}
+
+// UpdateDeviceParams defines parameters for UpdateDevice.
+type UpdateDeviceParams struct {
+	// XDeviceID Unique Identifier of the calling Device. If calling Data endpoints, must be presented in order
+	// to be properly authenticated.
+	XDeviceID XDeviceID `json:"X-Device-ID"`
+}
+
+// ApproveDeviceParams defines parameters for ApproveDevice.
+type ApproveDeviceParams struct {
+	// XDeviceID Unique Identifier of the calling Device. If calling Data endpoints, must be presented in order
+	// to be properly authenticated.
+	XDeviceID XDeviceID `json:"X-Device-ID"`
+}
+
+// RejectDeviceParams defines parameters for RejectDevice.
+type RejectDeviceParams struct {
+	// XDeviceID Unique Identifier of the calling Device. If calling Data endpoints, must be presented in order
+	// to be properly authenticated.
+	XDeviceID XDeviceID `json:"X-Device-ID"`
+}
+
+// GetEventsParams defines parameters for GetEvents.
+type GetEventsParams struct {
 	// XDeviceID Unique Identifier of the calling Device. If calling Data endpoints, must be presented in order
 	// to be properly authenticated.
 	XDeviceID XDeviceID `json:"X-Device-ID"`
@@ -146,70 +726,1368 @@ type DeleteModulesParams struct {
 	XDeviceID XDeviceID `json:"X-Device-ID"`
 }
 
+// ListModulesParams defines parameters for ListModules.
+type ListModulesParams struct {
+	// DeviceId Device Identifier to use for the Query. If given, takes precedence over X-Device-ID or other hints.
+	// Use to query data from devices in your account from another account.
+	DeviceId *DeviceIDQuery `form:"device-id,omitempty" json:"device-id,omitempty"`
+
+	// Prefix Only list Modules whose Name starts with the given Prefix
+	Prefix *ModulePrefixQuery `form:"prefix,omitempty" json:"prefix,omitempty"`
+
+	// Cursor Cursor returned as next by the previous Page of a Listing
+	Cursor *CursorQuery `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Limit Maximum amount of Items on a Page
+	Limit *LimitQuery `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// XDeviceID Unique Identifier of the calling Device. If calling Data endpoints, must be presented in order
+	// to be properly authenticated.
+	XDeviceID XDeviceID `json:"X-Device-ID"`
+}
+
+// DeleteModuleParams defines parameters for DeleteModule.
+type DeleteModuleParams struct {
+	// XDeviceID Unique Identifier of the calling Device. If calling Data endpoints, must be presented in order
+	// to be properly authenticated.
+	XDeviceID XDeviceID `json:"X-Device-ID"`
+
+	// IfMatch Only update the Module if its ETag matches any of the given ETags, * requires the Module to exist.
+	// Use the ETag of the last read to avoid overwriting changes of other Devices.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+
+	// IfUnmodifiedSince Only update the Module if it was not modified after the given HTTP Date
+	IfUnmodifiedSince *IfUnmodifiedSince `json:"If-Unmodified-Since,omitempty"`
+}
+
 // GetModuleParams defines parameters for GetModule.
 type GetModuleParams struct {
 	// DeviceId Device Identifier to use for the Query. If given, takes precedence over X-Device-ID or other hints.
 	// Use to query data from devices in your account from another account.
 	DeviceId *DeviceIDQuery `form:"device-id,omitempty" json:"device-id,omitempty"`
 
-	// XDeviceID Unique Identifier of the calling Device. If calling Data endpoints, must be presented in order
-	// to be properly authenticated.
-	XDeviceID XDeviceID `json:"X-Device-ID"`
-}
+	// XDeviceID Unique Identifier of the calling Device. If calling Data endpoints, must be presented in order
+	// to be properly authenticated.
+	XDeviceID XDeviceID `json:"X-Device-ID"`
+
+	// IfNoneMatch Only return the Module if its ETag does not match any of the given ETags
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+
+	// IfModifiedSince Only return the Module if it was modified after the given HTTP Date
+	IfModifiedSince *IfModifiedSince `json:"If-Modified-Since,omitempty"`
+
+	// Range Only return the given byte Range of the Module Data, e.g. bytes=1024- to resume a download
+	Range *Range `json:"Range,omitempty"`
+
+	// IfRange Only return the Range if the Module still has the given ETag or HTTP Date of its last modification,
+	// otherwise the whole Module Data is returned
+	IfRange *IfRange `json:"If-Range,omitempty"`
+}
+
+// HeadModuleParams defines parameters for HeadModule.
+type HeadModuleParams struct {
+	// DeviceId Device Identifier to use for the Query. If given, takes precedence over X-Device-ID or other hints.
+	// Use to query data from devices in your account from another account.
+	DeviceId *DeviceIDQuery `form:"device-id,omitempty" json:"device-id,omitempty"`
+
+	// XDeviceID Unique Identifier of the calling Device. If calling Data endpoints, must be presented in order
+	// to be properly authenticated.
+	XDeviceID XDeviceID `json:"X-Device-ID"`
+
+	// IfNoneMatch Only return the Module if its ETag does not match any of the given ETags
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+
+	// IfModifiedSince Only return the Module if it was modified after the given HTTP Date
+	IfModifiedSince *IfModifiedSince `json:"If-Modified-Since,omitempty"`
+}
+
+// CreateModuleParams defines parameters for CreateModule.
+type CreateModuleParams struct {
+	// XDeviceID Unique Identifier of the calling Device. If calling Data endpoints, must be presented in order
+	// to be properly authenticated.
+	XDeviceID XDeviceID `json:"X-Device-ID"`
+
+	// IfMatch Only update the Module if its ETag matches any of the given ETags, * requires the Module to exist.
+	// Use the ETag of the last read to avoid overwriting changes of other Devices.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+
+	// IfUnmodifiedSince Only update the Module if it was not modified after the given HTTP Date
+	IfUnmodifiedSince *IfUnmodifiedSince `json:"If-Unmodified-Since,omitempty"`
+
+	// XExpiresIn Seconds after which the written Module expires together with its Metadata and History.
+	// Capped by the maximum of the Server, which decides the Expiry of the Module if omitted.
+	// Every Write of a Module starts its Expiry anew.
+	XExpiresIn *XExpiresIn `json:"X-Expires-In,omitempty"`
+}
+
+// CreateUploadParams defines parameters for CreateUpload.
+type CreateUploadParams struct {
+	// XDeviceID Unique Identifier of the calling Device. If calling Data endpoints, must be presented in order
+	// to be properly authenticated.
+	XDeviceID XDeviceID `json:"X-Device-ID"`
+
+	// UploadLength Size of the complete Module Data in Bytes
+	UploadLength UploadLength `json:"Upload-Length"`
+
+	// UploadMetadata Comma separated pairs of key and base64 encoded value describing the Module Data, like in the tus protocol.
+	// The filetype is stored as Content-Type of the Module and the filename as its Filename.
+	UploadMetadata *UploadMetadata `json:"Upload-Metadata,omitempty"`
+}
+
+// GetModuleVersionsParams defines parameters for GetModuleVersions.
+type GetModuleVersionsParams struct {
+	// DeviceId Device Identifier to use for the Query. If given, takes precedence over X-Device-ID or other hints.
+	// Use to query data from devices in your account from another account.
+	DeviceId *DeviceIDQuery `form:"device-id,omitempty" json:"device-id,omitempty"`
+
+	// XDeviceID Unique Identifier of the calling Device. If calling Data endpoints, must be presented in order
+	// to be properly authenticated.
+	XDeviceID XDeviceID `json:"X-Device-ID"`
+}
+
+// GetModuleVersionParams defines parameters for GetModuleVersion.
+type GetModuleVersionParams struct {
+	// DeviceId Device Identifier to use for the Query. If given, takes precedence over X-Device-ID or other hints.
+	// Use to query data from devices in your account from another account.
+	DeviceId *DeviceIDQuery `form:"device-id,omitempty" json:"device-id,omitempty"`
+
+	// XDeviceID Unique Identifier of the calling Device. If calling Data endpoints, must be presented in order
+	// to be properly authenticated.
+	XDeviceID XDeviceID `json:"X-Device-ID"`
+}
+
+// RestoreModuleVersionParams defines parameters for RestoreModuleVersion.
+type RestoreModuleVersionParams struct {
+	// XDeviceID Unique Identifier of the calling Device. If calling Data endpoints, must be presented in order
+	// to be properly authenticated.
+	XDeviceID XDeviceID `json:"X-Device-ID"`
+
+	// IfMatch Only update the Module if its ETag matches any of the given ETags, * requires the Module to exist.
+	// Use the ETag of the last read to avoid overwriting changes of other Devices.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+
+	// IfUnmodifiedSince Only update the Module if it was not modified after the given HTTP Date
+	IfUnmodifiedSince *IfUnmodifiedSince `json:"If-Unmodified-Since,omitempty"`
+
+	// XExpiresIn Seconds after which the written Module expires together with its Metadata and History.
+	// Capped by the maximum of the Server, which decides the Expiry of the Module if omitted.
+	// Every Write of a Module starts its Expiry anew.
+	XExpiresIn *XExpiresIn `json:"X-Expires-In,omitempty"`
+}
+
+// BatchGetModulesParams defines parameters for BatchGetModules.
+type BatchGetModulesParams struct {
+	// XDeviceID Unique Identifier of the calling Device. If calling Data endpoints, must be presented in order
+	// to be properly authenticated.
+	XDeviceID XDeviceID `json:"X-Device-ID"`
+}
+
+// BatchSetModulesParams defines parameters for BatchSetModules.
+type BatchSetModulesParams struct {
+	// XDeviceID Unique Identifier of the calling Device. If calling Data endpoints, must be presented in order
+	// to be properly authenticated.
+	XDeviceID XDeviceID `json:"X-Device-ID"`
+
+	// XExpiresIn Seconds after which the written Module expires together with its Metadata and History.
+	// Capped by the maximum of the Server, which decides the Expiry of the Module if omitted.
+	// Every Write of a Module starts its Expiry anew.
+	XExpiresIn *XExpiresIn `json:"X-Expires-In,omitempty"`
+}
+
+// DeleteUploadParams defines parameters for DeleteUpload.
+type DeleteUploadParams struct {
+	// XDeviceID Unique Identifier of the calling Device. If calling Data endpoints, must be presented in order
+	// to be properly authenticated.
+	XDeviceID XDeviceID `json:"X-Device-ID"`
+}
+
+// GetUploadParams defines parameters for GetUpload.
+type GetUploadParams struct {
+	// XDeviceID Unique Identifier of the calling Device. If calling Data endpoints, must be presented in order
+	// to be properly authenticated.
+	XDeviceID XDeviceID `json:"X-Device-ID"`
+}
+
+// AppendUploadParams defines parameters for AppendUpload.
+type AppendUploadParams struct {
+	// XDeviceID Unique Identifier of the calling Device. If calling Data endpoints, must be presented in order
+	// to be properly authenticated.
+	XDeviceID XDeviceID `json:"X-Device-ID"`
+
+	// UploadOffset Offset of the chunk within the Module Data, has to be the Offset of the Upload
+	UploadOffset UploadOffset `json:"Upload-Offset"`
+}
+
+// FinalizeUploadParams defines parameters for FinalizeUpload.
+type FinalizeUploadParams struct {
+	// XDeviceID Unique Identifier of the calling Device. If calling Data endpoints, must be presented in order
+	// to be properly authenticated.
+	XDeviceID XDeviceID `json:"X-Device-ID"`
+
+	// UploadChecksum Checksum of the complete Module Data as algorithm and base64 encoded digest, e.g. sha256 <digest>
+	UploadChecksum *UploadChecksum `json:"Upload-Checksum,omitempty"`
+
+	// IfMatch Only update the Module if its ETag matches any of the given ETags, * requires the Module to exist.
+	// Use the ETag of the last read to avoid overwriting changes of other Devices.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+
+	// IfUnmodifiedSince Only update the Module if it was not modified after the given HTTP Date
+	IfUnmodifiedSince *IfUnmodifiedSince `json:"If-Unmodified-Since,omitempty"`
+
+	// XExpiresIn Seconds after which the written Module expires together with its Metadata and History.
+	// Capped by the maximum of the Server, which decides the Expiry of the Module if omitted.
+	// Every Write of a Module starts its Expiry anew.
+	XExpiresIn *XExpiresIn `json:"X-Expires-In,omitempty"`
+}
+
+// ConnectWebSocketParams defines parameters for ConnectWebSocket.
+type ConnectWebSocketParams struct {
+	// XDeviceID Unique Identifier of the calling Device. If calling Data endpoints, must be presented in order
+	// to be properly authenticated.
+	XDeviceID XDeviceID `json:"X-Device-ID"`
+}
+
+// UpdateDeviceJSONRequestBody defines body for UpdateDevice for application/json ContentType.
+type UpdateDeviceJSONRequestBody = DeviceUpdate
+
+// CreateModuleApplicationJSONPatchPlusJSONRequestBody defines body for CreateModule for application/json-patch+json ContentType.
+type CreateModuleApplicationJSONPatchPlusJSONRequestBody = JSONPatch
+
+// CreateModuleApplicationMergePatchPlusJSONRequestBody defines body for CreateModule for application/merge-patch+json ContentType.
+type CreateModuleApplicationMergePatchPlusJSONRequestBody = JSONMergePatch
+
+// CreateModuleApplicationVndOctiLwwMapPlusJSONRequestBody defines body for CreateModule for application/vnd.octi.lww-map+json ContentType.
+type CreateModuleApplicationVndOctiLwwMapPlusJSONRequestBody = LWWMap
+
+// BatchGetModulesJSONRequestBody defines body for BatchGetModules for application/json ContentType.
+type BatchGetModulesJSONRequestBody = BatchGet
+
+// BatchSetModulesMultipartRequestBody defines body for BatchSetModules for multipart/mixed ContentType.
+type BatchSetModulesMultipartRequestBody = ModuleDataStream
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Get the Storage used by your Account
+	// (GET /account/usage)
+	GetAccountUsage(ctx echo.Context, params GetAccountUsageParams) error
+	// Register A Device
+	// (POST /auth/register)
+	Register(ctx echo.Context, params RegisterParams) error
+	// Share your Account
+	// (POST /auth/share)
+	Share(ctx echo.Context, params ShareParams) error
+	// List Module Changes of your Account
+	// (GET /changes)
+	GetChanges(ctx echo.Context, params GetChangesParams) error
+	// Get All registered Devices for your Account
+	// (GET /devices)
+	GetDevices(ctx echo.Context, params GetDevicesParams) error
+	// Update a registered Device
+	// (PATCH /devices/{id})
+	UpdateDevice(ctx echo.Context, id DeviceIDPath, params UpdateDeviceParams) error
+	// Approve a pending Device
+	// (POST /devices/{id}/approve)
+	ApproveDevice(ctx echo.Context, id DeviceIDPath, params ApproveDeviceParams) error
+	// Reject a pending Device
+	// (POST /devices/{id}/reject)
+	RejectDevice(ctx echo.Context, id DeviceIDPath, params RejectDeviceParams) error
+	// Subscribe to Events of your Account
+	// (GET /events)
+	GetEvents(ctx echo.Context, params GetEventsParams) error
+	// Checks if the Service is Available for Processing Request
+	// (GET /health)
+	IsHealthy(ctx echo.Context) error
+	// Clears Module Data for a Device
+	// (DELETE /module)
+	DeleteModules(ctx echo.Context, params DeleteModulesParams) error
+	// List Modules of a Device
+	// (GET /module)
+	ListModules(ctx echo.Context, params ListModulesParams) error
+	// Delete a single Module
+	// (DELETE /module/{name})
+	DeleteModule(ctx echo.Context, name ModuleName, params DeleteModuleParams) error
+	// Get Module Data
+	// (GET /module/{name})
+	GetModule(ctx echo.Context, name ModuleName, params GetModuleParams) error
+	// Get Module Information
+	// (HEAD /module/{name})
+	HeadModule(ctx echo.Context, name ModuleName, params HeadModuleParams) error
+	// Create/Update Module Data
+	// (POST /module/{name})
+	CreateModule(ctx echo.Context, name ModuleName, params CreateModuleParams) error
+	// Start a resumable Upload of Module Data
+	// (POST /module/{name}/uploads)
+	CreateUpload(ctx echo.Context, name ModuleName, params CreateUploadParams) error
+	// List Module Versions
+	// (GET /module/{name}/versions)
+	GetModuleVersions(ctx echo.Context, name ModuleName, params GetModuleVersionsParams) error
+	// Get Module Version Data
+	// (GET /module/{name}/versions/{version})
+	GetModuleVersion(ctx echo.Context, name ModuleName, version ModuleVersionPath, params GetModuleVersionParams) error
+	// Restore Module Version
+	// (POST /module/{name}/versions/{version}/restore)
+	RestoreModuleVersion(ctx echo.Context, name ModuleName, version ModuleVersionPath, params RestoreModuleVersionParams) error
+	// Read multiple Modules of a Device at once
+	// (POST /modules:batchGet)
+	BatchGetModules(ctx echo.Context, params BatchGetModulesParams) error
+	// Write multiple Modules of your Device at once
+	// (POST /modules:batchSet)
+	BatchSetModules(ctx echo.Context, params BatchSetModulesParams) error
+	// Checks if the Service is Operational
+	// (GET /ready)
+	IsReady(ctx echo.Context) error
+	// Get the registered Module Schemas
+	// (GET /schemas)
+	GetSchemas(ctx echo.Context) error
+	// Abort an Upload
+	// (DELETE /uploads/{id})
+	DeleteUpload(ctx echo.Context, id UploadIDPath, params DeleteUploadParams) error
+	// Get the Progress of an Upload
+	// (GET /uploads/{id})
+	GetUpload(ctx echo.Context, id UploadIDPath, params GetUploadParams) error
+	// Append a Chunk to an Upload
+	// (PATCH /uploads/{id})
+	AppendUpload(ctx echo.Context, id UploadIDPath, params AppendUploadParams) error
+	// Finish an Upload
+	// (POST /uploads/{id}/finalize)
+	FinalizeUpload(ctx echo.Context, id UploadIDPath, params FinalizeUploadParams) error
+	// Open a WebSocket Sync Channel
+	// (GET /ws)
+	ConnectWebSocket(ctx echo.Context, params ConnectWebSocketParams) error
+}
+
+// ServerInterfaceWrapper converts echo contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler ServerInterface
+}
+
+// GetAccountUsage converts echo context to params.
+func (w *ServerInterfaceWrapper) GetAccountUsage(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(DeviceAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAccountUsageParams
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Device-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Device-ID")]; found {
+		var XDeviceID XDeviceID
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Device-ID, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Device-ID", valueList[0], &XDeviceID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Device-ID: %s", err))
+		}
+
+		params.XDeviceID = XDeviceID
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Device-ID is required, but not found"))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetAccountUsage(ctx, params)
+	return err
+}
+
+// Register converts echo context to params.
+func (w *ServerInterfaceWrapper) Register(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RegisterParams
+	// ------------- Optional query parameter "share" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "share", ctx.QueryParams(), &params.Share)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter share: %s", err))
+	}
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Device-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Device-ID")]; found {
+		var XDeviceID XDeviceID
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Device-ID, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Device-ID", valueList[0], &XDeviceID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Device-ID: %s", err))
+		}
+
+		params.XDeviceID = XDeviceID
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Device-ID is required, but not found"))
+	}
+	// ------------- Optional header parameter "X-Device-Name" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Device-Name")]; found {
+		var XDeviceName XDeviceName
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Device-Name, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Device-Name", valueList[0], &XDeviceName, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Device-Name: %s", err))
+		}
+
+		params.XDeviceName = &XDeviceName
+	}
+	// ------------- Optional header parameter "X-Device-Platform" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Device-Platform")]; found {
+		var XDevicePlatform XDevicePlatform
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Device-Platform, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Device-Platform", valueList[0], &XDevicePlatform, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Device-Platform: %s", err))
+		}
+
+		params.XDevicePlatform = &XDevicePlatform
+	}
+	// ------------- Optional header parameter "X-Client-Version" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Client-Version")]; found {
+		var XClientVersion XClientVersion
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Client-Version, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Client-Version", valueList[0], &XClientVersion, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Client-Version: %s", err))
+		}
+
+		params.XClientVersion = &XClientVersion
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.Register(ctx, params)
+	return err
+}
+
+// Share converts echo context to params.
+func (w *ServerInterfaceWrapper) Share(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(DeviceAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ShareParams
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Device-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Device-ID")]; found {
+		var XDeviceID XDeviceID
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Device-ID, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Device-ID", valueList[0], &XDeviceID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Device-ID: %s", err))
+		}
+
+		params.XDeviceID = XDeviceID
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Device-ID is required, but not found"))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.Share(ctx, params)
+	return err
+}
+
+// GetChanges converts echo context to params.
+func (w *ServerInterfaceWrapper) GetChanges(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(DeviceAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetChangesParams
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "since", ctx.QueryParams(), &params.Since)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter since: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Device-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Device-ID")]; found {
+		var XDeviceID XDeviceID
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Device-ID, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Device-ID", valueList[0], &XDeviceID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Device-ID: %s", err))
+		}
+
+		params.XDeviceID = XDeviceID
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Device-ID is required, but not found"))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetChanges(ctx, params)
+	return err
+}
+
+// GetDevices converts echo context to params.
+func (w *ServerInterfaceWrapper) GetDevices(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(DeviceAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetDevicesParams
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", ctx.QueryParams(), &params.Status)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter status: %s", err))
+	}
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Device-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Device-ID")]; found {
+		var XDeviceID XDeviceID
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Device-ID, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Device-ID", valueList[0], &XDeviceID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Device-ID: %s", err))
+		}
+
+		params.XDeviceID = XDeviceID
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Device-ID is required, but not found"))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetDevices(ctx, params)
+	return err
+}
+
+// UpdateDevice converts echo context to params.
+func (w *ServerInterfaceWrapper) UpdateDevice(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id DeviceIDPath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(DeviceAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdateDeviceParams
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Device-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Device-ID")]; found {
+		var XDeviceID XDeviceID
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Device-ID, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Device-ID", valueList[0], &XDeviceID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Device-ID: %s", err))
+		}
+
+		params.XDeviceID = XDeviceID
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Device-ID is required, but not found"))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.UpdateDevice(ctx, id, params)
+	return err
+}
+
+// ApproveDevice converts echo context to params.
+func (w *ServerInterfaceWrapper) ApproveDevice(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id DeviceIDPath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(DeviceAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ApproveDeviceParams
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Device-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Device-ID")]; found {
+		var XDeviceID XDeviceID
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Device-ID, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Device-ID", valueList[0], &XDeviceID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Device-ID: %s", err))
+		}
+
+		params.XDeviceID = XDeviceID
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Device-ID is required, but not found"))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ApproveDevice(ctx, id, params)
+	return err
+}
+
+// RejectDevice converts echo context to params.
+func (w *ServerInterfaceWrapper) RejectDevice(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id DeviceIDPath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(DeviceAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RejectDeviceParams
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Device-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Device-ID")]; found {
+		var XDeviceID XDeviceID
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Device-ID, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Device-ID", valueList[0], &XDeviceID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Device-ID: %s", err))
+		}
+
+		params.XDeviceID = XDeviceID
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Device-ID is required, but not found"))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RejectDevice(ctx, id, params)
+	return err
+}
+
+// GetEvents converts echo context to params.
+func (w *ServerInterfaceWrapper) GetEvents(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(DeviceAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetEventsParams
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Device-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Device-ID")]; found {
+		var XDeviceID XDeviceID
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Device-ID, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Device-ID", valueList[0], &XDeviceID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Device-ID: %s", err))
+		}
+
+		params.XDeviceID = XDeviceID
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Device-ID is required, but not found"))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetEvents(ctx, params)
+	return err
+}
+
+// IsHealthy converts echo context to params.
+func (w *ServerInterfaceWrapper) IsHealthy(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.IsHealthy(ctx)
+	return err
+}
+
+// DeleteModules converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteModules(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(DeviceAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteModulesParams
+	// ------------- Optional query parameter "device-id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "device-id", ctx.QueryParams(), &params.DeviceId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter device-id: %s", err))
+	}
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Device-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Device-ID")]; found {
+		var XDeviceID XDeviceID
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Device-ID, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Device-ID", valueList[0], &XDeviceID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Device-ID: %s", err))
+		}
+
+		params.XDeviceID = XDeviceID
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Device-ID is required, but not found"))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteModules(ctx, params)
+	return err
+}
+
+// ListModules converts echo context to params.
+func (w *ServerInterfaceWrapper) ListModules(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(DeviceAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListModulesParams
+	// ------------- Optional query parameter "device-id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "device-id", ctx.QueryParams(), &params.DeviceId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter device-id: %s", err))
+	}
+
+	// ------------- Optional query parameter "prefix" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "prefix", ctx.QueryParams(), &params.Prefix)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter prefix: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Device-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Device-ID")]; found {
+		var XDeviceID XDeviceID
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Device-ID, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Device-ID", valueList[0], &XDeviceID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Device-ID: %s", err))
+		}
+
+		params.XDeviceID = XDeviceID
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Device-ID is required, but not found"))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ListModules(ctx, params)
+	return err
+}
+
+// DeleteModule converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteModule(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "name" -------------
+	var name ModuleName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", ctx.Param("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	ctx.Set(DeviceAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteModuleParams
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Device-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Device-ID")]; found {
+		var XDeviceID XDeviceID
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Device-ID, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Device-ID", valueList[0], &XDeviceID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Device-ID: %s", err))
+		}
+
+		params.XDeviceID = XDeviceID
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Device-ID is required, but not found"))
+	}
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Match: %s", err))
+		}
+
+		params.IfMatch = &IfMatch
+	}
+	// ------------- Optional header parameter "If-Unmodified-Since" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Unmodified-Since")]; found {
+		var IfUnmodifiedSince IfUnmodifiedSince
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Unmodified-Since, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Unmodified-Since", valueList[0], &IfUnmodifiedSince, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Unmodified-Since: %s", err))
+		}
+
+		params.IfUnmodifiedSince = &IfUnmodifiedSince
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteModule(ctx, name, params)
+	return err
+}
+
+// GetModule converts echo context to params.
+func (w *ServerInterfaceWrapper) GetModule(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "name" -------------
+	var name ModuleName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", ctx.Param("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	ctx.Set(DeviceAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetModuleParams
+	// ------------- Optional query parameter "device-id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "device-id", ctx.QueryParams(), &params.DeviceId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter device-id: %s", err))
+	}
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Device-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Device-ID")]; found {
+		var XDeviceID XDeviceID
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Device-ID, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Device-ID", valueList[0], &XDeviceID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Device-ID: %s", err))
+		}
+
+		params.XDeviceID = XDeviceID
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Device-ID is required, but not found"))
+	}
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatch
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-None-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-None-Match: %s", err))
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+	}
+	// ------------- Optional header parameter "If-Modified-Since" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Modified-Since")]; found {
+		var IfModifiedSince IfModifiedSince
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Modified-Since, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Modified-Since", valueList[0], &IfModifiedSince, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Modified-Since: %s", err))
+		}
+
+		params.IfModifiedSince = &IfModifiedSince
+	}
+	// ------------- Optional header parameter "Range" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Range")]; found {
+		var Range Range
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for Range, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Range", valueList[0], &Range, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter Range: %s", err))
+		}
+
+		params.Range = &Range
+	}
+	// ------------- Optional header parameter "If-Range" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Range")]; found {
+		var IfRange IfRange
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Range, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Range", valueList[0], &IfRange, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Range: %s", err))
+		}
+
+		params.IfRange = &IfRange
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetModule(ctx, name, params)
+	return err
+}
+
+// HeadModule converts echo context to params.
+func (w *ServerInterfaceWrapper) HeadModule(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "name" -------------
+	var name ModuleName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", ctx.Param("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	ctx.Set(DeviceAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params HeadModuleParams
+	// ------------- Optional query parameter "device-id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "device-id", ctx.QueryParams(), &params.DeviceId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter device-id: %s", err))
+	}
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Device-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Device-ID")]; found {
+		var XDeviceID XDeviceID
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Device-ID, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Device-ID", valueList[0], &XDeviceID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Device-ID: %s", err))
+		}
+
+		params.XDeviceID = XDeviceID
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Device-ID is required, but not found"))
+	}
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatch
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-None-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-None-Match: %s", err))
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+	}
+	// ------------- Optional header parameter "If-Modified-Since" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Modified-Since")]; found {
+		var IfModifiedSince IfModifiedSince
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Modified-Since, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Modified-Since", valueList[0], &IfModifiedSince, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Modified-Since: %s", err))
+		}
+
+		params.IfModifiedSince = &IfModifiedSince
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.HeadModule(ctx, name, params)
+	return err
+}
+
+// CreateModule converts echo context to params.
+func (w *ServerInterfaceWrapper) CreateModule(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "name" -------------
+	var name ModuleName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", ctx.Param("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	ctx.Set(DeviceAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateModuleParams
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Device-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Device-ID")]; found {
+		var XDeviceID XDeviceID
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Device-ID, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Device-ID", valueList[0], &XDeviceID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Device-ID: %s", err))
+		}
+
+		params.XDeviceID = XDeviceID
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Device-ID is required, but not found"))
+	}
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Match: %s", err))
+		}
+
+		params.IfMatch = &IfMatch
+	}
+	// ------------- Optional header parameter "If-Unmodified-Since" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Unmodified-Since")]; found {
+		var IfUnmodifiedSince IfUnmodifiedSince
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Unmodified-Since, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Unmodified-Since", valueList[0], &IfUnmodifiedSince, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Unmodified-Since: %s", err))
+		}
+
+		params.IfUnmodifiedSince = &IfUnmodifiedSince
+	}
+	// ------------- Optional header parameter "X-Expires-In" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Expires-In")]; found {
+		var XExpiresIn XExpiresIn
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Expires-In, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Expires-In", valueList[0], &XExpiresIn, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Expires-In: %s", err))
+		}
+
+		params.XExpiresIn = &XExpiresIn
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.CreateModule(ctx, name, params)
+	return err
+}
+
+// CreateUpload converts echo context to params.
+func (w *ServerInterfaceWrapper) CreateUpload(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "name" -------------
+	var name ModuleName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", ctx.Param("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	ctx.Set(DeviceAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateUploadParams
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Device-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Device-ID")]; found {
+		var XDeviceID XDeviceID
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Device-ID, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Device-ID", valueList[0], &XDeviceID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Device-ID: %s", err))
+		}
+
+		params.XDeviceID = XDeviceID
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Device-ID is required, but not found"))
+	}
+	// ------------- Required header parameter "Upload-Length" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Upload-Length")]; found {
+		var UploadLength UploadLength
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for Upload-Length, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Upload-Length", valueList[0], &UploadLength, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter Upload-Length: %s", err))
+		}
+
+		params.UploadLength = UploadLength
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter Upload-Length is required, but not found"))
+	}
+	// ------------- Optional header parameter "Upload-Metadata" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Upload-Metadata")]; found {
+		var UploadMetadata UploadMetadata
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for Upload-Metadata, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Upload-Metadata", valueList[0], &UploadMetadata, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter Upload-Metadata: %s", err))
+		}
+
+		params.UploadMetadata = &UploadMetadata
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.CreateUpload(ctx, name, params)
+	return err
+}
+
+// GetModuleVersions converts echo context to params.
+func (w *ServerInterfaceWrapper) GetModuleVersions(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "name" -------------
+	var name ModuleName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", ctx.Param("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	ctx.Set(DeviceAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetModuleVersionsParams
+	// ------------- Optional query parameter "device-id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "device-id", ctx.QueryParams(), &params.DeviceId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter device-id: %s", err))
+	}
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Device-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Device-ID")]; found {
+		var XDeviceID XDeviceID
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Device-ID, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Device-ID", valueList[0], &XDeviceID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Device-ID: %s", err))
+		}
+
+		params.XDeviceID = XDeviceID
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Device-ID is required, but not found"))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetModuleVersions(ctx, name, params)
+	return err
+}
+
+// GetModuleVersion converts echo context to params.
+func (w *ServerInterfaceWrapper) GetModuleVersion(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "name" -------------
+	var name ModuleName
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", ctx.Param("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	// ------------- Path parameter "version" -------------
+	var version ModuleVersionPath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "version", ctx.Param("version"), &version, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter version: %s", err))
+	}
+
+	ctx.Set(DeviceAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetModuleVersionParams
+	// ------------- Optional query parameter "device-id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "device-id", ctx.QueryParams(), &params.DeviceId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter device-id: %s", err))
+	}
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Device-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Device-ID")]; found {
+		var XDeviceID XDeviceID
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Device-ID, got %d", n))
+		}
 
-// CreateModuleParams defines parameters for CreateModule.
-type CreateModuleParams struct {
-	// XDeviceID Unique Identifier of the calling Device. If calling Data endpoints, must be presented in order
-	// to be properly authenticated.
-	XDeviceID XDeviceID `json:"X-Device-ID"`
-}
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Device-ID", valueList[0], &XDeviceID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Device-ID: %s", err))
+		}
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// Register A Device
-	// (POST /auth/register)
-	Register(ctx echo.Context, params RegisterParams) error
-	// Share your Account
-	// (POST /auth/share)
-	Share(ctx echo.Context, params ShareParams) error
-	// Get All registered Devices for your Account
-	// (GET /devices)
-	GetDevices(ctx echo.Context, params GetDevicesParams) error
-	// Checks if the Service is Available for Processing Request
-	// (GET /health)
-	IsHealthy(ctx echo.Context) error
-	// Clears Module Data for a Device
-	// (DELETE /module)
-	DeleteModules(ctx echo.Context, params DeleteModulesParams) error
-	// Get Module Data
-	// (GET /module/{name})
-	GetModule(ctx echo.Context, name ModuleName, params GetModuleParams) error
-	// Create/Update Module Data
-	// (POST /module/{name})
-	CreateModule(ctx echo.Context, name ModuleName, params CreateModuleParams) error
-	// Checks if the Service is Operational
-	// (GET /ready)
-	IsReady(ctx echo.Context) error
-}
+		params.XDeviceID = XDeviceID
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Device-ID is required, but not found"))
+	}
 
-// ServerInterfaceWrapper converts echo contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler ServerInterface
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetModuleVersion(ctx, name, version, params)
+	return err
 }
 
-// Register converts echo context to params.
-func (w *ServerInterfaceWrapper) Register(ctx echo.Context) error {
+// RestoreModuleVersion converts echo context to params.
+func (w *ServerInterfaceWrapper) RestoreModuleVersion(ctx echo.Context) error {
 	var err error
+	// ------------- Path parameter "name" -------------
+	var name ModuleName
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params RegisterParams
-	// ------------- Optional query parameter "share" -------------
+	err = runtime.BindStyledParameterWithOptions("simple", "name", ctx.Param("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
 
-	err = runtime.BindQueryParameter("form", true, false, "share", ctx.QueryParams(), &params.Share)
+	// ------------- Path parameter "version" -------------
+	var version ModuleVersionPath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "version", ctx.Param("version"), &version, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter share: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter version: %s", err))
 	}
 
+	ctx.Set(DeviceAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RestoreModuleVersionParams
+
 	headers := ctx.Request().Header
 	// ------------- Required header parameter "X-Device-ID" -------------
 	if valueList, found := headers[http.CanonicalHeaderKey("X-Device-ID")]; found {
@@ -228,20 +2106,65 @@ func (w *ServerInterfaceWrapper) Register(ctx echo.Context) error {
 	} else {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Device-ID is required, but not found"))
 	}
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Match: %s", err))
+		}
+
+		params.IfMatch = &IfMatch
+	}
+	// ------------- Optional header parameter "If-Unmodified-Since" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Unmodified-Since")]; found {
+		var IfUnmodifiedSince IfUnmodifiedSince
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Unmodified-Since, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Unmodified-Since", valueList[0], &IfUnmodifiedSince, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Unmodified-Since: %s", err))
+		}
+
+		params.IfUnmodifiedSince = &IfUnmodifiedSince
+	}
+	// ------------- Optional header parameter "X-Expires-In" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Expires-In")]; found {
+		var XExpiresIn XExpiresIn
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Expires-In, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Expires-In", valueList[0], &XExpiresIn, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Expires-In: %s", err))
+		}
+
+		params.XExpiresIn = &XExpiresIn
+	}
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.Register(ctx, params)
+	err = w.Handler.RestoreModuleVersion(ctx, name, version, params)
 	return err
 }
 
-// Share converts echo context to params.
-func (w *ServerInterfaceWrapper) Share(ctx echo.Context) error {
+// BatchGetModules converts echo context to params.
+func (w *ServerInterfaceWrapper) BatchGetModules(ctx echo.Context) error {
 	var err error
 
 	ctx.Set(DeviceAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params ShareParams
+	var params BatchGetModulesParams
 
 	headers := ctx.Request().Header
 	// ------------- Required header parameter "X-Device-ID" -------------
@@ -263,18 +2186,18 @@ func (w *ServerInterfaceWrapper) Share(ctx echo.Context) error {
 	}
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.Share(ctx, params)
+	err = w.Handler.BatchGetModules(ctx, params)
 	return err
 }
 
-// GetDevices converts echo context to params.
-func (w *ServerInterfaceWrapper) GetDevices(ctx echo.Context) error {
+// BatchSetModules converts echo context to params.
+func (w *ServerInterfaceWrapper) BatchSetModules(ctx echo.Context) error {
 	var err error
 
 	ctx.Set(DeviceAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetDevicesParams
+	var params BatchSetModulesParams
 
 	headers := ctx.Request().Header
 	// ------------- Required header parameter "X-Device-ID" -------------
@@ -294,36 +2217,61 @@ func (w *ServerInterfaceWrapper) GetDevices(ctx echo.Context) error {
 	} else {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Device-ID is required, but not found"))
 	}
+	// ------------- Optional header parameter "X-Expires-In" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Expires-In")]; found {
+		var XExpiresIn XExpiresIn
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Expires-In, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Expires-In", valueList[0], &XExpiresIn, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Expires-In: %s", err))
+		}
+
+		params.XExpiresIn = &XExpiresIn
+	}
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetDevices(ctx, params)
+	err = w.Handler.BatchSetModules(ctx, params)
 	return err
 }
 
-// IsHealthy converts echo context to params.
-func (w *ServerInterfaceWrapper) IsHealthy(ctx echo.Context) error {
+// IsReady converts echo context to params.
+func (w *ServerInterfaceWrapper) IsReady(ctx echo.Context) error {
 	var err error
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.IsHealthy(ctx)
+	err = w.Handler.IsReady(ctx)
 	return err
 }
 
-// DeleteModules converts echo context to params.
-func (w *ServerInterfaceWrapper) DeleteModules(ctx echo.Context) error {
+// GetSchemas converts echo context to params.
+func (w *ServerInterfaceWrapper) GetSchemas(ctx echo.Context) error {
 	var err error
 
-	ctx.Set(DeviceAuthScopes, []string{})
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetSchemas(ctx)
+	return err
+}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params DeleteModulesParams
-	// ------------- Optional query parameter "device-id" -------------
+// DeleteUpload converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteUpload(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id UploadIDPath
 
-	err = runtime.BindQueryParameter("form", true, false, "device-id", ctx.QueryParams(), &params.DeviceId)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter device-id: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
 	}
 
+	ctx.Set(DeviceAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteUploadParams
+
 	headers := ctx.Request().Header
 	// ------------- Required header parameter "X-Device-ID" -------------
 	if valueList, found := headers[http.CanonicalHeaderKey("X-Device-ID")]; found {
@@ -344,32 +2292,66 @@ func (w *ServerInterfaceWrapper) DeleteModules(ctx echo.Context) error {
 	}
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.DeleteModules(ctx, params)
+	err = w.Handler.DeleteUpload(ctx, id, params)
 	return err
 }
 
-// GetModule converts echo context to params.
-func (w *ServerInterfaceWrapper) GetModule(ctx echo.Context) error {
+// GetUpload converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUpload(ctx echo.Context) error {
 	var err error
-	// ------------- Path parameter "name" -------------
-	var name ModuleName
+	// ------------- Path parameter "id" -------------
+	var id UploadIDPath
 
-	err = runtime.BindStyledParameterWithOptions("simple", "name", ctx.Param("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
 	}
 
 	ctx.Set(DeviceAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetModuleParams
-	// ------------- Optional query parameter "device-id" -------------
+	var params GetUploadParams
 
-	err = runtime.BindQueryParameter("form", true, false, "device-id", ctx.QueryParams(), &params.DeviceId)
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Device-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Device-ID")]; found {
+		var XDeviceID XDeviceID
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Device-ID, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Device-ID", valueList[0], &XDeviceID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Device-ID: %s", err))
+		}
+
+		params.XDeviceID = XDeviceID
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Device-ID is required, but not found"))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetUpload(ctx, id, params)
+	return err
+}
+
+// AppendUpload converts echo context to params.
+func (w *ServerInterfaceWrapper) AppendUpload(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id UploadIDPath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter device-id: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
 	}
 
+	ctx.Set(DeviceAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AppendUploadParams
+
 	headers := ctx.Request().Header
 	// ------------- Required header parameter "X-Device-ID" -------------
 	if valueList, found := headers[http.CanonicalHeaderKey("X-Device-ID")]; found {
@@ -388,27 +2370,44 @@ func (w *ServerInterfaceWrapper) GetModule(ctx echo.Context) error {
 	} else {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Device-ID is required, but not found"))
 	}
+	// ------------- Required header parameter "Upload-Offset" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Upload-Offset")]; found {
+		var UploadOffset UploadOffset
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for Upload-Offset, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Upload-Offset", valueList[0], &UploadOffset, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter Upload-Offset: %s", err))
+		}
+
+		params.UploadOffset = UploadOffset
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter Upload-Offset is required, but not found"))
+	}
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetModule(ctx, name, params)
+	err = w.Handler.AppendUpload(ctx, id, params)
 	return err
 }
 
-// CreateModule converts echo context to params.
-func (w *ServerInterfaceWrapper) CreateModule(ctx echo.Context) error {
+// FinalizeUpload converts echo context to params.
+func (w *ServerInterfaceWrapper) FinalizeUpload(ctx echo.Context) error {
 	var err error
-	// ------------- Path parameter "name" -------------
-	var name ModuleName
+	// ------------- Path parameter "id" -------------
+	var id UploadIDPath
 
-	err = runtime.BindStyledParameterWithOptions("simple", "name", ctx.Param("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
 	}
 
 	ctx.Set(DeviceAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params CreateModuleParams
+	var params FinalizeUploadParams
 
 	headers := ctx.Request().Header
 	// ------------- Required header parameter "X-Device-ID" -------------
@@ -428,18 +2427,102 @@ func (w *ServerInterfaceWrapper) CreateModule(ctx echo.Context) error {
 	} else {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Device-ID is required, but not found"))
 	}
+	// ------------- Optional header parameter "Upload-Checksum" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Upload-Checksum")]; found {
+		var UploadChecksum UploadChecksum
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for Upload-Checksum, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Upload-Checksum", valueList[0], &UploadChecksum, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter Upload-Checksum: %s", err))
+		}
+
+		params.UploadChecksum = &UploadChecksum
+	}
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Match: %s", err))
+		}
+
+		params.IfMatch = &IfMatch
+	}
+	// ------------- Optional header parameter "If-Unmodified-Since" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Unmodified-Since")]; found {
+		var IfUnmodifiedSince IfUnmodifiedSince
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Unmodified-Since, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Unmodified-Since", valueList[0], &IfUnmodifiedSince, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Unmodified-Since: %s", err))
+		}
+
+		params.IfUnmodifiedSince = &IfUnmodifiedSince
+	}
+	// ------------- Optional header parameter "X-Expires-In" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Expires-In")]; found {
+		var XExpiresIn XExpiresIn
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Expires-In, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Expires-In", valueList[0], &XExpiresIn, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Expires-In: %s", err))
+		}
+
+		params.XExpiresIn = &XExpiresIn
+	}
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.CreateModule(ctx, name, params)
+	err = w.Handler.FinalizeUpload(ctx, id, params)
 	return err
 }
 
-// IsReady converts echo context to params.
-func (w *ServerInterfaceWrapper) IsReady(ctx echo.Context) error {
+// ConnectWebSocket converts echo context to params.
+func (w *ServerInterfaceWrapper) ConnectWebSocket(ctx echo.Context) error {
 	var err error
 
+	ctx.Set(DeviceAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ConnectWebSocketParams
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Device-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Device-ID")]; found {
+		var XDeviceID XDeviceID
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Device-ID, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Device-ID", valueList[0], &XDeviceID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Device-ID: %s", err))
+		}
+
+		params.XDeviceID = XDeviceID
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Device-ID is required, but not found"))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.IsReady(ctx)
+	err = w.Handler.ConnectWebSocket(ctx, params)
 	return err
 }
 
@@ -471,54 +2554,208 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 		Handler: si,
 	}
 
+	router.GET(baseURL+"/account/usage", wrapper.GetAccountUsage)
 	router.POST(baseURL+"/auth/register", wrapper.Register)
 	router.POST(baseURL+"/auth/share", wrapper.Share)
+	router.GET(baseURL+"/changes", wrapper.GetChanges)
 	router.GET(baseURL+"/devices", wrapper.GetDevices)
+	router.PATCH(baseURL+"/devices/:id", wrapper.UpdateDevice)
+	router.POST(baseURL+"/devices/:id/approve", wrapper.ApproveDevice)
+	router.POST(baseURL+"/devices/:id/reject", wrapper.RejectDevice)
+	router.GET(baseURL+"/events", wrapper.GetEvents)
 	router.GET(baseURL+"/health", wrapper.IsHealthy)
 	router.DELETE(baseURL+"/module", wrapper.DeleteModules)
+	router.GET(baseURL+"/module", wrapper.ListModules)
+	router.DELETE(baseURL+"/module/:name", wrapper.DeleteModule)
 	router.GET(baseURL+"/module/:name", wrapper.GetModule)
+	router.HEAD(baseURL+"/module/:name", wrapper.HeadModule)
 	router.POST(baseURL+"/module/:name", wrapper.CreateModule)
+	router.POST(baseURL+"/module/:name/uploads", wrapper.CreateUpload)
+	router.GET(baseURL+"/module/:name/versions", wrapper.GetModuleVersions)
+	router.GET(baseURL+"/module/:name/versions/:version", wrapper.GetModuleVersion)
+	router.POST(baseURL+"/module/:name/versions/:version/restore", wrapper.RestoreModuleVersion)
+	router.POST(baseURL+"/modules:batchGet", wrapper.BatchGetModules)
+	router.POST(baseURL+"/modules:batchSet", wrapper.BatchSetModules)
 	router.GET(baseURL+"/ready", wrapper.IsReady)
+	router.GET(baseURL+"/schemas", wrapper.GetSchemas)
+	router.DELETE(baseURL+"/uploads/:id", wrapper.DeleteUpload)
+	router.GET(baseURL+"/uploads/:id", wrapper.GetUpload)
+	router.PATCH(baseURL+"/uploads/:id", wrapper.AppendUpload)
+	router.POST(baseURL+"/uploads/:id/finalize", wrapper.FinalizeUpload)
+	router.GET(baseURL+"/ws", wrapper.ConnectWebSocket)
 
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/8xZX48buQ3/KqrahxYYe/YuKHDwU93dNHGRS9J1ggZI9kEe0R4lGmkicbw1Fv7uBaX5",
-	"65mN49wl7VOyI4kifyR/JOUHntmitAYMer544KVwogAEF/66gb3KYHXzrwrcgT5I8JlTJSpr+KJeZisJ",
-	"BtVWgWNoWeWBba1jmAML5+ZstWU7tQeTMBSfwLPSQQYSTAbM7sGxd7Moaba6YdYxizk4liuDfv7BvPVA",
-	"Yj+TKCYFCrZ1tmAynPBMGXawlWMiy2xlMC4KE2XUH+c84YoUDkJ4wo0ogC94lDFTkifcZzkUgmz8k4Mt",
-	"X/A/ph0yaVz1aQMIPx4T/quVlYaXQdYpNGt0KsM+NISJYPFMo08pMO/UCf8k3MHnSjmQfIGugq/VrKcM",
-	"6bbOhYNrKydUe5MDC8uM1iNg2H5bvl4Fh5UOPBgESQhnttgoI0gAu1eYM8EM3LPG/zcJ+2AUskwYtgGK",
-	"AEkuE1KGbY2r6JM55xNPWgz8gYcyLKBTZheMe9e6YWTcW6M+V4OQtNtgXSa0VmZX6xxMbD9RUIGRpaWQ",
-	"S1hReSQ7BhBYJ8F9MGjjii3B6QMTFeZ0UyYQZGtSDkKC62zqxfc3+7cXeccoAzz+3UoFIVOj+8mS27hE",
-	"HzNrEEz4ryhLTVoqa1KbIeDMowNR0NolAUY3rOPJoMgQ/bgnAtruIm19aY2HHqe8UB5v689fUPWjJ7kP",
-	"F2FEoqeUq6OVlhldJ5Qh7xeVRlWS1jFMeZvZZMYyy6BEctYFOg7vXRr2tCjxwP65fvXyHGo7i6y5M1DG",
-	"a2cz8D6EfjJw81nwfqifkzrqg4/fzX61ktJPzpY4RuTfORjmACtnQCZMGRkSyLN7WqBsJVJQICPf3wvP",
-	"tPDIilronF/AiuHEEt+oAjyKoiR7OiRBAyn1P3dzo0jIl1r7LlvGt4maVXnCIxlhTQRKXlTDOjJ6T0fv",
-	"koZu7eYjZEgx9zjZtvzPlA9uqyL7qtOq56CwCJ3KW+sKgXzBqyoU3xOKT3okMb5VUwLbbVNWRgiE8nIO",
-	"BBK9Qiiuw+ZjwhVC4Sdwdk4cerclTCG7V1ozoe/FwTOBTIPoKCXgEDc3pWdQJKg6Umlo7zvvKlKvUGYV",
-	"T/zUwhV04wmPqNfLVFNOHRsRae6c8vFzEBrz5W7nYCei6Q8jWPt94rilkGq7BQcG2XW7s0FgDW7/9VaP",
-	"lGkFkqoD44+BdjTmXyfzFnylcRT4tYivAqbTZYTQt2jS9AhTiFIz1yDYXstiBxKlsOscsk8wkUInFtat",
-	"5VlDa7XG5Da4kNXbEg6mKkj+25In/Mbem57sLpuHyTYWXoTO3W5ZiOEmk2LfFTiglakMwg5cXQpH1D6h",
-	"d7vYlBmq+aHOCKouVTEuL32CkgJhhiqgN7JrVCpH908WylY49dSh931E8vRoUct8KaaVuoWd8uhCsHbu",
-	"HEZqKby/t05OtNcJJ4JqgvLLUdXuTDqJU7EVJot+wzLUxvdnlfGNJ+KoPkJWOYWHNaUU1HxETLmsYv6F",
-	"XKNDG+FV1qGUI5axNMN/kHTXNzab4LNnCp9XG6JWp+tjfpGmO4V5tZlntkg/ik92U1jQGpyEPfVaauYP",
-	"Jpv5yHVUUszWNg2FyIIboBCKJNaf/hbEzGo5cxmo/pQIlG/K66sMFVsfTFYTKvVBWmVQo1pPG8tSZDmw",
-	"n+dXv8WAdKPtJi2EMumL1fXTl+ungXwVarrjVBOe8D04H1Xe/0RbbQlGlIov+JP51fxJiBHMA9gpVcTU",
-	"hUAFFwLCTlX623rHcNxMqXOqKxqFUYj0leztD3d17xjvpwm525J2A+UxObu5G62Pdyezzc9XV7/bMDOR",
-	"xxNN5brKaDzYVpr1D8Q0qYqC+KUH5LIGkVJC7AgbTr7gd7Q/uiUO4I/65NpBaNURitI64Q69twQf2j0S",
-	"QCQbnmUec9W6HvO/2U/fE/ohX02h3j2f9LZ1xBRs6VPS+ztSuHNIFHCC0IRHmh538cB3MJkhGah9M7vG",
-	"OhNabq1HL2SPueIZ4E3XSv/O/pgS0O5LJ94CLoLxGSBbas0aKgHZTPEBhUfwbUCNEHdd2yTCr8FRvfZM",
-	"NC1QFlqgui97/ubNa3Zrq8g6Q2BXPp448O8Yq+PO/cssEfcrA96fkERo7TxTXctOIaU8W+6F0mKj4eRB",
-	"gjVPTR20TYMZkC3iW2eAVAPC1ABJ3z3rt0kb4UEyayZGp9qVTBjZ8dgQ8ygxyvPftw4MH8cnEuDn8wnw",
-	"yCvERUlwrUG4IYZx6h5RfVHD0ndP+kBtw/Esw8TuFWT/nikqaR+4fxzy5w/0H8e/iagm3t0uJqohcGOf",
-	"JI92QRd4IJbnH+GEEabNi/ThcTh7j9bp+MX6+BsyqP9Ue1n2BMDStyVNemd9RHnjQEQbz9WLWxAyEG1d",
-	"Mv4cfjqRUIKRYDIVanOmKwnyLxPF4zbc839UOlp7yFN/vXryYxX5h1C6csBk5WLtacANdetra9mrBmSh",
-	"J8tWCB2aaHwdOaePMna7VZkSuh58/jCesdxBaz+neWouhfsEZg5VGmaiUWZXhilkL2wmtD4wtAzdgb7Y",
-	"CoeCF2mqaVduPS5+ufrlKgi8ay04lfx0D+5AZZ6A0qF4omXLrprStvb3qdBwjtVbBs/TwTDpdUW1Ptak",
-	"xfjkyiA4kWH8sbDXnfV+Cuz/anv6k6z/oja9FoalvUBYmfiyMrCtdu3x7vjfAAAA//+3e+hr6x4AAA==",
+	"H4sIAAAAAAAC/+x9/XMbN7Lgv4LjvapL3g4l2clm37rqqk5rOxtd+WstZ5O6le8KnAFJxEOAC2AkMyn9",
+	"71fdDWAwMxhyKMmO9+79klgcfDQa3Y1Gf+G3Wak3W62Ecnb25LfZWvBKGPzneVmKrXvL1Urg35WwpZFb",
+	"J7WaPZktdk5YJpeMGjC9ZG4t2EtdNbVgz7jjrOSKLQQz4p+NsE5Us2Jmy7XYcBjN7bZi9mRmnZFqNbu9",
+	"LWZPtXJCuRdCrdx6OOGl/FXkZpGK/QVh4ZZJx264ZTdGOidUwfQG/lExSd2ovWVClboSFVvs8GcrzLUw",
+	"HeiW2my4mz2ZSeW++3ZWBHClcmIlDML7/B1fDcG8qIRycimFxbHLxhihHPu7MFZqlVlAgf/Nw8SW2uCf",
+	"RtitVlZcqZIbEwYHCNiNdGv8C0eQagWIsM1yKT8W7GYtyzUsmeNmigo+4vh8IyJQUrFSq0rCEngdNswe",
+	"2K/nH7fSCHvuhkj4aS1Uuk5BTWHyH969ewMrFgVT2rGtERbwIzt4qbSw+Jk6HgDkBbfupa4A6dVhWBDb",
+	"QCU1t45tfL8OaAfm+3Fba169Xi6tyKz9fKMb5WCjiSz9jlMnZkQp5LWomNVsyY8luttituWGb4TzPPq0",
+	"MVabvzXC7IaQ0EdmhGuMoiUq8dEFEtsacS11Y9kbvkLO4uyFtA5WWcwkDPBPHLeYKb4BOEoc7wBynolr",
+	"WYqLZ2+4W+/hDkMTUmsgwJ1uDDsvS0BemH8LY8TpJQgQoE1pYJ+daUQKyr8ZsZw9mf3X01agndJXexqA",
+	"6kA4gjUPUgKp06yxIvIi9jthF0u2ktcgZRz/ICygsxSVUKVgGlj35zmNNL94xrRh2q2FYWupnD25Uj9a",
+	"AcMiglkFFLk0esMq7GEjPjjhgz5yRWP4H09GdonGmMtqdg/sXDruGjuCodeq3rFaWue3z7YyCDHCqPcI",
+	"fDZ8PAY4PyIAeLF8yV25HgGr2VbciZTf5ZJJZ0lWbqCnsIyrXeBLghi+2oL9O/P0ZdMRnGbio7Qu7FuQ",
+	"vH4ElCNG8Aoa8mstKyQAOIRAGpfreEDS/nmkxf2jI7dF0MVyTivcz2gXyyDzLqUqxQg+iPkH+EAB2Mq+",
+	"pRMmwUYqCEdB9J3nNPshWF9pJfbt2wicft/ieYAbOLJ9e4CF2SciFdWZw0Bis96pZZ2sa7bmtgcZsH9E",
+	"KdO0ruT0KTlMUlwRf99IT2M3a133VB0bhfmexdIKDq3zR7WZQD4j7ITkg/txHxJqQZhERC/kRroRkfSS",
+	"f5SbZsN4PHwvnNhYphXjeL6NCKMaxuzMW4klb2o3e/Lo7KyYbWhc/Av+lMr/mdUICUWvcOiB+uqMLF16",
+	"rMB5wj1a8yce/u+uZ14CTAvbGyOW8uNBuU6NLZCgFQzGYNZx4waSnsYbQe42fNy3qzSV10TzSkOiO+/H",
+	"1zU1vCfK/HSvms3C7+tEmUAogYuRFw85ZV+crE6wjf3vj84efztnTjMjbLMRjLNK3yhQE8eYZgprX665",
+	"EU91lQH43Vow/MzgO2kVLv52/uYCtRqvkouK7gWbhVQooGjvOVPiJuhtF88KdqWkC1e9xgo6BasKmwV9",
+	"Bn5ShxQXC1AcWhzIiYnqbl/N9cot7MpTOpPHAJkgjEidf7oW5QfbbDLA+C+BBoDaauG68pxbxuuVNtKt",
+	"Qb2r2IJb8d238S5YyZWwztOMXfPHf/yOXTVnZ9+U9AX/PSphCcR5hHHKgibq7cpfZ+6kqI/NPeXin0Vj",
+	"sAAcQIQffx9ogxtYFPhnWYFPI78UjoMOn6ECvdlwZgVc2oChtlwa1AQ/iF1uw6953QhGYyyAVgfio5Yf",
+	"8LoEH1wDtw7tdKnrkysF3L2UtQA4mbTMOm3o1uctK/N3u23EpB8VgHC+I6CLrCiWfe//PjmA1Lj2+1yZ",
+	"6fe4x+tGfUBhI9UQAaheaRA28Knbs0uUIxB7IB6SDH5+WkuhnD849h5gACa1Zpd66W648VJT01qNWEnr",
+	"BCDOC9mx5fw8p3Hmf4+n3r4d+Dne8gbg/ajkP5vOjTfsBK/rFhA8HOJPZLOqthputAXbNNbBpnQOD20q",
+	"Ya4UbdfW6K0w9Y7xxq1hphJY4mR8efH6/DDXfo+AvHb2Q7Phag6XOL6oBUNO0MuRHUFEBNNi1eAXzoyY",
+	"U1MT1Pn09EFNgQYSFY0vLfsgtu4wBl6RGnjMmqPa51f9puYOqHq48vCFfYWnzOutAPjVil3urBObr8fR",
+	"cBDuOOkByvSGxIsM51wKMExaf70gaybejcjC27cvOr0SeMdGVQXkWJBPKOh+kCATdydX6infblsVwWv5",
+	"YamXaHsNxtNKlLIKBleYZ9cToTISw8mVen4NFp2fjHQi1VeD+ow3WhqEK3GzZ+89UuYXXcbeewO5JUYR",
+	"1v1FV5Ks9n+BW+9fhXtLH+Cnko4D+Cffbmt//zz9xZLsmkZnYVyatncb89cHVG35UBu4LQgs3+4waB/n",
+	"jpvpsNG4IKIunRF8g2S2aWont9y40438SIbiO482WDHwW8WSdQOBitzCiTd+xHv1Q+9JOnYOSvrOzp0z",
+	"ctE4gpTsUzlQg47bqA+HIdV4rP5Bl064uSVEPSiKEQ7gqUQdmBHJk28kOK3gjvGj5Svx1n94MPymg+dA",
+	"vHTa8JU/0L1s8X1mA5o/CNu/GNEHy4A0TKpKXsuq4TV7K2xTOxuXT38/+M4kY+cg9VAEwZ3A54kJBTZC",
+	"SVdDuCs+OJDt0Hn6jnbigFKp+iREHPxJoGuH3iM64DOD6bhUoAkQfQE34nfbwviJ4MvBds6sVKsIBMDw",
+	"/FooR5Q7AogTH92pgGZHSyscPM//MBDsIDbpb2CBf6A2opdMoKaADUM7319auGH7WYpZy4rn3ol7FE57",
+	"uFLs+Wbrdux/Xr5+NX52k36/0o6FOdFc+cboUlhLLsIkVCC4wnNY881OsY331s5Td+2+Th3X7m2R6EXn",
+	"7lDf1j99e9tB4wTS/HTHWIpf36oYRl3M27CLfUvshGi0IRTzZ9JutZUuexsFsU3qbLjkZ93jQcUGuV6A",
+	"ksvVrvBX70rabc13eMurpRLTIjvmz32EQsZ+7yUeN2TjMcJaUTHumBHWnfgrcwhjCJaRUleibHXs1uaB",
+	"Z5F3tTPp0KhhOw4W6aB5JdLJVJXOjWOsfpVbXHsMn1AVKvEdk4pEZ8iVSgerQfPdnbTHY4JOxlkfJd43",
+	"6NZc0aSAiWjK9NcZWsfJobiML5wZoWP0Ho5Gj4S1F3hal9yhO8KHcoDBVoqKVYFYO7EcJ7MjDP7Y49y9",
+	"kxthHd9sZ7eptBC1cLmgknctw8D0FbUr2Du9WVinFZ3j/meWUjdc+b3r52Yt0ZORcD/cnbHPfEpYTTL1",
+	"9PiV3uqkVr/7sRIAaU+7HwSvUjE9xP4PhLSMj+UTyNPWNL2vdzeA7T858SE58ZOovO3QebUyBEZRO9uS",
+	"5yu9J+AskQ2VrNBNTndsho6l1ATcho6gF/pfUqN6w42TvH7abshn16nOR/2tXaTek51D9xF38Lv1uN83",
+	"aPjBVgHimiJol9JYNwdCOLXyV/Evfra3ZPFO6xfcrB6OWf/WaMeffyyFqERFM43yHXlXa63ANAw9rI/c",
+	"03T3Si5lTJuODdiPAE7HWT884RMKoWSGLIXXNTMCbt2iCmG7thMQcVvMuhj6rHgng/eNburKY3wU4QDp",
+	"JY79SdDZDj0Ga+KHCZtNfVvI/s5rWeHs33NZPyAyR4afQM0xBO6aOgvGV1wq68hygOOmSwvRqi15JObc",
+	"VOMbTusdsNwyvt0KVVFMR8fBmgjVrlv1gKjo+IFvk0AKI7h7QDx7QEfwSl9xheiXEb0lvdA06RA94Usv",
+	"qttpZoWqCHOWOT3JHX5PnD0464wjLVrYPgEB/CQWl7r8IB5eFMSRXwo7arK/ka5ctxQe+7A3PrKi8Ja6",
+	"DQ2C1jmWGTrs+MANMaQi2/MTtLFRs2JGfnLn3XeoJOyPiXnmDYq8rqOmWhxOJih8pLjdl8IQzLqTxtv4",
+	"yfeMdxR8/4TTY9LxNLu9Tf1X//Boa0FqFxuGfR9n1ItfRNk6aP6ai1DZdD2aoM5xtvABxd0do4mmhyaQ",
+	"zxe7Sic29pi4TgxQvaBej3yAavgzro8bw3cDDNGko1iAUbw/ZYAMg7+T9uHlwsZb4MbRIlwuWyqNo+/6",
+	"9GdFX24WM8+Cw2HeCm5DgOgSj9Q9oygfATIdyT5lITMtIiKYXihJgWIrY7yaIZUfbXweWTQ4Cz7VbEhR",
+	"f6tmEYrRLdu/XV5bxM0pwnUEA3TaCI8AT3fnSDAdQBgoXEAzT4OKF2l5ElH3ae72APXGNCEcPIcScqcN",
+	"scGRzgSGpHqyHa6YEp3GIkxDGBfOULAtR4+It8/SHb+W1tmkVYhgkS5HkceLjE00l9zJsHIXJtAUGaTV",
+	"oW604tex+WDvQhqZp+sqRBO1M3QWOL67qOUPdilJuPFiu2C6roR1dNl+IAI/QCROI2G0JOE0UgWrORCC",
+	"J4tBEqTS4RO7EUag7oH9UEcdUM5xXEYjPxhzvU4pQiiISvrHrPSafDGj3JEKd5jM6O8zK3gWab/Pp5Eu",
+	"eru1P9YSpS42YT4dgLyuOBgzYqtNEraXRuxl8Vsdw5Vym4fHiI12gvGqMsLaLjzthc3oTQ4EMA1dCqFy",
+	"9tlohvXDQdtufGXnlhgTnaPyBTs0d3Jz54MyDTcsZpq8gTk4vXsrgiptyI2ud6zUSonSCcqcS/XrFq6F",
+	"1rXg6CPYjkYzwgTh6332vd2XKWiHiySKlmQ/J2O5VS6OSoNMGZZyPcfVg/HQ35jHAfsBK2ooFlj205Q8",
+	"CUeejGtrGpmVTEk4yWBWlIPoHws6+YNqHD1BAjIuma3A/DXI1OP1Dd9Zxh2rBW/jWtKd9Ud9l6Uai0w0",
+	"SeS2ISnjqnkxI6z7zxh9d7RIvkN0cyJiN/xjMIo//uN3o9t5OaII/+T5O4yIUfq8LFHa0cWat+ZWypPk",
+	"N1w6NDEZfc3rWRGPEPoJWWgrUHPec3L4aMfhja1xuFjeBjt2l9wluWNl3W1mCyhiJ3OSefePVPtu+nyf",
+	"wxeHZmu0xx0hW0Z1FAKo1WdJUZoToBVNZx9MVW3qgz0eStcMIE+I4ILQjYEoxd6JTsrz+mc7QKL6dJEY",
+	"R5nzqkr/BFFK1I0Zb3MjKiE2I+rRD4LXbn2+WhmxihjpS8u0gMvQ2lnJ5VLAQcuexpbdUPepwmwATBxw",
+	"qFOiibB262ljxtDN7m74IXI7sAeWAYbuAkmr/gwx+ipJD4nT+tAoGoVh1l1OZ8/f7Q8udOxyf96Z0Ifb",
+	"JrL0x+2smD3TNypLXRCt8VKYlXiTT83nGM/BsAnDNuyrt98/ZX/65s/ffV2wjYAUWcuswNWrpq593BJS",
+	"eJtg2johYLz9kyXTfPfns8dfFxhvFSUCRdGgUThJMsK4LDCAasOUVmF3NmnjqVQeQcyIlZa6M60GdAfr",
+	"H66TVqmlclRbBBBEiX+ENW1Yqbcyf+HT21Ti8KrCwH3oiP/Y1pzOc/qh1FtQL5ywLrv/22yy5ziA8K+4",
+	"Ex6v3t0xGBt7DAf3A2GOcME8xLBmBLLPHno781DmWOPFTz+95IgQXoXqRW86O7BXgcTez5Uzu9nAL2Ad",
+	"Dxk8cKmao9HIzG+kskzUYgMMv+Hb4ByAfM61rr2fWcCYbao83PitYy7YXyBXU/pwsIXRHzBpPNE6C2Ig",
+	"XtuQnqnaPTiZjSKCljKgwmosiC2w6QexS2LTLGw4dyxcXK95zXDZeDXTNXDbDYXL5+5m1ch9nn6noW+M",
+	"dqLFVI56IrKGIyHD22gybkssfRA7nyvtbzIf2UbWtbQ+iax/bQvaju87zSmxl6xTQDLVrYBK4K7oET9U",
+	"PuKis9TeufDscbJAIxtuMyQkoW92OTnz4HBoFj+GGDC4RCM+Oau4azbD2K/JSuogwmckOrgXMx0Hh/oE",
+	"eSKifhdqqXOnjY8V9q4L4FbdODxsfEJzX81SLlH6+oneSTjw4WjqHm1IN2gDoGglHkYFF0dXajuuONu0",
+	"ff4dbNYYUXV0JcFpgmDKPcOHQuUuGsGpQzFffk9zbJ8EKQ5WsvUBiq2b8/O7bhImy6hJUHBu1EYeYlSg",
+	"EQZbdsnOm25RqvgaPvuV6cNWkn0VeugbKvdFwCgWNtFQ52UhOvyJRhRL9ahCOsKWOyeMIo0F//Vk9r//",
+	"cT7/X3z+69n8z+/bf578n/n7386KR4//dPtv44IrL2qcL+LA2dO3z95F8YWVevx5CGf5BnT2ql1IECqc",
+	"4QCkpqPyhSEI8UQPqmV9czPf8O3s/Sh4oyZ43oaqXaele0a8bYc9wymT+p/aogcPL2c+pdS4bpF2l0pE",
+	"KbW31Y6SFXvox2k/DTQcNcx6ovIzwGkgbh7af3YXKRN2/f4erByCpxSeAtd5aQSPGUKk/4d07/HKIY9y",
+	"1PC3EOHS34aNbG00IVpILxPzYcF8I1SogZtTyRn+FmqpTSmqwZaNhBWFMmr2k4YXDYu1PUyY0XDcI+DN",
+	"GXQHAbUjoQR4p0G9/hfyYvlSxGk4LGcUd9TfCdzG4didyNm3wurGlGIiftoomYxfK9RiHlaBgU4UA5cE",
+	"8/IqhsYdB4UJrafEb8Whb4tZkw/xIfB6CGELsdQmgXriTqeyIqArAbmIdQGb+Cng7f0YnbxNFhwO0fEw",
+	"tNyx+jZxSrb2vi65bLm1N9pU2d1trDBBSd6vKcWWRTtibmWXI/le3lZHn1t7QchtTpUOxlstCaYMFZz6",
+	"ccyjLpnuzGlpHqKhIhawChcpCDEN5seg94iPTqgxlSHqa0MFGz+ECf25qGIGLUGQWMKghmxaZdYC4Sjy",
+	"JJZrbnjphMl6VtqY1rwxiwofDd3d6UZUumw2QrmhjSh/+2g1Vj/7OA3sVxiSOAbP2L+LluDJ9f7qwXga",
+	"wFFHAJkSDsTuexrqkNgAe/uE+lhS5pBVWiYFWAM0VcKCQzOu1DWi4Nhd+HvoeHA7WgnsV9KZdc/2xBky",
+	"+7LlJlVlcdG4S+ObAfsVIeiiH75zVYrxzICc0VwqnMMbz31kQQJQDt8fxA6k8XET+d31fRODPeFR2APR",
+	"tPsPi8Hih1C2o2W3C/ycaXh/F7k2rVg6BGUwnE9CyN47bbNBt3+DbXoEMNjWKYYxmg2rBRiNVcPIYkdJ",
+	"/2wplbTrI8ydsjpUXrOTaTPoXz9AmcwJ+tsdAkQnPogwfALhWJUNI478EVaHqp461HVsNzVHi4PMjQwZ",
+	"eVpmZIW69oFrN2JhsSezO1Wi90CJesxOPF7rYlj6IWvl2DYuvsRB7s3yA94JV6L94KsMhurgoeSnqjqv",
+	"Psgs/083aN8DwNzE+Vqpdxy9dQPs3EOZy6capIJBEKsO57lVhJigCQWFIukeUwnRg9LHWLTjY8HYXjnB",
+	"/UaJwwKKh3lYuda2dV9S3C16dTEIOBx5vPwwTDIYCsa9DzsEPWvfuw5+tckDCndJIfEJJPso+C4xTQeS",
+	"SLr4yeWU3NkL0Rd544FPUwRmP+rJNgssV4w6awM7u0IxzMsPFNhi3EJwNwu88D5nxbeibIx0O1Ts0jyq",
+	"84bOOlwLupq5lWWLirVzW8rrgwueUbx+pssMlv8q3Q/NAm70pvbd7JPT05V062ZxUurN6S/8g15stKhr",
+	"YSpxfapLJ+cg6ec+bBpI1LsS0bdaIl+LDZcwov/pf+Awcz/OSSVmmQxUaUO47evSSXYJxwlFYp2g7aEU",
+	"Xk+iQ3h2vuXlWrDHJ2f3WcDpotaL0w2X6vTFxdPnry6fk6PdASXP+pDMElv17PqRj8pTfCtnT2bfnJyd",
+	"fOMjMxDZp94seRqtN6ucKvAWDyVaOioDRVsbR1XxOZlM/cReadc2uRwtNd4DszlJs0guKth34Tq5mN0H",
+	"lP6RZ5y2yWlbNvn2fa/U5OOzszHOi+1Os/UoU4pHIFJa/8d7mMk2mw14tXEFvjhcN2m092CS4yuLIUH+",
+	"l/cwySkELp+Gyzlq3tpm94VadKv7nybDd7Ea2t8HncXBxu1LBrfF1JG9tJ3aPJYontKlW+V7jB4eJHU5",
+	"YwrMJS83GGK9bGqWdgBefXz2+DMDk7Zibm10swLbX/LehG1KMqUXbNEQUT9rw8UpfDLEfl8pzItOX0zq",
+	"F+7wtl//+oSPNgebG+iXJ4CEP5796TCH9utbpLwX+eK8rXkdGQ24NeEyvMWOsxhVOLDMic1WG252CWYs",
+	"esJhABBkPcbuct6lfyTjoaXYw1S36NzzcwTbEsPdhCENMCb62h3xOYCjB9ELzM/EXDVycFKyGh1DlS9H",
+	"1qnF6j1gz3ovtMXSnmmG4cmVeuOTQkmn81cxCsdvUwJ1+4CMjWl/aKQKkf6k7t9wU8GrcT95Wzb2LhCe",
+	"6GkP3QE/lC94cqVQc/ZnJAwWb+KhtbRxhIJZzXiAkeL8sBwhLMGvrjcbdSeDZ3GluB1ku7KNXK2BVVmt",
+	"oY5jQB/GNK75tQC2x+t04l4kg5yvx6icVE0bnoVLzx7z7Zsun/JIat+fmdA6ebHqbtpDphjxbTH79uxs",
+	"SNHv1iLsnbTB8oitH+1vTTdFeiekv3vkR2a1VithUkrJ7thxrPyifWiKJbWPR3g7eNCIvRPX8oieSaU/",
+	"/cGBNgXMY6vrwRuLY5L2r8K1bulPSFLDBxfvRiuZ0tBH65lUZiq6cgK3AupG9iX6M9N9Of1NVrfkrcxe",
+	"6FtRJ1gmT+urGMML95+v07ytg7tG6WDxsP7k++YfTKItC08v7MZ3K3md4TT3FMDt3bf+bttO0zM+3PdJ",
+	"u3zqFbZxxeevhmPl3sCMeL79ovEYSbcyrzN2kwjHdv2cgPidtv2+2wUS/du8jH4Wk6UxvMf7tDoccNRu",
+	"e0Qxznxm5VF7TWf9vmskxLXfea9bhd7XHgtZoP3LJ0DxJez1/k3ruoPRJJ+kRXX38J4kAN3/PKm73/WY",
+	"dXsU9RDmpxOPuA5ZidlTmkL5bSiW31sV49abouaXQrnQqlFO1t7JhUUD8AFzy8pa2+QFHmwNv6MPJz4H",
+	"RLGvliFk5FbwlfbhV3St4u3xSvU0k5Ibs/PPW3SzZ7llsgqPBfl3ELecioD3a39sJP7uB82qsTH19vPa",
+	"qXIPJhx3MwtmYFhsfkdH1bk2PzNLJ2+EARsNyBWf7FhisqO3B6Cd/K1uyB7VReiFpR672Se89g5zdPfb",
+	"aqi9VMLanr2B3moM+RZA/Z53z6+5rFFP6j7DkBaR8qgNqaSI2dZRQVlguQoQ8LvtOPcWPHmRrlv7IDJn",
+	"NNcWV4piYLvVzlktOJ0FsSY6eWewma+XBKwLdHKjhrxAgLURnJ9Bzo+q348PM9BISfWjeOhpLbjpbgVV",
+	"3xjI2ZZ/in0GjtZdZvOlLIJlzaTWuPCOLYtPyRfMUvmUxQ7zI06uVHhSB07vLV9JxbEAfkznwNQULw5j",
+	"9CT03+YsI9iFLqRDSoC1/B50cLjD8FHlCZ3o3Ph8RoRMAfUjjQh3vNb7iht7iLcVUae/wV3vdoqk6nq9",
+	"R2l6+BZg+/zfeSKUeo/52cELDUVMiTWCV71XWbmyN8JY9u2jMyaVdYKCjh6ffXulQEZ2nvxHfnACsuT7",
+	"0pKjYoeaIQCF06FcZeIjWfP2CshPyxcdR/bB1hfejz+paf8Z+ocVvnvU6mEyIxAuEL6zCXV461hiSJsM",
+	"Sej1+ODzISERefA+QHga4CgOpMnb2pyRQiYfHsF8RvqgqDonUvS/piFCxfBBG6D++MBQJsO1CDr2MPGV",
+	"d8buPoVDN8PsowLwriekxIVh26fZpfH5cBhPIfJr8JJAGgZ/oZTwGNz7pPvwkEtedFcM4zRNA/QYn3cv",
+	"rpRprfXona/i4sMjQlKrk6DekQkuPrbmO4cHjjA4ba3rDljZy8XnEBZ3PESnS5dXWokjJMzLrnw53OWt",
+	"L5I4YWzf9B7Hcuc9MvTbfje1a+/hjdti9s3Zt1M7py+Y3EO2fbfvGQz/5i15E6jaQzX5jY49j2zYkUzM",
+	"+KbGvx9+SuP2aNt4F+KcLIVV7I95CY8WZRQJSrfyIistRgATFlcq6CDhJTSQOnQNLteCbyFCri2yKLGE",
+	"zG6o2yyNsOuhaAC4/lM23IePOw9WIR/v1zukZQIfzNIGT0bS9fxB+Pl5+Y7MAFn/EH7r06oyPDFmKN6j",
+	"YHhVG3PTU23/5EpRNaokNX8hvOe+Qsuo5HW9C1lne0pXMW1Cg0G5KYqopp+ljZWmQqYFRVgHrd3pjSxx",
+	"0sCfpVa+rimNISyrNGqY+loYimIVvFzTzQCc+ibGr3ntpE3uL33QiP8zpCCTvYUKCcenxiklwas5UnXh",
+	"xYFbIRLuI6Jq5/ZLauGvkTj1cllLJcIiam1T+P9bUF4hPOFdXqEiCRaT9EJWUvqKYvJe5eCFxVahNGE5",
+	"VyoqcPEalkS8Y10aQgcI2hMiq9YQPBiZXnnsPgdZXKmY+ax2tNphzyTmHoMpAI6SK8AUmoGBACoMB8F/",
+	"Rdr9IMQ2WJM7arQ2bAFQCNa3Rw6lNoUU/b92AZwQiPc8hsln/K57TLpz3JA/HGfdbSvWgZRMh0Reu/OY",
+	"Sd29/sAP9lJad9hrVZ3o0skTX9vjSKB9tbVJb9qSgCUhmpQxEBVLRHjG2f34GL25e88fMWdFQQ48ySnp",
+	"b3g0aJOcBkWwh6LQJZnci6kZ87bRaK0E2Hd6+FplC1Hyxoqeogb9ASIIq0oPKsfNSqA73bft5jRGK4a3",
+	"bKHPS8BPbf1Eym/oX5ZHC92QlCIsgHjDKUD2JT2TNw6TG/RDmT+K9DcfKroUzifA+NMqeuzoKqAqOgoR",
+	"A6V34oVqAWaH5wnELHkgv5lKd/FZO+z2x31UoE2PgNoT6PD7vzj+4wnsMPai2N2jXyd7K/D4OfUBJIeu",
+	"RwNT7ymlaNrxcIJLf39UIQdTL9NpiPQlkINmNWyKt9p6q01IpdEmEI1tzDWoncuaf9gl/uOgvMQ0Ov8m",
+	"u3/Oi7YMn0LzgAB5BSZB5f2GJbVi0lsddF1KxWv5q6DOJ1fqRxWyRv3KQr6Wvyt7w/PYiR9zQ7+YE58g",
+	"ii9lTmz/UjiOuYCZi9ejw6TbfTnu7mz8qfkEyZilyclZcp7INaEm04HQZu9dG38wslvT6YS9Wwv/hoFv",
+	"HVKUwm1mmm0xTPUF2xHuccvPvQN656DXBFXHbPzpb/5ftwfDX7PXa5+/2SWNgxv6L20X6qzkHoF7eZPt",
+	"qIspYSSl2+D5O1tbwnh3kBYt0cBynN6XqfITFRHs1P4aEMzeYy9U4dXM6LpmC8j/5fgWGvlBvD0nE9mH",
+	"oH1Gwrs3HX2Bt+GHulAdS9UP5eu8uz7+ZevLnrp73HyAke2TRfIo5Zg1FTTIZgsM9+js7M4RP/GNctSb",
+	"vU4cBus7YckZyY073ciPosI7M7dwP2VymTpDfaQv0dbelwdj8CYMixGXshvu8QpdyWrMdFgMXbqgqI/U",
+	"24gXRl9jnbXPWgb3DL3o9/PcJ+GTl4p99fjsrIAID1gyxH1gWFvo03O7oz2ZGyx0LmMc6ULDjcKyN+c/",
+	"MyNKbSrLXj99d3FCRQIQJPxbOL5CT3R622/NC8gz+NaO8u6Eha52hbc14FAexcIYbaJt1VCxA78XP899",
+	"av+V8kv01IEghGIoLaw0Pl1Ye7SGxNqlFQiKNFUtbCbeNTy4+gDRXXdJwAjT3yv5AgfxC5gUYOVhaI02",
+	"aBFfCHcjhGKPcJMSNj5WyPCqDRTIxGEx7phWpZgmdy6FO6goHCV4CrpcDwUI0hw3aCs3Pq5Q8U1bCAD+",
+	"gEbRhxDpoA3cGPMnbPjOcx5XsTyI52dy+PQM8Ylhv+dqOGHvEnbuQykN23K3jpKsNejFWjEx5BUhQ2Ne",
+	"+IEk0AibXIrPEwS537o+kakiP9yXsSjM9Ci+KtGTHl0wxoY3VfA9BMs2VDGUqy6X7bHnhZFB5RES5V6f",
+	"ehWR73HMSr67HLdi2PxEhoVDYDcleP4tHlHCWh848BWSdiXwjX9VSkyKLOumEtXXmUj6tzjPFxRHH9dD",
+	"2ts3nxcQUBwbI8KLki1yMYh/amB/fD2I18kGd2L4k2flDxZVSYqB2jSjz8tQmk2bXnBsX8V646ueurXY",
+	"oQtjx5z2rmrRyYqPlV157ESHv097kkEyPztcB5PAztqWLmNN0ePFSFu+tGuyGRRYSdDlkdFOGzYm7AXt",
+	"jLdgx3TX0chlo7c2LSYYXqtCrAzr4eXifD+H0ZfmODrfzS/qhscSiUcmJS60ca2Z/7hI1Zb2AxiBol9j",
+	"QUDmdFtFAOKrvXEiWNqTLDJ8iynwMyfrf5Ycv4S9OJtqIJ9kqvK4G/oRY/zzXUoWvTF6ZYS1vnT93u0d",
+	"SRU/R7eLt0TDhjBfapqurLTHIfuuLSOT7H+noCZ4PcmpQ3UyqC6Gl44bXz+e5sEowySkP63OBrcs6n2S",
+	"y0WOjqLPSSJT2xNa7qbbeZcL4Gdctft2susGxplkczpMnnt88p4OYucND67jHIUk9qfhUEQZVNGfaBKu",
+	"4o0qRcXI+xWuzkNa2qthUjNvUgs3pE5ICIL6h05kyLGZ3wJOHYZYBzbZz5H98+00eDEnGY7jmRYCc8dd",
+	"pFqVAg/DFpXx1awwTM8/ey0M2kg76gOtZU6aVrMB1QNlxJBDv+/4Y79EHg2L+P/RvnxnXn+l3SE6Yjvh",
+	"vhxD9cPU3BqxZ99m6k72+TIjEvtc5MUZzZKqyOHtrhibG6uxt48LUyc4aQmSBZ21nOWBxmP15HOY4r/H",
+	"8IsJEvBm/Nr143ZleCVQ4+AsVkmletGkj/QilWPECAar+mvAWPEDI1LpR9YkB1myS4NWcJtO6u23Sb7Q",
+	"V7Esa8G2jSvYSrivWxuYrGL0jCjXOpbqlQaK9RZXit4T8zbkpa5rfWNTs3Kpq9bQB5493Ba0gGM+vlDV",
+	"VkvlQmgNlSBlFpU5zmJZWCCppuam3hVpQE6bCqZ2sRx3fE6Y6gwTCd7oK9WOhtlW9HKqEaEmRMyZ8LFy",
+	"SPIYgdts0xg3Pw/1raT18EC8MexUKFdWIrM02+jaQNlA0W/fC9yunyB8h8X+ReprkZbZNZr/LNMKY6ul",
+	"ShvAVTUT+kODxS1/yAoRj6aE28SZ726Ma4hlqh7LHMe7r7dCpb2paO3TWI09w8g4PNCf9aP3odXLpSwl",
+	"r33Bkf8yLLNrdnVtKXq24uaDUCeiOcWyuIMraYPB5y805QU4DfGG8ItuXHfgJ6enNbRaa+ue/MfZf5zh",
+	"gO/jEgYVyEEIuDVZm2ruUw3PWys7uRN90WCshjgE7zwWVEK8tSZl320T7aH9nhfAW7z0IYzJURAqqjlN",
+	"MozHch9+TN8gO2ZMIGF8AQzfKxgSFhMr3WSfwwgWr9RZmXvdxI8WrDj7sJMU6WCniXWvm/LiB/T2utv3",
+	"t/93ADTgwGdQxQAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
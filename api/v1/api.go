@@ -2,8 +2,11 @@ package v1
 
 import (
 	"context"
+	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/labstack/gommon/bytes"
 	"github.com/sethvargo/go-password/password"
 
 	"github.com/jakobmoellerdev/octi-sync-server/api/v1/REST"
@@ -19,12 +22,44 @@ type API struct {
 	service.Devices
 	service.Modules
 	service.MetadataProvider
+	service.Locker
+	service.History
+	service.ChangeFeed
+	service.Events
+	service.Presence
+	service.Uploads
+	service.Usage
+	service.Schemas
 	password.PasswordGenerator
 	service.UsernameGenerator
+
+	// RequireDeviceApproval makes devices registered through a share code pending until approved.
+	RequireDeviceApproval bool
+
+	// AllowedOrigins are the browser origins allowed to open websockets besides the origin of the server.
+	AllowedOrigins []string
+	// MaxModuleSize limits the module data of uploads and websocket messages, 0 does not limit it.
+	MaxModuleSize int64
+	// Quota limits the storage of every account.
+	Quota service.Quota
+	// Compression is the codec new module data is compressed with at rest.
+	Compression service.ModuleEncoding
+	// Encryption encrypts new module data at rest, modules are stored unencrypted without it.
+	Encryption service.Encryption
+	// Expiry decides when written modules expire.
+	Expiry service.ExpiryPolicy
+	// TombstoneRetention is the time deleted modules are reported as deleted.
+	TombstoneRetention time.Duration
 }
 
 const Prefix = "/v1"
 
+// IsStreaming reports whether the request is answered with a long-lived stream or connection that must
+// neither be buffered nor cut off by request timeouts.
+func IsStreaming(ctx echo.Context) bool {
+	return ctx.Path() == Prefix+EventsPath || ctx.Path() == Prefix+WebSocketPath
+}
+
 func New(_ context.Context, engine *echo.Echo, config *config.Config) {
 	api := engine.Group(Prefix)
 
@@ -35,17 +70,69 @@ func New(_ context.Context, engine *echo.Echo, config *config.Config) {
 
 	api.GET("/openapi", NewOpenAPIHandler(swagger, config.Logger).ServeOpenAPI)
 
+	if config.Modules.MaxSize == "" {
+		config.Modules.MaxSize = DefaultMaxModuleSize
+	}
+
+	maxModuleSize, err := bytes.Parse(config.Modules.MaxSize)
+	if err != nil {
+		config.Logger.Fatal().Err(err).Msg("error while parsing maximum module size")
+	}
+
+	quota := service.Quota{Modules: config.Quota.Modules, Devices: config.Quota.Devices}
+	if config.Quota.Bytes != "" {
+		if quota.Bytes, err = bytes.Parse(config.Quota.Bytes); err != nil {
+			config.Logger.Fatal().Err(err).Msg("error while parsing byte quota")
+		}
+	}
+
+	compression, err := service.ParseModuleEncoding(config.Modules.Compression)
+	if err != nil {
+		config.Logger.Fatal().Err(err).Msg("error while parsing module compression")
+	}
+
+	// modules expired after the expiration of the redis backend before the expiry policy was introduced
+	expiry := config.Modules.Expiry
+	if expiry.Default == 0 {
+		expiry.Default = config.Redis.Module.Expiration
+	}
+
+	tombstoneRetention := config.Modules.TombstoneRetention
+	if tombstoneRetention <= 0 {
+		tombstoneRetention = DefaultTombstoneRetention
+	}
+
+	if config.Uploads.MaxChunkSize == "" {
+		config.Uploads.MaxChunkSize = DefaultMaxUploadChunkSize
+	}
+
 	basicAuthWithShare := basic.AuthWithShare(config.Services.Accounts, config.Services.Devices)
 
 	wrapper := REST.ServerInterfaceWrapper{
 		Handler: &API{
-			config.Services.Accounts,
-			config.Services.Sharing,
-			config.Services.Devices,
-			config.Services.Modules,
-			config.Services.MetadataProvider,
-			config.PasswordGenerator,
-			config.UsernameGenerator,
+			Accounts:              config.Services.Accounts,
+			Sharing:               config.Services.Sharing,
+			Devices:               config.Services.Devices,
+			Modules:               config.Services.Modules,
+			MetadataProvider:      config.Services.MetadataProvider,
+			Locker:                config.Services.Locker,
+			History:               config.Services.History,
+			ChangeFeed:            config.Services.ChangeFeed,
+			Events:                config.Services.Events,
+			Presence:              config.Services.Presence,
+			Uploads:               config.Services.Uploads,
+			Usage:                 config.Services.Usage,
+			Schemas:               config.Services.Schemas,
+			PasswordGenerator:     config.PasswordGenerator,
+			UsernameGenerator:     config.UsernameGenerator,
+			RequireDeviceApproval: config.Registration.RequireApproval,
+			AllowedOrigins:        config.Server.CORS.AllowOrigins,
+			MaxModuleSize:         maxModuleSize,
+			Quota:                 quota,
+			Compression:           compression,
+			Encryption:            config.Services.Encryption,
+			Expiry:                expiry,
+			TombstoneRetention:    tombstoneRetention,
 		},
 	}
 
@@ -53,12 +140,40 @@ func New(_ context.Context, engine *echo.Echo, config *config.Config) {
 	auth.POST("/register", wrapper.Register)
 	auth.POST("/share", wrapper.Share, basicAuthWithShare)
 
+	// the colon of the batch methods has to be escaped, echo would treat it as path parameter otherwise
+	api.POST("/modules\\:batchGet", wrapper.BatchGetModules, basicAuthWithShare)
+	api.POST("/modules\\:batchSet", wrapper.BatchSetModules, basicAuthWithShare)
+
 	module := api.Group("/module", basicAuthWithShare)
+	module.GET("", wrapper.ListModules)
 	module.GET("/:name", wrapper.GetModule)
+	module.HEAD("/:name", wrapper.HeadModule)
 	module.POST("/:name", wrapper.CreateModule)
 	module.DELETE("", wrapper.DeleteModules)
+	module.DELETE("/:name", wrapper.DeleteModule)
+	module.GET("/:name/versions", wrapper.GetModuleVersions)
+	module.GET("/:name/versions/:version", wrapper.GetModuleVersion)
+	module.POST("/:name/versions/:version/restore", wrapper.RestoreModuleVersion)
+	module.POST("/:name/uploads", wrapper.CreateUpload)
+
+	// chunks skip the request body limit and are limited by the maximum chunk size instead
+	api.GET(UploadPath, wrapper.GetUpload, basicAuthWithShare)
+	api.PATCH(UploadPath, wrapper.AppendUpload, basicAuthWithShare, middleware.BodyLimit(config.Uploads.MaxChunkSize))
+	api.DELETE(UploadPath, wrapper.DeleteUpload, basicAuthWithShare)
+	api.POST(UploadPath+"/finalize", wrapper.FinalizeUpload, basicAuthWithShare)
+
+	api.GET("/changes", wrapper.GetChanges, basicAuthWithShare)
+	api.GET(EventsPath, wrapper.GetEvents, basicAuthWithShare)
+	api.GET(WebSocketPath, wrapper.ConnectWebSocket, basicAuthWithShare)
+
+	api.GET("/account/usage", wrapper.GetAccountUsage, basicAuthWithShare)
+
+	api.GET("/schemas", wrapper.GetSchemas)
 
 	api.GET("/devices", wrapper.GetDevices, basicAuthWithShare)
+	api.PATCH("/devices/:id", wrapper.UpdateDevice, basicAuthWithShare)
+	api.POST("/devices/:id/approve", wrapper.ApproveDevice, basicAuthWithShare)
+	api.POST("/devices/:id/reject", wrapper.RejectDevice, basicAuthWithShare)
 
 	api.GET("/health", wrapper.IsHealthy)
 	api.GET("/ready", wrapper.IsReady)
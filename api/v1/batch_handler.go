@@ -0,0 +1,526 @@
+package v1
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/jakobmoellerdev/octi-sync-server/api/v1/REST"
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+	"github.com/jakobmoellerdev/octi-sync-server/service/redis"
+)
+
+const (
+	// MaxBatchSize is the maximum amount of modules read or written in a single batch.
+	MaxBatchSize = 100
+
+	MIMEMultipartMixed = "multipart/mixed"
+	MIMEApplicationTar = "application/x-tar"
+
+	// HeaderBatchStatus and HeaderBatchMessage carry the result of a single module in a multipart batch.
+	HeaderBatchStatus  = "X-Status"
+	HeaderBatchMessage = "X-Message"
+	// PAXBatchStatus, PAXBatchMessage and PAXBatchETag carry the result of a single module in a tar batch.
+	PAXBatchStatus  = "OCTI.status"
+	PAXBatchMessage = "OCTI.message"
+	PAXBatchETag    = "OCTI.etag"
+)
+
+var (
+	ErrBatchEmpty             = errors.New("batch does not contain any modules")
+	ErrBatchTooLarge          = fmt.Errorf("batch contains more than %d modules", MaxBatchSize)
+	ErrBatchUnnamedModule     = errors.New("module in batch has no name")
+	ErrBatchDuplicateModule   = errors.New("module is part of the batch more than once")
+	ErrBatchUnsupportedFormat = fmt.Errorf("batch is neither %s nor %s", MIMEMultipartMixed, MIMEApplicationTar)
+)
+
+// batchEntry is a module read in a batch. Modules that could not be read carry the error instead of their data.
+type batchEntry struct {
+	name     string
+	module   service.Module
+	metadata service.Metadata
+	err      error
+}
+
+// batchItem is a module sent for writing in a batch.
+type batchItem struct {
+	name    string
+	data    []byte
+	ifMatch *string
+	headers contentHeaders
+}
+
+func (api *API) BatchGetModules(ctx echo.Context, params REST.BatchGetModulesParams) error {
+	var request REST.BatchGet
+	if err := ctx.Bind(&request); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if len(request.Names) == 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, ErrBatchEmpty.Error())
+	}
+
+	if len(request.Names) > MaxBatchSize {
+		return echo.NewHTTPError(http.StatusBadRequest, ErrBatchTooLarge.Error())
+	}
+
+	acc, device, err := api.resolveDeviceIDAndAccount(ctx, request.Device, &params.XDeviceID)
+	if err != nil {
+		return err
+	}
+
+	requestCtx := ctx.Request().Context()
+	ids := make([]string, len(request.Names))
+
+	for i, name := range request.Names {
+		ids[i] = fmt.Sprintf("%s-%s-%s", acc.Username(), device.ID(), name)
+	}
+
+	modules, err := api.Modules.GetMany(requestCtx, ids)
+	if err != nil {
+		return fmt.Errorf("error while fetching modules: %w", err)
+	}
+
+	metadata, err := api.storedMetadataOf(requestCtx, ids)
+	if err != nil {
+		return err
+	}
+
+	// a module that cannot be decoded fails on its own instead of failing the whole batch
+	entries := make([]batchEntry, len(ids))
+	for i := range ids {
+		entries[i] = batchEntry{name: request.Names[i], metadata: metadata[i]}
+
+		if entries[i].module, err = api.decodeModule(requestCtx, acc, modules[i], metadata[i]); err != nil {
+			entries[i].module, entries[i].err = redis.ModuleFromBytes(nil), err
+		}
+	}
+
+	if strings.Contains(ctx.Request().Header.Get(echo.HeaderAccept), MIMEApplicationTar) {
+		return writeTarBatch(ctx, entries)
+	}
+
+	return writeMultipartBatch(ctx, entries)
+}
+
+func writeMultipartBatch(ctx echo.Context, entries []batchEntry) error {
+	writer := multipart.NewWriter(ctx.Response())
+
+	ctx.Response().Header().Set(echo.HeaderContentType,
+		mime.FormatMediaType(MIMEMultipartMixed, map[string]string{"boundary": writer.Boundary()}))
+	ctx.Response().WriteHeader(http.StatusOK)
+
+	for _, entry := range entries {
+		status, message := entry.status()
+
+		header := textproto.MIMEHeader{}
+		header.Set(echo.HeaderContentDisposition,
+			mime.FormatMediaType("attachment", map[string]string{"name": entry.name}))
+		header.Set(echo.HeaderContentType, contentTypeOf(entry.metadata))
+		header.Set(HeaderBatchStatus, strconv.Itoa(status))
+
+		if message != "" {
+			header.Set(HeaderBatchMessage, message)
+		}
+
+		if entry.module.Size() > 0 && entry.metadata != nil {
+			header.Set(HeaderETag, entityTag(entry.metadata))
+
+			if contentEncoding := entry.metadata.GetContentEncoding(); contentEncoding != "" {
+				header.Set(echo.HeaderContentEncoding, contentEncoding)
+			}
+		}
+
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return fmt.Errorf("could not write batch part of %s: %w", entry.name, err)
+		}
+
+		if _, err := io.Copy(part, entry.module.Raw()); err != nil {
+			return fmt.Errorf("could not write module data of %s to batch: %w", entry.name, err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("could not finish batch: %w", err)
+	}
+
+	return nil
+}
+
+func writeTarBatch(ctx echo.Context, entries []batchEntry) error {
+	writer := tar.NewWriter(ctx.Response())
+
+	ctx.Response().Header().Set(echo.HeaderContentType, MIMEApplicationTar)
+	ctx.Response().WriteHeader(http.StatusOK)
+
+	for _, entry := range entries {
+		status, message := entry.status()
+
+		header := &tar.Header{
+			Typeflag:   tar.TypeReg,
+			Name:       entry.name,
+			Mode:       0o600,
+			Size:       int64(entry.module.Size()),
+			Format:     tar.FormatPAX,
+			PAXRecords: map[string]string{PAXBatchStatus: strconv.Itoa(status)},
+		}
+
+		if message != "" {
+			header.PAXRecords[PAXBatchMessage] = message
+		}
+
+		if entry.metadata != nil {
+			header.ModTime = time.Time(entry.metadata.GetModifiedAt())
+
+			if entry.module.Size() > 0 {
+				header.PAXRecords[PAXBatchETag] = entityTag(entry.metadata)
+			}
+		}
+
+		if err := writer.WriteHeader(header); err != nil {
+			return fmt.Errorf("could not write batch entry of %s: %w", entry.name, err)
+		}
+
+		if _, err := io.Copy(writer, entry.module.Raw()); err != nil {
+			return fmt.Errorf("could not write module data of %s to batch: %w", entry.name, err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("could not finish batch: %w", err)
+	}
+
+	return nil
+}
+
+// status is the status and error message reading the module on its own would have answered with.
+func (e batchEntry) status() (int, string) {
+	switch {
+	case e.err != nil:
+		return errorStatus(e.err)
+	case service.Deleted(e.metadata):
+		return http.StatusGone, ""
+	case e.module.Size() == 0:
+		return http.StatusNoContent, ""
+	default:
+		return http.StatusOK, ""
+	}
+}
+
+func (api *API) BatchSetModules(ctx echo.Context, params REST.BatchSetModulesParams) error {
+	acc, device, err := api.resolveDeviceIDAndAccount(ctx, &params.XDeviceID)
+	if err != nil {
+		return err
+	}
+
+	items, err := readBatch(ctx.Request())
+	if err != nil {
+		return err
+	}
+
+	results := api.writeBatch(ctx, acc, device, items, params.XExpiresIn)
+
+	if err := ctx.JSON(http.StatusOK, &REST.BatchResult{Count: len(results), Items: results}); err != nil {
+		return fmt.Errorf("could not write batch result: %w", err)
+	}
+
+	return nil
+}
+
+// writeBatch writes all items that can be written with a single multi-set and
+// returns the result of each item in the order of the batch. All items expire after the seconds of expiresIn.
+//
+//nolint:funlen
+func (api *API) writeBatch(
+	ctx echo.Context, acc service.Account, device service.Device, items []batchItem, expiresIn *int,
+) []REST.BatchItemResult {
+	requestCtx := ctx.Request().Context()
+	results := make([]REST.BatchItemResult, len(items))
+	writable := make([]int, 0, len(items))
+	seen := make(map[string]bool, len(items))
+
+	for i, item := range items {
+		results[i] = REST.BatchItemResult{Name: item.name}
+		nameErr := validateModuleName(item.name)
+
+		switch {
+		case item.name == "":
+			failBatchItem(&results[i], echo.NewHTTPError(http.StatusBadRequest, ErrBatchUnnamedModule.Error()))
+		case nameErr != nil:
+			failBatchItem(&results[i], nameErr)
+		case seen[item.name]:
+			failBatchItem(&results[i], echo.NewHTTPError(http.StatusBadRequest, ErrBatchDuplicateModule.Error()))
+		default:
+			seen[item.name] = true
+			writable = append(writable, i)
+		}
+	}
+
+	// concurrent batches lock their modules in the same order so that they cannot deadlock each other
+	sort.Slice(writable, func(a, b int) bool { return items[writable[a]].name < items[writable[b]].name })
+
+	writes := make(map[int]*moduleWrite, len(writable))
+
+	defer func() {
+		for _, write := range writes {
+			write.release(requestCtx)
+		}
+	}()
+
+	modules := make(map[string]service.Module, len(writable))
+	expiresAt := make(map[string]time.Time, len(writable))
+
+	// the quota is checked against the usage including the items of the batch accepted before
+	usage, usageErr := api.accountUsage(requestCtx, acc)
+
+	for _, i := range writable {
+		write, err := api.lockModule(requestCtx, acc, device, items[i].name)
+		if err != nil {
+			failBatchItem(&results[i], err)
+
+			continue
+		}
+
+		writes[i] = write
+
+		if write.expiresAt, err = api.expiresAt(items[i].name, expiresIn); err != nil {
+			failBatchItem(&results[i], err)
+
+			continue
+		}
+
+		if err := write.checkPreconditions(items[i].ifMatch, nil); err != nil {
+			failBatchItem(&results[i], err)
+
+			continue
+		}
+
+		// CRDT modules are merged with their stored state instead of being replaced
+		mode, err := write.resolveMode(writeMode{})
+		if err != nil {
+			failBatchItem(&results[i], err)
+
+			continue
+		}
+
+		if err := write.resolveHeaders(items[i].headers, mode); err != nil {
+			failBatchItem(&results[i], err)
+
+			continue
+		}
+
+		if mode.patch != nil {
+			merged, err := api.patchModule(requestCtx, write, mode.patch, items[i].data)
+			if err != nil {
+				failBatchItem(&results[i], err)
+
+				continue
+			}
+
+			items[i].data = merged.Bytes()
+		}
+
+		if err := api.validateModule(requestCtx, items[i].name, items[i].data); err != nil {
+			failBatchItem(&results[i], err)
+
+			continue
+		}
+
+		if usageErr != nil {
+			failBatchItem(&results[i], usageErr)
+
+			continue
+		}
+
+		if usage, err = api.checkModuleQuota(usage, write.current, int64(len(items[i].data))); err != nil {
+			failBatchItem(&results[i], err)
+
+			continue
+		}
+
+		module, err := api.encodeModule(requestCtx, write, bytes.NewReader(items[i].data), len(items[i].data))
+		if err != nil {
+			failBatchItem(&results[i], err)
+
+			continue
+		}
+
+		modules[write.id] = module
+		expiresAt[write.id] = write.expiresAt
+	}
+
+	if len(modules) == 0 {
+		return results
+	}
+
+	if err := api.Modules.SetMany(requestCtx, modules, expiresAt); err != nil {
+		for _, i := range writable {
+			if results[i].Status == 0 {
+				failBatchItem(&results[i], fmt.Errorf("could not create/update modules: %w", err))
+			}
+		}
+
+		return results
+	}
+
+	for _, i := range writable {
+		if results[i].Status != 0 {
+			continue
+		}
+
+		metadata, err := api.commitWrite(
+			ctx, acc, device, writes[i], service.Digest(items[i].data), int64(len(items[i].data)),
+		)
+		if err != nil {
+			failBatchItem(&results[i], err)
+
+			continue
+		}
+
+		results[i].Status = http.StatusAccepted
+		results[i].Etag = optionalString(entityTag(metadata))
+	}
+
+	return results
+}
+
+// failBatchItem records the status the error would have caused for a single module write.
+func failBatchItem(result *REST.BatchItemResult, err error) {
+	status, reason := errorStatus(err)
+
+	result.Status = status
+	result.Message = &reason
+}
+
+// errorStatus is the status and message the error would have caused for a single module request.
+func errorStatus(err error) (int, string) {
+	var httpError *echo.HTTPError
+	if errors.As(err, &httpError) {
+		return httpError.Code, httpErrorMessage(httpError)
+	}
+
+	return http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError)
+}
+
+// readBatch reads all modules of a multipart/mixed or tar batch.
+func readBatch(req *http.Request) ([]batchItem, error) {
+	mediaType, params, err := mime.ParseMediaType(req.Header.Get(echo.HeaderContentType))
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusUnsupportedMediaType, ErrBatchUnsupportedFormat.Error()).
+			SetInternal(err)
+	}
+
+	var items []batchItem
+
+	switch mediaType {
+	case MIMEMultipartMixed:
+		items, err = readMultipartBatch(multipart.NewReader(req.Body, params["boundary"]))
+	case MIMEApplicationTar:
+		items, err = readTarBatch(tar.NewReader(req.Body))
+	default:
+		return nil, echo.NewHTTPError(http.StatusUnsupportedMediaType, ErrBatchUnsupportedFormat.Error())
+	}
+
+	var httpError *echo.HTTPError
+
+	switch {
+	case errors.As(err, &httpError):
+		return nil, err
+	case err != nil:
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "batch could not be read").SetInternal(err)
+	case len(items) == 0:
+		return nil, echo.NewHTTPError(http.StatusBadRequest, ErrBatchEmpty.Error())
+	}
+
+	return items, nil
+}
+
+func readMultipartBatch(reader *multipart.Reader) ([]batchItem, error) {
+	var items []batchItem
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return items, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("could not read batch part: %w", err)
+		}
+
+		if len(items) == MaxBatchSize {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, ErrBatchTooLarge.Error())
+		}
+
+		item := batchItem{
+			name:    partName(part),
+			headers: contentHeaders{contentType: normalizeContentType(part.Header.Get(echo.HeaderContentType))},
+		}
+		if ifMatch := part.Header.Get("If-Match"); ifMatch != "" {
+			item.ifMatch = &ifMatch
+		}
+
+		if item.data, err = io.ReadAll(part); err != nil {
+			return nil, fmt.Errorf("could not read batch part of %s: %w", item.name, err)
+		}
+
+		items = append(items, item)
+	}
+}
+
+// partName reads the module name from the name or filename of the content disposition of the part.
+func partName(part *multipart.Part) string {
+	_, params, err := mime.ParseMediaType(part.Header.Get(echo.HeaderContentDisposition))
+	if err != nil {
+		return ""
+	}
+
+	if name := params["name"]; name != "" {
+		return name
+	}
+
+	return params["filename"]
+}
+
+func readTarBatch(reader *tar.Reader) ([]batchItem, error) {
+	var items []batchItem
+
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return items, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("could not read batch entry: %w", err)
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		if len(items) == MaxBatchSize {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, ErrBatchTooLarge.Error())
+		}
+
+		item := batchItem{name: header.Name}
+		if item.data, err = io.ReadAll(reader); err != nil {
+			return nil, fmt.Errorf("could not read batch entry of %s: %w", item.name, err)
+		}
+
+		items = append(items, item)
+	}
+}
@@ -0,0 +1,203 @@
+package v1_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"testing"
+
+	json "github.com/json-iterator/go"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+
+	v1 "github.com/jakobmoellerdev/octi-sync-server/api/v1"
+	"github.com/jakobmoellerdev/octi-sync-server/api/v1/REST"
+	"github.com/jakobmoellerdev/octi-sync-server/config"
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+func TestAPI_BatchRoutes(t *testing.T) {
+	t.Parallel()
+	log := zerolog.New(zerolog.NewTestWriter(t))
+	router := echo.New()
+	v1.New(context.Background(), router, &config.Config{Logger: &log})
+
+	for _, path := range []string{"/v1/modules:batchGet", "/v1/modules:batchSet"} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, nil))
+		// the batch routes exist and are protected by authentication
+		if rec.Code == http.StatusNotFound {
+			t.Errorf("%s is not routed", path)
+		}
+	}
+}
+
+func TestAPI_BatchModules(t *testing.T) {
+	t.Parallel()
+	_, assertions, router := SetupAPITest(t)
+	api := API()
+	client := newTestDevice(t, api, router, "batch")
+	deviceID := client.deviceID
+
+	serve := func(body io.Reader, contentType, accept string, handle func(echo.Context) error) *httptest.ResponseRecorder {
+		data, err := io.ReadAll(body)
+		assertions.NoError(err)
+
+		ctx, rec := client.request(http.MethodPost, string(data),
+			echo.HeaderContentType, contentType, echo.HeaderAccept, accept)
+		assertions.NoError(handle(ctx))
+
+		return rec
+	}
+	batchSet := func(body io.Reader, contentType string) REST.BatchResult {
+		rec := serve(body, contentType, "", func(ctx echo.Context) error {
+			return api.BatchSetModules(ctx, REST.BatchSetModulesParams{XDeviceID: deviceID})
+		})
+
+		var result REST.BatchResult
+		assertions.Equal(http.StatusOK, rec.Code)
+		assertions.NoError(json.Unmarshal(rec.Body.Bytes(), &result))
+
+		return result
+	}
+	batchGet := func(accept string, names ...string) *httptest.ResponseRecorder {
+		request, _ := json.Marshal(REST.BatchGet{Names: names})
+
+		return serve(bytes.NewReader(request), echo.MIMEApplicationJSON, accept, func(ctx echo.Context) error {
+			return api.BatchGetModules(ctx, REST.BatchGetModulesParams{XDeviceID: deviceID})
+		})
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	for _, part := range []struct{ name, ifMatch, data string }{
+		{"settings", "", "first"},
+		{"sms", `"stale"`, "second"},
+		{"settings", "", "third"},
+		{"", "", "fourth"},
+	} {
+		header := textproto.MIMEHeader{}
+		header.Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"name": part.name}))
+
+		if part.ifMatch != "" {
+			header.Set("If-Match", part.ifMatch)
+		}
+
+		partWriter, err := writer.CreatePart(header)
+		assertions.NoError(err)
+		_, err = partWriter.Write([]byte(part.data))
+		assertions.NoError(err)
+	}
+
+	assertions.NoError(writer.Close())
+
+	result := batchSet(&body, v1.MIMEMultipartMixed+"; boundary="+writer.Boundary())
+
+	if assertions.Equal(4, result.Count) {
+		assertions.Equal(http.StatusAccepted, result.Items[0].Status)
+		assertions.NotNil(result.Items[0].Etag)
+		assertions.Equal(http.StatusPreconditionFailed, result.Items[1].Status)
+		assertions.Equal(http.StatusBadRequest, result.Items[2].Status)
+		assertions.Equal(http.StatusBadRequest, result.Items[3].Status)
+	}
+
+	body.Reset()
+	tarWriter := tar.NewWriter(&body)
+
+	for _, name := range []string{"sms", "../escape"} {
+		assertions.NoError(tarWriter.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: name, Size: 3, Mode: 0o600}))
+		_, err := tarWriter.Write([]byte("sms"))
+		assertions.NoError(err)
+	}
+
+	assertions.NoError(tarWriter.Close())
+
+	result = batchSet(&body, v1.MIMEApplicationTar)
+
+	if assertions.Equal(2, result.Count) {
+		assertions.Equal("sms", result.Items[0].Name)
+		assertions.Equal(http.StatusAccepted, result.Items[0].Status)
+		// entries are only written with valid module names
+		assertions.Equal("../escape", result.Items[1].Name)
+		assertions.Equal(http.StatusBadRequest, result.Items[1].Status)
+	}
+
+	rec := batchGet("", "settings", "missing", "sms")
+	mediaType, params, err := mime.ParseMediaType(rec.Header().Get(echo.HeaderContentType))
+	assertions.NoError(err)
+	assertions.Equal(v1.MIMEMultipartMixed, mediaType)
+
+	reader := multipart.NewReader(rec.Body, params["boundary"])
+
+	for _, expected := range []struct{ name, status, data string }{
+		{"settings", "200", "first"},
+		{"missing", "204", ""},
+		{"sms", "200", "sms"},
+	} {
+		part, err := reader.NextPart()
+		if !assertions.NoError(err) {
+			return
+		}
+
+		data, _ := io.ReadAll(part)
+		_, disposition, err := mime.ParseMediaType(part.Header.Get(echo.HeaderContentDisposition))
+		assertions.NoError(err)
+		assertions.Equal(expected.name, disposition["name"])
+		assertions.Equal(expected.status, part.Header.Get(v1.HeaderBatchStatus))
+		assertions.Equal(expected.data, string(data))
+	}
+
+	// a module that cannot be decoded fails on its own
+	requestCtx := context.Background()
+	smsID := service.MetadataID(client.moduleID("sms"))
+	stored, err := api.MetadataProvider.Get(requestCtx, smsID)
+	assertions.NoError(err)
+
+	corrupted := service.MetadataOf(stored)
+	corrupted.Encoding = service.EncodingGzip
+	assertions.NoError(api.MetadataProvider.Set(requestCtx, &corrupted))
+
+	rec = batchGet(v1.MIMEApplicationTar, "sms", "settings")
+	tarReader := tar.NewReader(rec.Body)
+
+	header, err := tarReader.Next()
+	if assertions.NoError(err) {
+		assertions.Equal("500", header.PAXRecords[v1.PAXBatchStatus])
+		assertions.NotEmpty(header.PAXRecords[v1.PAXBatchMessage])
+		assertions.Zero(header.Size)
+	}
+
+	header, err = tarReader.Next()
+	if assertions.NoError(err) {
+		data, _ := io.ReadAll(tarReader)
+		assertions.Equal("200", header.PAXRecords[v1.PAXBatchStatus])
+		assertions.Equal("first", string(data))
+	}
+
+	assertions.NoError(api.MetadataProvider.Set(requestCtx, stored))
+
+	rec = batchGet(v1.MIMEApplicationTar, "sms")
+	assertions.Equal(v1.MIMEApplicationTar, rec.Header().Get(echo.HeaderContentType))
+
+	tarReader = tar.NewReader(rec.Body)
+	header, err = tarReader.Next()
+
+	if assertions.NoError(err) {
+		data, _ := io.ReadAll(tarReader)
+		assertions.Equal("sms", header.Name)
+		assertions.Equal("200", header.PAXRecords[v1.PAXBatchStatus])
+		assertions.NotEmpty(header.PAXRecords[v1.PAXBatchETag])
+		assertions.Equal("sms", string(data))
+	}
+
+	ctx, _ := client.request(http.MethodPost, "{}", echo.HeaderContentType, echo.MIMEApplicationJSON)
+	assertHTTPError(assertions, api.BatchSetModules(ctx, REST.BatchSetModulesParams{XDeviceID: deviceID}),
+		http.StatusUnsupportedMediaType)
+}
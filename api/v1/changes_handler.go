@@ -0,0 +1,94 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/jakobmoellerdev/octi-sync-server/api/v1/REST"
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+func (api *API) GetChanges(ctx echo.Context, params REST.GetChangesParams) error {
+	acc, _, err := api.resolveDeviceIDAndAccount(ctx, &params.XDeviceID)
+	if err != nil {
+		return err
+	}
+
+	var since string
+	if params.Since != nil {
+		since = *params.Since
+	}
+
+	var limit int
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	changes, err := api.ChangeFeed.Since(ctx.Request().Context(), acc, since, limit)
+	if errors.Is(err, service.ErrInvalidCursor) {
+		return echo.NewHTTPError(http.StatusBadRequest, service.ErrInvalidCursor.Error()).SetInternal(err)
+	}
+
+	if errors.Is(err, service.ErrCursorExpired) {
+		return echo.NewHTTPError(http.StatusGone, service.ErrCursorExpired.Error()).SetInternal(err)
+	}
+
+	if err != nil {
+		return fmt.Errorf("error while listing changes: %w", err)
+	}
+
+	items := make([]REST.Change, len(changes))
+	cursor := since
+
+	for i, change := range changes {
+		items[i] = REST.Change{
+			Cursor:     change.Cursor,
+			Name:       change.Module,
+			Device:     change.Device.UUID(),
+			Operation:  REST.ChangeOperation(change.Operation),
+			ModifiedAt: change.ModifiedAt.UTC(),
+		}
+		cursor = change.Cursor
+	}
+
+	if err := ctx.JSON(http.StatusOK, &REST.ChangeList{
+		Count:  len(items),
+		Items:  items,
+		Cursor: optionalString(cursor),
+	}); err != nil {
+		return fmt.Errorf("could not write change list response: %w", err)
+	}
+
+	return nil
+}
+
+// recordChange appends the change of the module to the change feed of the account and notifies its devices.
+func (api *API) recordChange(
+	ctx echo.Context, acc service.Account, device service.Device,
+	name string, operation service.ChangeOperation, modifiedAt time.Time,
+) error {
+	cursor, err := api.ChangeFeed.Append(ctx.Request().Context(), acc, service.Change{
+		Module:     name,
+		Device:     device.ID(),
+		Operation:  operation,
+		ModifiedAt: modifiedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("could not record module change: %w", err)
+	}
+
+	api.publish(ctx, acc, service.Event{
+		Type:      service.EventModuleChanged,
+		Device:    device.ID(),
+		Module:    name,
+		Operation: operation,
+		Cursor:    cursor,
+		At:        modifiedAt,
+	})
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package v1_test
+
+import (
+	"net/http"
+	"testing"
+
+	json "github.com/json-iterator/go"
+
+	"github.com/jakobmoellerdev/octi-sync-server/api/v1/REST"
+	"github.com/jakobmoellerdev/octi-sync-server/service/memory"
+)
+
+func TestAPI_GetChanges(t *testing.T) {
+	t.Parallel()
+	_, assertions, router := SetupAPITest(t)
+	api := API()
+	api.ChangeFeed = memory.NewChangeFeed(3)
+
+	client := newTestDevice(t, api, router, "changes")
+
+	changes := func(since *string) (REST.ChangeList, error) {
+		ctx, rec := client.request(http.MethodGet, "")
+
+		var list REST.ChangeList
+		if err := api.GetChanges(ctx, REST.GetChangesParams{XDeviceID: client.deviceID, Since: since}); err != nil {
+			return list, err
+		}
+
+		return list, json.Unmarshal(rec.Body.Bytes(), &list)
+	}
+
+	list, err := changes(nil)
+	assertions.NoError(err)
+	assertions.Equal(0, list.Count)
+	assertions.Nil(list.Cursor)
+
+	assertions.NoError(client.write("settings", "first"))
+	assertions.NoError(client.write("settings", "second"))
+	assertions.NoError(client.write("sms", "third"))
+
+	list, err = changes(nil)
+	assertions.NoError(err)
+
+	if assertions.Equal(3, list.Count) {
+		assertions.Equal(REST.Created, list.Items[0].Operation)
+		assertions.Equal(REST.Updated, list.Items[1].Operation)
+		assertions.Equal("sms", list.Items[2].Name)
+		assertions.Equal(client.deviceID, list.Items[2].Device)
+		assertions.Equal(list.Items[2].Cursor, *list.Cursor)
+	}
+
+	cursor, first := list.Cursor, list.Items[0].Cursor
+
+	ctx, _ := client.request(http.MethodDelete, "")
+	assertions.NoError(api.DeleteModules(ctx, REST.DeleteModulesParams{XDeviceID: client.deviceID}))
+
+	list, err = changes(cursor)
+	assertions.NoError(err)
+
+	if assertions.Equal(2, list.Count) {
+		assertions.Equal(REST.Deleted, list.Items[0].Operation)
+		assertions.Equal("settings", list.Items[0].Name)
+		assertions.Equal("sms", list.Items[1].Name)
+	}
+
+	list, err = changes(list.Cursor)
+	assertions.NoError(err)
+	assertions.Equal(0, list.Count)
+	assertions.NotNil(list.Cursor)
+
+	invalid := "invalid"
+	_, err = changes(&invalid)
+	assertHTTPError(assertions, err, http.StatusBadRequest)
+
+	// changes after the first one were no longer retained, so the client has to sync again
+	_, err = changes(&first)
+	assertHTTPError(assertions, err, http.StatusGone)
+}
@@ -0,0 +1,255 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+	"github.com/jakobmoellerdev/octi-sync-server/service/redis"
+)
+
+var ErrEncryptionNotConfigured = errors.New("module is encrypted at rest, but no master keys are configured")
+
+// EncodesResponse reports whether the handler negotiates the content encoding of the response itself,
+// so that modules compressed at rest are neither decompressed nor compressed again by the compression middleware.
+func EncodesResponse(ctx echo.Context) bool {
+	if method := ctx.Request().Method; method != http.MethodGet && method != http.MethodHead {
+		return false
+	}
+
+	path := ctx.Path()
+
+	return path == Prefix+"/module/:name" || path == Prefix+"/module/:name/versions/:version"
+}
+
+// encodeModule compresses the data with the configured encoding and encrypts it with the data key of the account
+// for storing it, and records both in the write. Empty modules are stored as is, data that is content encoded
+// by the client or of a compressed content type is not compressed again.
+func (api *API) encodeModule(
+	ctx context.Context, write *moduleWrite, data io.Reader, size int,
+) (service.Module, error) {
+	write.encoding, write.keyID = service.EncodingIdentity, ""
+
+	if size == 0 {
+		return redis.ModuleFromReader(data, size), nil
+	}
+
+	// the size of the stored data is only known once it was stored
+	if api.Compression != service.EncodingIdentity &&
+		write.headers.contentEncoding == "" && compressible(write.headers.contentType) {
+		write.encoding = api.Compression
+		data, size = api.Compression.Encode(data), -1
+	}
+
+	if api.Encryption != nil {
+		encrypted, keyID, err := api.Encryption.Encrypt(ctx, write.account, data)
+		if err != nil {
+			return nil, fmt.Errorf("could not encrypt module: %w", err)
+		}
+
+		write.keyID = keyID
+		data, size = encrypted, -1
+	}
+
+	return redis.ModuleFromReader(data, size), nil
+}
+
+// decryptModule returns the module with its data decrypted according to its metadata, but still compressed.
+func (api *API) decryptModule(
+	ctx context.Context, acc service.Account, module service.Module, metadata service.Metadata,
+) (service.Module, error) {
+	if metadata == nil || metadata.GetKeyID() == "" || module.Size() == 0 {
+		return module, nil
+	}
+
+	if api.Encryption == nil {
+		return nil, ErrEncryptionNotConfigured
+	}
+
+	data, err := api.Encryption.Decrypt(ctx, acc, metadata.GetKeyID(), module.Raw())
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt module: %w", err)
+	}
+
+	size := -1
+	if metadata.GetEncoding() == service.EncodingIdentity {
+		size = int(metadata.GetSize())
+	}
+
+	return redis.ModuleFromReader(data, size), nil
+}
+
+// decodeModule returns the module with its data decrypted and decompressed according to its metadata,
+// modules without metadata are stored as is.
+func (api *API) decodeModule(
+	ctx context.Context, acc service.Account, module service.Module, metadata service.Metadata,
+) (service.Module, error) {
+	if metadata == nil || module.Size() == 0 {
+		return module, nil
+	}
+
+	module, err := api.decryptModule(ctx, acc, module, metadata)
+	if err != nil || metadata.GetEncoding() == service.EncodingIdentity {
+		return module, err
+	}
+
+	data, err := metadata.GetEncoding().Decode(module.Raw())
+	if err != nil {
+		return nil, fmt.Errorf("could not decode module data: %w", err)
+	}
+
+	return redis.ModuleFromReader(data, int(metadata.GetSize())), nil
+}
+
+// moduleCoding is how the data of a module is encoded for a response.
+type moduleCoding int
+
+const (
+	// codingIdentity sends the data decoded.
+	codingIdentity moduleCoding = iota
+	// codingContent sends the data with the content encoding of the client it was written with.
+	codingContent
+	// codingAtRest sends the data as it is compressed at rest.
+	codingAtRest
+	// codingGzip sends the data compressed with gzip for the response.
+	codingGzip
+)
+
+// negotiateCoding picks how the data of the module is sent to the client.
+// Data compressed at rest is sent as is to clients accepting its encoding, otherwise it is decompressed and
+// compressed with gzip if the client accepts it and its content type is not compressed already.
+// Data content encoded by the client is always sent with its encoding.
+func negotiateCoding(ctx echo.Context, metadata service.Metadata, empty bool) moduleCoding {
+	accepted := ctx.Request().Header.Get(echo.HeaderAcceptEncoding)
+
+	switch {
+	case empty:
+		return codingIdentity
+	case metadata != nil && metadata.GetContentEncoding() != "":
+		return codingContent
+	case metadata != nil && metadata.GetEncoding() != service.EncodingIdentity &&
+		acceptsEncoding(accepted, metadata.GetEncoding()):
+		return codingAtRest
+	case compressible(contentTypeOf(metadata)) && acceptsEncoding(accepted, service.EncodingGzip):
+		return codingGzip
+	default:
+		return codingIdentity
+	}
+}
+
+// contentEncoding is the Content-Encoding of a response with the coding, empty for decoded data.
+func (c moduleCoding) contentEncoding(metadata service.Metadata) string {
+	switch c {
+	case codingContent:
+		return metadata.GetContentEncoding()
+	case codingAtRest:
+		return string(metadata.GetEncoding())
+	case codingGzip:
+		return string(service.EncodingGzip)
+	default:
+		return ""
+	}
+}
+
+// recoded reports whether the data is encoded by the server for the response,
+// so that it differs from the data sent to clients accepting other encodings.
+func (c moduleCoding) recoded() bool {
+	return c == codingAtRest || c == codingGzip
+}
+
+// setCoding announces the coding of the response, recoded responses carry the ETag of their encoding.
+func setCoding(ctx echo.Context, metadata service.Metadata, coding moduleCoding) {
+	header := ctx.Response().Header()
+	header.Add(echo.HeaderVary, echo.HeaderAcceptEncoding)
+
+	if contentEncoding := coding.contentEncoding(metadata); contentEncoding != "" {
+		header.Set(echo.HeaderContentEncoding, contentEncoding)
+	}
+
+	if coding.recoded() && header.Get(HeaderETag) != "" {
+		header.Set(HeaderETag, representationTag(metadata, coding.contentEncoding(metadata)))
+	}
+}
+
+// streamModule writes the data of the module to the response with the content headers it was written with,
+// encoded as negotiated with the client.
+func (api *API) streamModule(
+	ctx echo.Context, acc service.Account, status int, module service.Module, metadata service.Metadata,
+) error {
+	contentType := contentTypeOf(metadata)
+	empty := module.Size() == 0
+
+	if !empty {
+		setContentDisposition(ctx, metadata)
+	}
+
+	if !empty && metadata != nil && metadata.GetSize() > 0 {
+		ctx.Response().Header().Set(HeaderAcceptRanges, byteRangeUnit)
+	}
+
+	coding := negotiateCoding(ctx, metadata, empty)
+	setCoding(ctx, metadata, coding)
+
+	if coding == codingAtRest {
+		decrypted, err := api.decryptModule(ctx.Request().Context(), acc, module, metadata)
+		if err != nil {
+			return err
+		}
+
+		return stream(ctx, status, contentType, decrypted.Raw())
+	}
+
+	decoded, err := api.decodeModule(ctx.Request().Context(), acc, module, metadata)
+	if err != nil {
+		return err
+	}
+
+	data := decoded.Raw()
+
+	if coding == codingGzip {
+		data = service.EncodingGzip.Encode(data)
+	}
+
+	return stream(ctx, status, contentType, data)
+}
+
+func stream(ctx echo.Context, status int, contentType string, data io.Reader) error {
+	if err := ctx.Stream(status, contentType, data); err != nil {
+		return fmt.Errorf("error while writing module data to response: %w", err)
+	}
+
+	return nil
+}
+
+// acceptsEncoding reports whether the Accept-Encoding header of the client accepts the encoding,
+// an explicit entry of the encoding takes precedence over the wildcard.
+func acceptsEncoding(header string, encoding service.ModuleEncoding) bool {
+	wildcard := false
+
+	for _, coding := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(coding, ";")
+		name = strings.TrimSpace(name)
+
+		acceptable := true
+		if quality, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			q, err := strconv.ParseFloat(quality, 64)
+			acceptable = err == nil && q > 0
+		}
+
+		switch name {
+		case string(encoding):
+			return acceptable
+		case "*":
+			wildcard = acceptable
+		}
+	}
+
+	return wildcard
+}
@@ -0,0 +1,126 @@
+package v1_test
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	json "github.com/json-iterator/go"
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/jakobmoellerdev/octi-sync-server/api/v1"
+	"github.com/jakobmoellerdev/octi-sync-server/api/v1/REST"
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+func TestAPI_ModuleCompression(t *testing.T) {
+	t.Parallel()
+	_, assertions, router := SetupAPITest(t)
+	api := API()
+	api.Compression = service.EncodingZstd
+
+	client := newTestDevice(t, api, router, "compression")
+	data := strings.Repeat(`{"theme": "dark"}`, 1000)
+
+	read := func(acceptEncoding string) *httptest.ResponseRecorder {
+		rec, err := client.read("settings", echo.HeaderAcceptEncoding, acceptEncoding)
+		assertions.NoError(err)
+
+		return rec
+	}
+
+	assertions.NoError(client.write("settings", data))
+
+	// clients accepting the stored encoding receive the compressed data as is
+	rec := read("")
+	etag := rec.Header().Get(v1.HeaderETag)
+	assertions.NotEmpty(etag)
+
+	rec = read("gzip, zstd")
+	assertions.Equal("zstd", rec.Header().Get(echo.HeaderContentEncoding))
+	// responses encoded by the server carry the ETag of their encoding
+	assertions.Equal(strings.TrimSuffix(etag, `"`)+`-zstd"`, rec.Header().Get(v1.HeaderETag))
+
+	decoder, err := service.EncodingZstd.Decode(rec.Body)
+	if assertions.NoError(err) {
+		decoded, err := io.ReadAll(decoder)
+		assertions.NoError(err)
+		assertions.Equal(data, string(decoded))
+	}
+
+	rec = read("gzip, zstd;q=0")
+	assertions.Equal("gzip", rec.Header().Get(echo.HeaderContentEncoding))
+	gzipETag := rec.Header().Get(v1.HeaderETag)
+	assertions.Equal(strings.TrimSuffix(etag, `"`)+`-gzip"`, gzipETag)
+
+	gzipReader, err := gzip.NewReader(rec.Body)
+	if assertions.NoError(err) {
+		decoded, err := io.ReadAll(gzipReader)
+		assertions.NoError(err)
+		assertions.Equal(data, string(decoded))
+	}
+
+	rec = read("")
+	assertions.Empty(rec.Header().Get(echo.HeaderContentEncoding))
+	assertions.Equal(data, rec.Body.String())
+
+	// the ETag of an encoding stands for the same version of the module
+	ctx, rec := client.request(http.MethodGet, "", echo.HeaderAcceptEncoding, "gzip, zstd;q=0")
+	assertions.NoError(api.GetModule(ctx, "settings",
+		REST.GetModuleParams{XDeviceID: client.deviceID, IfNoneMatch: &gzipETag}))
+	assertions.Equal(http.StatusNotModified, rec.Code)
+	assertions.Equal(gzipETag, rec.Header().Get(v1.HeaderETag))
+
+	ctx, rec = client.request(http.MethodHead, "", echo.HeaderAcceptEncoding, "gzip, zstd;q=0")
+	assertions.NoError(api.HeadModule(ctx, "settings", REST.HeadModuleParams{XDeviceID: client.deviceID}))
+	// HEAD negotiates the encoding like GET, the length of data encoded for the response is unknown
+	assertions.Equal("gzip", rec.Header().Get(echo.HeaderContentEncoding))
+	assertions.Equal(gzipETag, rec.Header().Get(v1.HeaderETag))
+	assertions.Empty(rec.Header().Get(echo.HeaderContentLength))
+
+	ctx, rec = client.request(http.MethodHead, "")
+	assertions.NoError(api.HeadModule(ctx, "settings", REST.HeadModuleParams{XDeviceID: client.deviceID}))
+	assertions.Empty(rec.Header().Get(echo.HeaderContentEncoding))
+	assertions.Equal(etag, rec.Header().Get(v1.HeaderETag))
+	assertions.Equal(strconv.Itoa(len(data)), rec.Header().Get(echo.HeaderContentLength))
+
+	ctx, rec = client.request(http.MethodHead, "")
+	assertions.NoError(api.HeadModule(ctx, "settings",
+		REST.HeadModuleParams{XDeviceID: client.deviceID, IfNoneMatch: &gzipETag}))
+	assertions.Equal(http.StatusNotModified, rec.Code)
+	assertions.Equal(etag, rec.Header().Get(v1.HeaderETag))
+
+	// the version recorded in the history is compressed as well
+	ctx, rec = client.request(http.MethodGet, "")
+	assertions.NoError(api.GetModuleVersion(ctx, "settings", 1, REST.GetModuleVersionParams{XDeviceID: client.deviceID}))
+	assertions.Equal(data, rec.Body.String())
+
+	// modules are listed with the size of their data before compression
+	ctx, rec = client.request(http.MethodGet, "")
+	assertions.NoError(api.ListModules(ctx, REST.ListModulesParams{XDeviceID: client.deviceID}))
+
+	var list REST.ModuleList
+
+	assertions.NoError(json.Unmarshal(rec.Body.Bytes(), &list))
+
+	if assertions.Len(list.Items, 1) {
+		assertions.Equal(int64(len(data)), list.Items[0].Size)
+	}
+
+	// changing the compression keeps modules written before readable and recompresses them on their next write
+	api.Compression = service.EncodingIdentity
+
+	assertions.Equal(data, read("").Body.String())
+
+	assertions.NoError(client.write("settings", data))
+
+	rec = read("zstd")
+	assertions.Empty(rec.Header().Get(echo.HeaderContentEncoding))
+	assertions.Equal(data, rec.Body.String())
+	// the data did not change, so neither did its version
+	assertions.Equal(etag, rec.Header().Get(v1.HeaderETag))
+}
@@ -0,0 +1,141 @@
+package v1
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/jakobmoellerdev/octi-sync-server/api/v1/REST"
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+const (
+	HeaderETag              = "ETag"
+	HeaderLastModified      = "Last-Modified"
+	anyEntityTag            = "*"
+	weakEntityTagIdentifier = "W/"
+)
+
+// entityTag is the strong ETag of the content described by the metadata,
+// it is empty for content written before hashes were recorded.
+func entityTag(metadata service.Metadata) string {
+	if metadata == nil || metadata.GetHash() == "" {
+		return ""
+	}
+
+	return `"` + metadata.GetHash() + `"`
+}
+
+// representationTag is the ETag of the content described by the metadata encoded by the server with the encoding,
+// so that differently encoded responses never share a strong ETag. It is the entity tag with the encoding as suffix.
+func representationTag(metadata service.Metadata, encoding string) string {
+	etag := entityTag(metadata)
+	if etag == "" || encoding == "" {
+		return etag
+	}
+
+	return strings.TrimSuffix(etag, `"`) + "-" + encoding + `"`
+}
+
+// setValidators announces the version of the content described by the metadata to the client.
+func setValidators(ctx echo.Context, metadata service.Metadata) {
+	header := ctx.Response().Header()
+	modifiedAt := time.Time(metadata.GetModifiedAt())
+
+	header.Set(XModifiedAt, REST.ModifiedAtTimestamp(modifiedAt).Format(time.RFC3339))
+	header.Set(HeaderLastModified, modifiedAt.UTC().Format(http.TimeFormat))
+
+	if etag := entityTag(metadata); etag != "" {
+		header.Set(HeaderETag, etag)
+	}
+}
+
+// notModified evaluates If-None-Match and If-Modified-Since as described in RFC 9110 section 13.2.2,
+// If-Modified-Since is ignored if If-None-Match is present.
+func notModified(ifNoneMatch, ifModifiedSince *string, current service.Metadata) bool {
+	if current == nil {
+		return false
+	}
+
+	if ifNoneMatch != nil {
+		return matchesEntityTag(*ifNoneMatch, entityTag(current), true)
+	}
+
+	if ifModifiedSince != nil {
+		since, err := http.ParseTime(*ifModifiedSince)
+
+		return err == nil && !modifiedAfter(current, since)
+	}
+
+	return false
+}
+
+// preconditionFailed evaluates If-Match and If-Unmodified-Since as described in RFC 9110 section 13.2.2,
+// If-Unmodified-Since is ignored if If-Match is present. Current metadata is nil if there is no content yet.
+func preconditionFailed(ifMatch, ifUnmodifiedSince *string, current service.Metadata) bool {
+	if ifMatch != nil {
+		return current == nil || !matchesEntityTag(*ifMatch, entityTag(current), false)
+	}
+
+	if ifUnmodifiedSince != nil {
+		since, err := http.ParseTime(*ifUnmodifiedSince)
+
+		return err == nil && current != nil && modifiedAfter(current, since)
+	}
+
+	return false
+}
+
+// modifiedAfter compares at the one second precision of HTTP dates.
+func modifiedAfter(metadata service.Metadata, since time.Time) bool {
+	return time.Time(metadata.GetModifiedAt()).Truncate(time.Second).After(since)
+}
+
+// matchesEntityTag checks if the ETag is contained in the comma separated list of an If-Match or If-None-Match
+// header, * matches any existing content. Weak comparison ignores the weakness indicator, strong comparison never matches weak tags.
+// The ETags of the content encoded for a response match as well, as they stand for the same version of the content.
+func matchesEntityTag(header, etag string, weak bool) bool {
+	if strings.TrimSpace(header) == anyEntityTag {
+		return true
+	}
+
+	if etag == "" {
+		return false
+	}
+
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+
+		if strings.HasPrefix(candidate, weakEntityTagIdentifier) {
+			if !weak {
+				continue
+			}
+
+			candidate = strings.TrimPrefix(candidate, weakEntityTagIdentifier)
+		}
+
+		if sameVersion(candidate, etag) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sameVersion reports whether the candidate is the ETag or the representation ETag of any encoding of the same content.
+func sameVersion(candidate, etag string) bool {
+	if candidate == etag {
+		return true
+	}
+
+	encoding, found := strings.CutPrefix(candidate, strings.TrimSuffix(etag, `"`)+"-")
+	if !found {
+		return false
+	}
+
+	encoding, found = strings.CutSuffix(encoding, `"`)
+
+	return found && encoding != "" && !strings.Contains(encoding, `"`)
+}
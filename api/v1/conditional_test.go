@@ -0,0 +1,111 @@
+package v1_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "github.com/jakobmoellerdev/octi-sync-server/api/v1"
+	"github.com/jakobmoellerdev/octi-sync-server/api/v1/REST"
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+func TestAPI_ConditionalModuleRequests(t *testing.T) {
+	t.Parallel()
+	_, assertions, router := SetupAPITest(t)
+	api := API()
+	client := newTestDevice(t, api, router, "conditional")
+	deviceID := client.deviceID
+
+	serve := func(method, body string, headers map[string]string) (*httptest.ResponseRecorder, error) {
+		ctx, rec := client.request(method, body)
+		for key, value := range headers {
+			ctx.Request().Header.Set(key, value)
+		}
+
+		optional := func(key string) *string {
+			if value, found := headers[key]; found {
+				return &value
+			}
+
+			return nil
+		}
+
+		if method == http.MethodPost {
+			return rec, api.CreateModule(ctx, "conditional", REST.CreateModuleParams{
+				XDeviceID:         deviceID,
+				IfMatch:           optional("If-Match"),
+				IfUnmodifiedSince: optional("If-Unmodified-Since"),
+			})
+		}
+
+		return rec, api.GetModule(ctx, "conditional", REST.GetModuleParams{
+			XDeviceID:       deviceID,
+			IfNoneMatch:     optional("If-None-Match"),
+			IfModifiedSince: optional("If-Modified-Since"),
+		})
+	}
+
+	// If-Match requires the module to exist
+	_, err := serve(http.MethodPost, "first", map[string]string{"If-Match": "*"})
+	assertHTTPError(assertions, err, http.StatusPreconditionFailed)
+
+	rec, err := serve(http.MethodPost, "first", nil)
+	assertions.NoError(err)
+	assertions.Equal(http.StatusAccepted, rec.Code)
+
+	firstTag := rec.Header().Get(v1.HeaderETag)
+	assertions.NotEmpty(firstTag)
+	assertions.NotEmpty(rec.Header().Get(v1.HeaderLastModified))
+
+	rec, err = serve(http.MethodGet, "", nil)
+	assertions.NoError(err)
+	assertions.Equal(http.StatusOK, rec.Code)
+	assertions.Equal("first", rec.Body.String())
+	assertions.Equal(firstTag, rec.Header().Get(v1.HeaderETag))
+
+	rec, err = serve(http.MethodGet, "", map[string]string{"If-None-Match": `"other", W/` + firstTag})
+	assertions.NoError(err)
+	assertions.Equal(http.StatusNotModified, rec.Code)
+	assertions.Empty(rec.Body.String())
+
+	rec, err = serve(http.MethodGet, "", map[string]string{
+		"If-Modified-Since": time.Now().Add(time.Minute).UTC().Format(http.TimeFormat),
+	})
+	assertions.NoError(err)
+	assertions.Equal(http.StatusNotModified, rec.Code)
+
+	// the ETag of the data encoded for a response stands for the same version
+	gzipTag := strings.TrimSuffix(firstTag, `"`) + `-gzip"`
+	rec, err = serve(http.MethodPost, "second", map[string]string{"If-Match": gzipTag})
+	assertions.NoError(err)
+	assertions.Equal(http.StatusAccepted, rec.Code)
+
+	secondTag := rec.Header().Get(v1.HeaderETag)
+	assertions.NotEqual(firstTag, secondTag)
+
+	// a device that still holds the first version must not overwrite the second one
+	_, err = serve(http.MethodPost, "stale", map[string]string{"If-Match": firstTag})
+	assertHTTPError(assertions, err, http.StatusPreconditionFailed)
+
+	_, err = serve(http.MethodPost, "stale", map[string]string{
+		"If-Unmodified-Since": time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat),
+	})
+	assertHTTPError(assertions, err, http.StatusPreconditionFailed)
+
+	rec, err = serve(http.MethodGet, "", map[string]string{"If-None-Match": firstTag})
+	assertions.NoError(err)
+	assertions.Equal(http.StatusOK, rec.Code)
+	assertions.Equal("second", rec.Body.String())
+
+	metadata, err := api.MetadataProvider.Get(
+		context.Background(),
+		service.MetadataID(client.moduleID("conditional")),
+	)
+	if assertions.NoError(err) {
+		assertions.Equal(int64(2), metadata.GetVersion())
+	}
+}
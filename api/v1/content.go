@@ -0,0 +1,194 @@
+package v1
+
+import (
+	"encoding/base64"
+	"errors"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+var ErrPatchOfEncodedContent = errors.New("patches and crdt states cannot be sent with a content encoding")
+
+// incompressibleTypes are media types whose data is compressed already, compressing it again only costs time.
+var incompressibleTypes = map[string]bool{
+	"application/gzip":             true,
+	"application/zip":              true,
+	"application/zstd":             true,
+	"application/x-7z-compressed":  true,
+	"application/x-bzip2":          true,
+	"application/x-xz":             true,
+	"application/vnd.rar":          true,
+	"application/x-rar-compressed": true,
+	"font/woff":                    true,
+	"font/woff2":                   true,
+}
+
+// contentHeaders describe the data of a module as declared by the client that wrote it.
+type contentHeaders struct {
+	// contentType is the media type of the data, empty if not declared
+	contentType string
+	// contentEncoding is the encoding the data is stored and served with as is, empty if it is not encoded
+	contentEncoding string
+	// filename is the name of the file the data was written from, empty if not named
+	filename string
+}
+
+// contentHeadersOf reads the content headers of a request writing a module.
+// Data sent with gzip was already decompressed by the middleware, other encodings are kept as they are.
+func contentHeadersOf(header http.Header) contentHeaders {
+	headers := contentHeaders{contentType: normalizeContentType(header.Get(echo.HeaderContentType))}
+
+	if _, params, err := mime.ParseMediaType(header.Get(echo.HeaderContentDisposition)); err == nil {
+		headers.filename = params["filename"]
+	}
+
+	if contentEncoding := header.Get(echo.HeaderContentEncoding); contentEncoding != string(service.EncodingGzip) {
+		headers.contentEncoding = normalizeContentEncoding(contentEncoding)
+	}
+
+	return headers
+}
+
+// contentHeadersOfUpload reads the filetype and filename from the metadata of a tus upload,
+// which are comma separated pairs of a key and its base64 encoded value.
+func contentHeadersOfUpload(uploadMetadata *string) contentHeaders {
+	var headers contentHeaders
+
+	if uploadMetadata == nil {
+		return headers
+	}
+
+	for _, pair := range strings.Split(*uploadMetadata, ",") {
+		key, encoded, _ := strings.Cut(strings.TrimSpace(pair), " ")
+
+		value, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil {
+			continue
+		}
+
+		switch key {
+		case "filetype":
+			headers.contentType = normalizeContentType(string(value))
+		case "filename":
+			headers.filename = string(value)
+		}
+	}
+
+	return headers
+}
+
+// contentHeadersOfMetadata returns the content headers a module was written with.
+func contentHeadersOfMetadata(metadata service.Metadata) contentHeaders {
+	if metadata == nil {
+		return contentHeaders{}
+	}
+
+	return contentHeaders{
+		contentType:     metadata.GetContentType(),
+		contentEncoding: metadata.GetContentEncoding(),
+		filename:        metadata.GetFilename(),
+	}
+}
+
+// normalizeContentType formats the media type with its parameters, invalid media types are dropped.
+func normalizeContentType(contentType string) string {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+
+	return mime.FormatMediaType(mediaType, params)
+}
+
+func normalizeContentEncoding(contentEncoding string) string {
+	contentEncoding = strings.ToLower(strings.TrimSpace(contentEncoding))
+	if contentEncoding == "identity" {
+		return ""
+	}
+
+	return contentEncoding
+}
+
+// resolveHeaders decides the content headers of the data the write results in. Patches and merges compute the data
+// from the stored data, so they keep the content type and filename of the module instead of taking the ones of the
+// request, and they cannot be applied to encoded data.
+func (w *moduleWrite) resolveHeaders(headers contentHeaders, mode writeMode) error {
+	if mode.patch == nil {
+		w.headers = headers
+
+		return nil
+	}
+
+	if headers.contentEncoding != "" {
+		return echo.NewHTTPError(http.StatusUnsupportedMediaType, ErrPatchOfEncodedContent.Error())
+	}
+
+	w.headers = contentHeaders{contentType: echo.MIMEApplicationJSON}
+
+	if w.current != nil {
+		w.headers.filename = w.current.GetFilename()
+
+		if contentType := w.current.GetContentType(); contentType != "" {
+			w.headers.contentType = contentType
+		}
+	}
+
+	if mode.moduleType.IsCRDT() {
+		w.headers.contentType = mimeTypeOf(mode.moduleType)
+	}
+
+	return nil
+}
+
+// applyTo records the content headers in the metadata of the written module.
+func (h contentHeaders) applyTo(metadata *service.BaseMetadata) {
+	metadata.ContentType = h.contentType
+	metadata.ContentEncoding = h.contentEncoding
+	metadata.Filename = h.filename
+}
+
+// contentTypeOf returns the content type the module is served with. CRDT modules are served with the content type
+// of their type, modules written without a content type as application/octet-stream.
+func contentTypeOf(metadata service.Metadata) string {
+	switch {
+	case metadata == nil:
+		return echo.MIMEOctetStream
+	case metadata.GetType().IsCRDT():
+		return mimeTypeOf(metadata.GetType())
+	case metadata.GetContentType() != "":
+		return metadata.GetContentType()
+	default:
+		return echo.MIMEOctetStream
+	}
+}
+
+// compressible reports whether compressing data of the content type is worth it,
+// data of unknown content type is assumed to be compressible.
+func compressible(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	kind, subtype, _ := strings.Cut(mediaType, "/")
+
+	switch kind {
+	case "image":
+		return subtype == "svg+xml" || subtype == "bmp"
+	case "audio", "video":
+		return false
+	default:
+		return !incompressibleTypes[mediaType]
+	}
+}
+
+// setContentDisposition names the file the module was written from so that browsers display it under its name.
+func setContentDisposition(ctx echo.Context, metadata service.Metadata) {
+	if metadata == nil || metadata.GetFilename() == "" {
+		return
+	}
+
+	ctx.Response().Header().Set(echo.HeaderContentDisposition,
+		mime.FormatMediaType("inline", map[string]string{"filename": metadata.GetFilename()}))
+}
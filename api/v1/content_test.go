@@ -0,0 +1,75 @@
+package v1_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/jakobmoellerdev/octi-sync-server/api/v1"
+	"github.com/jakobmoellerdev/octi-sync-server/api/v1/REST"
+)
+
+func TestAPI_ModuleContentHeaders(t *testing.T) {
+	t.Parallel()
+	_, assertions, router := SetupAPITest(t)
+	api := API()
+
+	client := newTestDevice(t, api, router, "content")
+
+	read := func(name string) *httptest.ResponseRecorder {
+		rec, err := client.read(name, echo.HeaderAcceptEncoding, "gzip")
+		assertions.NoError(err)
+
+		return rec
+	}
+
+	// modules written without content type are served as they always were
+	assertions.NoError(client.write("plain", "some data"))
+	rec := read("plain")
+	assertions.Equal(echo.MIMEOctetStream, rec.Header().Get(echo.HeaderContentType))
+	assertions.Empty(rec.Header().Get(echo.HeaderContentDisposition))
+
+	// compressed content types are not compressed again, but keep their filename
+	assertions.NoError(client.write("avatar", "not really a png",
+		echo.HeaderContentType, "image/png",
+		echo.HeaderContentDisposition, `attachment; filename="me.png"`,
+	))
+	rec = read("avatar")
+	assertions.Equal("image/png", rec.Header().Get(echo.HeaderContentType))
+	assertions.Equal("inline; filename=me.png", rec.Header().Get(echo.HeaderContentDisposition))
+	assertions.Empty(rec.Header().Get(echo.HeaderContentEncoding))
+	assertions.Equal("not really a png", rec.Body.String())
+
+	assertions.NoError(client.write("notes", "text", echo.HeaderContentType, "text/plain; charset=UTF-8"))
+	rec = read("notes")
+	assertions.Equal("text/plain; charset=UTF-8", rec.Header().Get(echo.HeaderContentType))
+	assertions.Equal("gzip", rec.Header().Get(echo.HeaderContentEncoding))
+
+	// content encoded by the client is stored and served as is
+	assertions.NoError(client.write("bundle", "brotli data",
+		echo.HeaderContentType, echo.MIMEApplicationJSON,
+		echo.HeaderContentEncoding, "br",
+	))
+	rec = read("bundle")
+	assertions.Equal("br", rec.Header().Get(echo.HeaderContentEncoding))
+	assertions.Equal("brotli data", rec.Body.String())
+
+	// rewriting unchanged data updates its content headers
+	assertions.NoError(client.write("notes", "text", echo.HeaderContentType, "text/markdown"))
+	assertions.Equal("text/markdown", read("notes").Header().Get(echo.HeaderContentType))
+
+	// patches keep the content type of the module or become json, and cannot be content encoded
+	assertions.NoError(client.write("settings", `{"a":1}`, echo.HeaderContentType, v1.MIMEApplicationMergePatchJSON))
+	assertions.Equal(echo.MIMEApplicationJSON, read("settings").Header().Get(echo.HeaderContentType))
+
+	assertHTTPError(assertions, client.write("settings", `{"a":2}`,
+		echo.HeaderContentType, v1.MIMEApplicationMergePatchJSON,
+		echo.HeaderContentEncoding, "br",
+	), http.StatusUnsupportedMediaType)
+
+	ctx, rec := client.request(http.MethodGet, "")
+	assertions.NoError(api.ListModules(ctx, REST.ListModulesParams{XDeviceID: client.deviceID}))
+	assertions.Contains(rec.Body.String(), `"contentType":"image/png"`)
+}
@@ -0,0 +1,67 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+// MIMEApplicationLWWMapJSON is the content type of the state of last-writer-wins element map modules.
+const MIMEApplicationLWWMapJSON = "application/vnd.octi.lww-map+json"
+
+var (
+	ErrModuleTypeMismatch = errors.New("module already exists with another type")
+	ErrPatchOfCRDTModule  = errors.New("crdt modules can only be written with their state to merge it")
+)
+
+// mimeTypeOf returns the content type of the data of modules of the type.
+func mimeTypeOf(moduleType service.ModuleType) string {
+	switch moduleType {
+	case service.ModuleTypeLWWMap:
+		return MIMEApplicationLWWMapJSON
+	default:
+		return echo.MIMEOctetStream
+	}
+}
+
+func crdtWriteMode(moduleType service.ModuleType) writeMode {
+	return writeMode{patch: mergeCRDT(moduleType), moduleType: moduleType}
+}
+
+// mergeCRDT merges the written state into the current state of the module.
+func mergeCRDT(moduleType service.ModuleType) modulePatch {
+	return func(current, incoming []byte) ([]byte, error) {
+		merged, err := moduleType.Merge(current, incoming)
+		if errors.Is(err, service.ErrInvalidCRDTState) {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("could not merge %s module: %w", moduleType, err)
+		}
+
+		return merged, nil
+	}
+}
+
+// resolveMode merges every write of a CRDT module, no matter how it is written,
+// and rejects writes that would change the type of an existing module.
+func (w *moduleWrite) resolveMode(mode writeMode) (writeMode, error) {
+	switch {
+	case mode.moduleType == w.moduleType, w.current == nil:
+	case !mode.moduleType.IsCRDT() && mode.patch == nil:
+		mode = crdtWriteMode(w.moduleType)
+	case !mode.moduleType.IsCRDT():
+		return mode, echo.NewHTTPError(http.StatusConflict, ErrPatchOfCRDTModule.Error())
+	default:
+		return mode, echo.NewHTTPError(http.StatusConflict, ErrModuleTypeMismatch.Error())
+	}
+
+	w.moduleType = mode.moduleType
+
+	return mode, nil
+}
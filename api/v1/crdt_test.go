@@ -0,0 +1,77 @@
+package v1_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	json "github.com/json-iterator/go"
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/jakobmoellerdev/octi-sync-server/api/v1"
+	"github.com/jakobmoellerdev/octi-sync-server/api/v1/REST"
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+func TestAPI_CRDTModules(t *testing.T) {
+	t.Parallel()
+	_, assertions, router := SetupAPITest(t)
+	api := API()
+
+	client := newTestDevice(t, api, router, "crdt")
+
+	write := func(name, contentType, body string) error {
+		return client.write(name, body, echo.HeaderContentType, contentType)
+	}
+	read := func(name string) (service.LWWMap, *httptest.ResponseRecorder) {
+		rec, err := client.read(name)
+		assertions.NoError(err)
+
+		state := service.LWWMap{}
+		assertions.NoError(json.Unmarshal(rec.Body.Bytes(), &state))
+
+		return state, rec
+	}
+
+	assertions.NoError(write("settings", v1.MIMEApplicationLWWMapJSON,
+		`{"theme": {"value": "dark", "timestamp": 20, "device": "laptop"}}`))
+
+	// a laptop that was offline syncs an older change of the theme and a newer change of another key
+	assertions.NoError(write("settings", v1.MIMEApplicationLWWMapJSON, `{
+		"theme": {"value": "light", "timestamp": 10, "device": "other-laptop"},
+		"lang": {"value": "en", "timestamp": 30, "device": "other-laptop"}
+	}`))
+
+	// clients unaware of the module type are merged as well
+	assertions.NoError(write("settings", echo.MIMEOctetStream, `{"size": {"value": 12, "timestamp": 5}}`))
+
+	state, rec := read("settings")
+	assertions.Equal(v1.MIMEApplicationLWWMapJSON, rec.Header().Get(echo.HeaderContentType))
+	assertions.Len(state, 3)
+	assertions.JSONEq(`"dark"`, string(state["theme"].Value))
+	assertions.JSONEq(`"en"`, string(state["lang"].Value))
+
+	assertHTTPError(assertions, write("settings", v1.MIMEApplicationLWWMapJSON, `{"theme": "dark"}`),
+		http.StatusBadRequest)
+	assertHTTPError(assertions, write("settings", v1.MIMEApplicationMergePatchJSON, `{"theme": null}`),
+		http.StatusConflict)
+
+	assertions.NoError(write("raw", echo.MIMEOctetStream, "opaque"))
+	assertHTTPError(assertions, write("raw", v1.MIMEApplicationLWWMapJSON, `{}`), http.StatusConflict)
+
+	ctx, rec := client.request(http.MethodGet, "")
+	assertions.NoError(api.ListModules(ctx, REST.ListModulesParams{XDeviceID: client.deviceID}))
+
+	var list REST.ModuleList
+
+	assertions.NoError(json.Unmarshal(rec.Body.Bytes(), &list))
+
+	if assertions.Len(list.Items, 2) {
+		assertions.Equal("raw", list.Items[0].Name)
+		assertions.Nil(list.Items[0].Type)
+
+		if assertions.NotNil(list.Items[1].Type) {
+			assertions.Equal(REST.LwwMap, *list.Items[1].Type)
+		}
+	}
+}
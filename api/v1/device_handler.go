@@ -4,18 +4,23 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
 
 	"github.com/jakobmoellerdev/octi-sync-server/api/v1/REST"
 	"github.com/jakobmoellerdev/octi-sync-server/middleware/basic"
 	"github.com/jakobmoellerdev/octi-sync-server/service"
 )
 
-var ErrNoDeviceAccessWithoutAccount = echo.NewHTTPError(http.StatusForbidden,
-	errors.New("devices cannot be accessed without an account"))
+var (
+	ErrNoDeviceAccessWithoutAccount = echo.NewHTTPError(http.StatusForbidden,
+		errors.New("devices cannot be accessed without an account"))
+	ErrDeviceNotPending = errors.New("device is not pending approval")
+)
 
-func (api *API) GetDevices(ctx echo.Context, _ REST.GetDevicesParams) error {
+func (api *API) GetDevices(ctx echo.Context, params REST.GetDevicesParams) error {
 	account, found := ctx.Get(basic.AccountKey).(service.Account)
 	if !found {
 		return ErrNoDeviceAccessWithoutAccount
@@ -30,17 +35,30 @@ func (api *API) GetDevices(ctx echo.Context, _ REST.GetDevicesParams) error {
 			fmt.Errorf("could not fetch devices from account: %w", err))
 	}
 
-	devices := make([]REST.Device, len(devicesFromAccount))
+	// presence is informational, the devices are still listed without it
+	online, err := api.Presence.Online(ctx.Request().Context(), account)
+	if err != nil {
+		zerolog.Ctx(ctx.Request().Context()).Warn().Err(err).Msg("could not determine online devices")
+	}
 
-	i := 0
+	devices := make([]REST.Device, 0, len(devicesFromAccount))
 
 	for _, device := range devicesFromAccount {
-		devices[i] = REST.Device{Id: REST.DeviceID(device.ID())}
-		i++
+		if params.Status != nil && string(*params.Status) != string(device.Status()) {
+			continue
+		}
+
+		restDevice := toRESTDevice(device)
+		if online != nil {
+			isOnline := online[device.ID()]
+			restDevice.Online = &isOnline
+		}
+
+		devices = append(devices, restDevice)
 	}
 
 	if err := ctx.JSON(http.StatusOK, &REST.DeviceListResponse{
-		Count: len(devicesFromAccount),
+		Count: len(devices),
 		Items: devices,
 	}); err != nil {
 		return fmt.Errorf("could not write device list response: %w", err)
@@ -48,3 +66,136 @@ func (api *API) GetDevices(ctx echo.Context, _ REST.GetDevicesParams) error {
 
 	return nil
 }
+
+func (api *API) UpdateDevice(ctx echo.Context, id REST.DeviceIDPath, _ REST.UpdateDeviceParams) error {
+	account, found := ctx.Get(basic.AccountKey).(service.Account)
+	if !found {
+		return ErrNoDeviceAccessWithoutAccount
+	}
+
+	var update REST.DeviceUpdate
+	if err := ctx.Bind(&update); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid device update").SetInternal(err)
+	}
+
+	device, err := api.deviceFromAccount(ctx, account, id)
+	if err != nil {
+		return err
+	}
+
+	if update.Name != nil {
+		if device, err = api.Devices.RenameDevice(
+			ctx.Request().Context(), account, device.ID(), *update.Name,
+		); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError,
+				fmt.Errorf("could not rename device: %w", err))
+		}
+	}
+
+	if err := ctx.JSON(http.StatusOK, toRESTDevice(device)); err != nil {
+		return fmt.Errorf("could not write device response: %w", err)
+	}
+
+	return nil
+}
+
+func (api *API) ApproveDevice(ctx echo.Context, id REST.DeviceIDPath, _ REST.ApproveDeviceParams) error {
+	account, found := ctx.Get(basic.AccountKey).(service.Account)
+	if !found {
+		return ErrNoDeviceAccessWithoutAccount
+	}
+
+	device, err := api.deviceFromAccount(ctx, account, id)
+	if err != nil {
+		return err
+	}
+
+	if device.Status() == service.DeviceStatusPending {
+		if device, err = api.Devices.ApproveDevice(ctx.Request().Context(), account, device.ID()); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError,
+				fmt.Errorf("could not approve device: %w", err))
+		}
+	}
+
+	if err := ctx.JSON(http.StatusOK, toRESTDevice(device)); err != nil {
+		return fmt.Errorf("could not write device response: %w", err)
+	}
+
+	return nil
+}
+
+func (api *API) RejectDevice(ctx echo.Context, id REST.DeviceIDPath, _ REST.RejectDeviceParams) error {
+	account, found := ctx.Get(basic.AccountKey).(service.Account)
+	if !found {
+		return ErrNoDeviceAccessWithoutAccount
+	}
+
+	device, err := api.deviceFromAccount(ctx, account, id)
+	if err != nil {
+		return err
+	}
+
+	if device.Status() != service.DeviceStatusPending {
+		return echo.NewHTTPError(http.StatusConflict, ErrDeviceNotPending.Error()).SetInternal(ErrDeviceNotPending)
+	}
+
+	if err := api.Devices.DeleteDevice(ctx.Request().Context(), account, device.ID()); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError,
+			fmt.Errorf("could not reject device: %w", err))
+	}
+
+	api.publish(ctx, account, service.Event{Type: service.EventDeviceRemoved, Device: device.ID(), At: time.Now()})
+
+	if err := ctx.NoContent(http.StatusNoContent); err != nil {
+		return fmt.Errorf("could not acknowledge device rejection: %w", err)
+	}
+
+	return nil
+}
+
+func (api *API) deviceFromAccount(
+	ctx echo.Context, account service.Account, id REST.DeviceIDPath,
+) (service.Device, error) {
+	device, err := api.Devices.GetDevice(ctx.Request().Context(), account, service.DeviceID(id))
+	if errors.Is(err, service.ErrDeviceNotFound) {
+		return nil, echo.NewHTTPError(http.StatusNotFound).SetInternal(err)
+	}
+
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError,
+			fmt.Errorf("could not fetch device from account: %w", err))
+	}
+
+	return device, nil
+}
+
+func toRESTDevice(device service.Device) REST.Device {
+	return REST.Device{
+		Id:            REST.DeviceID(device.ID()),
+		Name:          optionalString(device.Name()),
+		Platform:      optionalString(device.Platform()),
+		ClientVersion: optionalString(device.ClientVersion()),
+		RegisteredAt:  optionalTime(device.RegisteredAt()),
+		LastSeenAt:    optionalTime(device.LastSeenAt()),
+		Status:        REST.DeviceStatus(device.Status()),
+		Ip:            optionalString(device.RemoteIP()),
+	}
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
+
+func optionalTime(value time.Time) *time.Time {
+	if value.IsZero() {
+		return nil
+	}
+
+	value = value.UTC()
+
+	return &value
+}
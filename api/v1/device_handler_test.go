@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/jakobmoellerdev/octi-sync-server/api/v1/REST"
 	"github.com/jakobmoellerdev/octi-sync-server/middleware/basic"
 	"github.com/jakobmoellerdev/octi-sync-server/service"
+	"github.com/jakobmoellerdev/octi-sync-server/service/memory"
 	"github.com/jakobmoellerdev/octi-sync-server/service/mock"
 )
 
@@ -30,7 +32,8 @@ func TestAPI_GetDevices(t *testing.T) {
 	devices := mock.NewMockDevices(ctrl)
 
 	api := &v1.API{
-		Devices: devices,
+		Devices:  devices,
+		Presence: memory.NewPresence(),
 	}
 
 	for _, testCase := range []struct {
@@ -90,6 +93,7 @@ func testGetDevicesReturns200(t *testing.T, api *v1.API) deviceTest {
 					deviceID: service.NewBaseDevice(deviceID, HashedPassword("test")),
 				}, nil,
 			)
+		assert.NoError(api.Presence.Connected(context.Background(), acc, deviceID, "connection", time.Now().Add(time.Minute)))
 
 		err := api.GetDevices(ctx, REST.GetDevicesParams{XDeviceID: REST.XDeviceID(deviceID)})
 		assert.NoError(err)
@@ -104,9 +108,83 @@ func testGetDevicesReturns200(t *testing.T, api *v1.API) deviceTest {
 			deviceListResponse.Items, deviceListResponse.Count,
 			"list count should equal item count",
 		)
+
+		if assert.Len(deviceListResponse.Items, 1) && assert.NotNil(deviceListResponse.Items[0].Online) {
+			assert.True(*deviceListResponse.Items[0].Online)
+		}
 	}
 }
 
+func TestAPI_UpdateDevice(t *testing.T) {
+	t.Parallel()
+
+	assert, ctrl := assertions.New(t), gomock.NewController(t)
+	router := echo.New()
+	devices := mock.NewMockDevices(ctrl)
+	api := &v1.API{Devices: devices}
+
+	acc := service.NewBaseAccount("test", time.Now())
+	deviceID := service.DeviceID(RandomUUID(t))
+	registeredAt := time.Now().Add(-time.Hour)
+
+	newRequest := func(body string) (echo.Context, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(http.MethodPatch, "/", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		ctx := router.NewContext(req, rec)
+		ctx.Set(basic.AccountKey, acc)
+
+		return ctx, rec
+	}
+
+	t.Run("renames device", func(t *testing.T) {
+		ctx, rec := newRequest(`{"name":"laptop"}`)
+
+		devices.EXPECT().GetDevice(ctx.Request().Context(), acc, deviceID).Times(1).
+			Return(service.NewBaseDevice(deviceID, HashedPassword("test")), nil)
+		devices.EXPECT().RenameDevice(ctx.Request().Context(), acc, deviceID, "laptop").Times(1).
+			Return(service.NewBaseDeviceWithInfo(
+				deviceID, HashedPassword("test"),
+				service.DeviceInfo{Name: "laptop", Platform: "linux"}, registeredAt, time.Time{},
+			), nil)
+
+		assert.NoError(api.UpdateDevice(ctx, REST.DeviceIDPath(deviceID), REST.UpdateDeviceParams{}))
+		assert.Equal(http.StatusOK, rec.Code)
+
+		var device REST.Device
+
+		assert.NoError(json.Unmarshal(rec.Body.Bytes(), &device))
+		assert.Equal("laptop", *device.Name)
+		assert.Equal("linux", *device.Platform)
+		assert.Nil(device.ClientVersion)
+		assert.Nil(device.LastSeenAt)
+		assert.True(registeredAt.Equal(*device.RegisteredAt))
+	})
+
+	t.Run("unknown device returns 404", func(t *testing.T) {
+		ctx, _ := newRequest(`{"name":"laptop"}`)
+
+		devices.EXPECT().GetDevice(ctx.Request().Context(), acc, deviceID).Times(1).
+			Return(nil, service.ErrDeviceNotFound)
+
+		err := api.UpdateDevice(ctx, REST.DeviceIDPath(deviceID), REST.UpdateDeviceParams{})
+		httpError, isHTTPError := err.(*echo.HTTPError)
+
+		assert.True(isHTTPError)
+		assert.Equal(http.StatusNotFound, httpError.Code)
+	})
+
+	t.Run("invalid body returns 400", func(t *testing.T) {
+		ctx, _ := newRequest(`{"name":`)
+
+		err := api.UpdateDevice(ctx, REST.DeviceIDPath(deviceID), REST.UpdateDeviceParams{})
+		httpError, isHTTPError := err.(*echo.HTTPError)
+
+		assert.True(isHTTPError)
+		assert.Equal(http.StatusBadRequest, httpError.Code)
+	})
+}
+
 func testGetDevicesReturns500(t *testing.T, api *v1.API) deviceTest {
 	assert := assertions.New(t)
 
@@ -129,3 +207,88 @@ func testGetDevicesReturns500(t *testing.T, api *v1.API) deviceTest {
 		assert.ErrorContains(messageErr, "could not fetch devices from account")
 	}
 }
+
+func TestAPI_ApproveAndRejectDevice(t *testing.T) {
+	t.Parallel()
+
+	assert, ctrl := assertions.New(t), gomock.NewController(t)
+	router := echo.New()
+	devices := mock.NewMockDevices(ctrl)
+	api := &v1.API{Devices: devices, Events: memory.NewEvents(), Presence: memory.NewPresence()}
+
+	acc := service.NewBaseAccount("test", time.Now())
+	deviceID := service.DeviceID(RandomUUID(t))
+	pending := service.NewBaseDeviceWithInfo(
+		deviceID, HashedPassword("test"),
+		service.DeviceInfo{Platform: "android", RemoteIP: "10.0.0.1", Status: service.DeviceStatusPending},
+		time.Now(), time.Time{},
+	)
+	approved := service.NewBaseDevice(deviceID, HashedPassword("test"))
+
+	newRequest := func() (echo.Context, *httptest.ResponseRecorder) {
+		rec := httptest.NewRecorder()
+		ctx := router.NewContext(emptyRequest(http.MethodPost), rec)
+		ctx.Set(basic.AccountKey, acc)
+
+		return ctx, rec
+	}
+
+	t.Run("pending devices are listed with platform and ip", func(t *testing.T) {
+		ctx, rec := newRequest()
+		status := REST.Pending
+
+		devices.EXPECT().GetDevices(ctx.Request().Context(), acc).Times(1).Return(
+			map[service.DeviceID]service.Device{deviceID: pending}, nil,
+		)
+
+		assert.NoError(api.GetDevices(ctx, REST.GetDevicesParams{Status: &status}))
+
+		var list REST.DeviceListResponse
+
+		assert.NoError(json.Unmarshal(rec.Body.Bytes(), &list))
+
+		if assert.Equal(1, list.Count) {
+			assert.Equal(REST.Pending, list.Items[0].Status)
+			assert.Equal("android", *list.Items[0].Platform)
+			assert.Equal("10.0.0.1", *list.Items[0].Ip)
+		}
+	})
+
+	t.Run("approve pending device", func(t *testing.T) {
+		ctx, rec := newRequest()
+
+		devices.EXPECT().GetDevice(ctx.Request().Context(), acc, deviceID).Times(1).Return(pending, nil)
+		devices.EXPECT().ApproveDevice(ctx.Request().Context(), acc, deviceID).Times(1).Return(approved, nil)
+
+		assert.NoError(api.ApproveDevice(ctx, REST.DeviceIDPath(deviceID), REST.ApproveDeviceParams{}))
+		assert.Equal(http.StatusOK, rec.Code)
+
+		var device REST.Device
+
+		assert.NoError(json.Unmarshal(rec.Body.Bytes(), &device))
+		assert.Equal(REST.Approved, device.Status)
+	})
+
+	t.Run("reject pending device", func(t *testing.T) {
+		ctx, rec := newRequest()
+
+		devices.EXPECT().GetDevice(ctx.Request().Context(), acc, deviceID).Times(1).Return(pending, nil)
+		devices.EXPECT().DeleteDevice(ctx.Request().Context(), acc, deviceID).Times(1).Return(nil)
+
+		assert.NoError(api.RejectDevice(ctx, REST.DeviceIDPath(deviceID), REST.RejectDeviceParams{}))
+		assert.Equal(http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("reject approved device conflicts", func(t *testing.T) {
+		ctx, _ := newRequest()
+
+		devices.EXPECT().GetDevice(ctx.Request().Context(), acc, deviceID).Times(1).Return(approved, nil)
+
+		err := api.RejectDevice(ctx, REST.DeviceIDPath(deviceID), REST.RejectDeviceParams{})
+		httpError, isHTTPError := err.(*echo.HTTPError)
+
+		assert.True(isHTTPError)
+		assert.Equal(http.StatusConflict, httpError.Code)
+		assert.ErrorIs(httpError, v1.ErrDeviceNotPending)
+	})
+}
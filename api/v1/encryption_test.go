@@ -0,0 +1,97 @@
+package v1_test
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/jakobmoellerdev/octi-sync-server/api/v1"
+	"github.com/jakobmoellerdev/octi-sync-server/api/v1/REST"
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+	"github.com/jakobmoellerdev/octi-sync-server/service/memory"
+)
+
+func TestAPI_ModuleEncryption(t *testing.T) {
+	t.Parallel()
+	_, assertions, router := SetupAPITest(t)
+
+	key := make([]byte, service.MasterKeySize)
+	_, err := rand.Read(key)
+	assertions.NoError(err)
+
+	keyring, err := service.ParseKeyring("master:" + base64.StdEncoding.EncodeToString(key))
+	assertions.NoError(err)
+
+	api := API()
+	api.Compression = service.EncodingZstd
+	api.Encryption = &service.Envelope{Keyring: keyring, DataKeys: memory.NewDataKeys()}
+
+	client := newTestDevice(t, api, router, "encryption")
+	data := strings.Repeat(`{"secret": "value"}`, 1000)
+
+	assertions.NoError(client.write("settings", data))
+
+	// the stored data cannot be read without the data key
+	id := client.moduleID("settings")
+
+	module, err := api.Modules.Get(context.Background(), id)
+	assertions.NoError(err)
+
+	stored, err := io.ReadAll(module.Raw())
+	assertions.NoError(err)
+	assertions.NotContains(string(stored), "secret")
+
+	metadata, err := api.MetadataProvider.Get(context.Background(), service.MetadataID(id))
+	assertions.NoError(err)
+	assertions.NotEmpty(metadata.GetKeyID())
+
+	// clients accepting the stored encoding receive the decrypted, but still compressed data
+	rec, err := client.read("settings", echo.HeaderAcceptEncoding, "zstd")
+	assertions.NoError(err)
+	assertions.Equal("zstd", rec.Header().Get(echo.HeaderContentEncoding))
+
+	decoder, err := service.EncodingZstd.Decode(rec.Body)
+	if assertions.NoError(err) {
+		decoded, err := io.ReadAll(decoder)
+		assertions.NoError(err)
+		assertions.Equal(data, string(decoded))
+	}
+
+	rec, err = client.read("settings")
+	assertions.NoError(err)
+	assertions.Equal(data, rec.Body.String())
+
+	etag := rec.Header().Get(v1.HeaderETag)
+
+	// the version recorded in the history is encrypted as well
+	ctx, rec := client.request(http.MethodGet, "")
+	assertions.NoError(api.GetModuleVersion(ctx, "settings", 1, REST.GetModuleVersionParams{XDeviceID: client.deviceID}))
+	assertions.Equal(data, rec.Body.String())
+
+	// writing the same data again stores the same ciphertext and keeps the version
+	assertions.NoError(client.write("settings", data))
+
+	module, err = api.Modules.Get(context.Background(), id)
+	assertions.NoError(err)
+
+	rewritten, err := io.ReadAll(module.Raw())
+	assertions.NoError(err)
+	assertions.Equal(stored, rewritten)
+
+	rec, err = client.read("settings")
+	assertions.NoError(err)
+	assertions.Equal(data, rec.Body.String())
+	assertions.Equal(etag, rec.Header().Get(v1.HeaderETag))
+
+	// without master keys, encrypted modules cannot be read
+	api.Encryption = nil
+
+	_, err = client.read("settings")
+	assertions.ErrorIs(err, v1.ErrEncryptionNotConfigured)
+}
@@ -0,0 +1,117 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	json "github.com/json-iterator/go"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+
+	"github.com/jakobmoellerdev/octi-sync-server/api/v1/REST"
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+const (
+	EventsPath          = "/events"
+	MIMETextEventStream = "text/event-stream"
+
+	// EventHeartbeatInterval keeps idle event streams from being closed by proxies.
+	EventHeartbeatInterval = 15 * time.Second
+)
+
+func (api *API) GetEvents(ctx echo.Context, params REST.GetEventsParams) error {
+	acc, _, err := api.resolveDeviceIDAndAccount(ctx, &params.XDeviceID)
+	if err != nil {
+		return err
+	}
+
+	requestCtx := ctx.Request().Context()
+
+	events, err := api.Events.Subscribe(requestCtx, acc)
+	if err != nil {
+		return fmt.Errorf("could not subscribe to account events: %w", err)
+	}
+
+	controller := http.NewResponseController(ctx.Response())
+
+	// the stream lives longer than the write timeout of the server
+	if err := controller.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return fmt.Errorf("could not clear write deadline of event stream: %w", err)
+	}
+
+	header := ctx.Response().Header()
+	header.Set(echo.HeaderContentType, MIMETextEventStream)
+	header.Set(echo.HeaderCacheControl, "no-cache")
+	header.Set("X-Accel-Buffering", "no")
+	ctx.Response().WriteHeader(http.StatusOK)
+
+	heartbeat := time.NewTicker(EventHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	// the comment tells clients that events published from now on are delivered
+	message := []byte(": subscribed\n\n")
+
+	for {
+		if _, err := ctx.Response().Write(message); err != nil {
+			return fmt.Errorf("could not write to event stream: %w", err)
+		}
+
+		if err := controller.Flush(); err != nil {
+			return fmt.Errorf("could not flush event stream: %w", err)
+		}
+
+		select {
+		case <-requestCtx.Done():
+			return nil
+		case <-heartbeat.C:
+			message = []byte(": heartbeat\n\n")
+		case event, open := <-events:
+			if !open {
+				return nil
+			}
+
+			if message, err = eventMessage(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// eventMessage formats the event as message of an event stream.
+func eventMessage(event service.Event) ([]byte, error) {
+	data, err := json.Marshal(toRESTEvent(event))
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal %s event: %w", event.Type, err)
+	}
+
+	message := fmt.Sprintf("event: %s\n", event.Type)
+	if event.Cursor != "" {
+		message += fmt.Sprintf("id: %s\n", event.Cursor)
+	}
+
+	return []byte(fmt.Sprintf("%sdata: %s\n\n", message, data)), nil
+}
+
+func toRESTEvent(event service.Event) REST.Event {
+	return REST.Event{
+		Type:      REST.EventType(event.Type),
+		Device:    event.Device.UUID(),
+		Module:    optionalString(event.Module),
+		Operation: (*REST.ChangeOperation)(optionalString(string(event.Operation))),
+		Cursor:    optionalString(event.Cursor),
+		At:        event.At.UTC(),
+	}
+}
+
+// publish notifies the subscribers of the account about the event. Events are best effort,
+// clients catch up through the change feed, so failures are only logged.
+func (api *API) publish(ctx echo.Context, acc service.Account, event service.Event) {
+	requestCtx := ctx.Request().Context()
+
+	if err := api.Events.Publish(requestCtx, acc, event); err != nil {
+		zerolog.Ctx(requestCtx).Warn().Err(err).Str("event", string(event.Type)).Msg("could not publish event")
+	}
+}
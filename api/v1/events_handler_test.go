@@ -0,0 +1,85 @@
+package v1_test
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/jakobmoellerdev/octi-sync-server/api/v1"
+	"github.com/jakobmoellerdev/octi-sync-server/api/v1/REST"
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+func TestAPI_GetEvents(t *testing.T) {
+	t.Parallel()
+	_, assertions, router := SetupAPITest(t)
+	api := API()
+	client := newTestDevice(t, api, router, "events")
+	deviceID, account := client.deviceID, client.account
+
+	router.GET(v1.Prefix+v1.EventsPath, func(ctx echo.Context) error {
+		client.authenticate(ctx)
+
+		return api.GetEvents(ctx, REST.GetEventsParams{XDeviceID: deviceID})
+	})
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+v1.Prefix+v1.EventsPath, nil)
+	assertions.NoError(err)
+
+	res, err := server.Client().Do(req)
+	if !assertions.NoError(err) {
+		return
+	}
+	defer res.Body.Close()
+
+	assertions.Equal(http.StatusOK, res.StatusCode)
+	assertions.Equal(v1.MIMETextEventStream, res.Header.Get(echo.HeaderContentType))
+
+	stream := bufio.NewReader(res.Body)
+	readMessage := func() string {
+		var message strings.Builder
+
+		for {
+			line, err := stream.ReadString('\n')
+			if !assertions.NoError(err) || line == "\n" {
+				return message.String()
+			}
+
+			message.WriteString(line)
+		}
+	}
+
+	// events are only delivered once the subscription was confirmed
+	assertions.Equal(": subscribed\n", readMessage())
+
+	assertions.NoError(client.write("settings", "data"))
+
+	message := readMessage()
+	assertions.Contains(message, "event: module-changed\n")
+	assertions.Contains(message, "id: 1\n")
+	assertions.Contains(message, `"module":"settings"`)
+	assertions.Contains(message, `"operation":"created"`)
+	assertions.Contains(message, `"device":"`+deviceID.String()+`"`)
+
+	other := service.NewBaseAccount("other", time.Now())
+	assertions.NoError(api.Events.Publish(ctx, other, service.Event{Type: service.EventDeviceAdded}))
+	assertions.NoError(api.Events.Publish(ctx, account, service.Event{
+		Type: service.EventDeviceRemoved, Device: service.DeviceID(deviceID), At: time.Now(),
+	}))
+
+	message = readMessage()
+	assertions.Contains(message, "event: device-removed\n")
+	assertions.NotContains(message, "id:")
+}
@@ -0,0 +1,41 @@
+package v1
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+const XExpiresAt = "X-Expires-At"
+
+var ErrInvalidExpiry = errors.New("modules can only expire after a positive amount of seconds")
+
+// expiresAt returns when the module written now expires according to the expiry policy,
+// expiresIn is the amount of seconds requested by the client or nil.
+func (api *API) expiresAt(name string, expiresIn *int) (time.Time, error) {
+	var requested time.Duration
+
+	if expiresIn != nil {
+		if *expiresIn <= 0 {
+			return time.Time{}, echo.NewHTTPError(http.StatusBadRequest, ErrInvalidExpiry.Error()).
+				SetInternal(ErrInvalidExpiry)
+		}
+
+		// expiries longer than a duration can hold are cut to the longest one
+		requested = time.Duration(min(int64(*expiresIn), math.MaxInt64/int64(time.Second))) * time.Second
+	}
+
+	return api.Expiry.ExpiresAt(name, requested, time.Now()), nil
+}
+
+// setExpiresAt tells the client when the module expires, if it expires at all.
+func setExpiresAt(ctx echo.Context, metadata service.Metadata) {
+	if expiresAt := metadata.GetExpiresAt(); !expiresAt.IsZero() {
+		ctx.Response().Header().Set(XExpiresAt, expiresAt.UTC().Format(http.TimeFormat))
+	}
+}
@@ -0,0 +1,104 @@
+package v1_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	json "github.com/json-iterator/go"
+
+	v1 "github.com/jakobmoellerdev/octi-sync-server/api/v1"
+	"github.com/jakobmoellerdev/octi-sync-server/api/v1/REST"
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+func TestAPI_ModuleExpiry(t *testing.T) {
+	t.Parallel()
+	_, assertions, router := SetupAPITest(t)
+	api := API()
+	api.Expiry = service.ExpiryPolicy{
+		Max:      24 * time.Hour,
+		Patterns: map[string]time.Duration{"clipboard-*": time.Hour},
+	}
+
+	client := newTestDevice(t, api, router, "expiry")
+	deviceID := client.deviceID
+
+	write := func(name string, expiresIn *int) (*httptest.ResponseRecorder, error) {
+		ctx, rec := client.request(http.MethodPost, "data of "+name)
+		err := api.CreateModule(ctx, name, REST.CreateModuleParams{XDeviceID: deviceID, XExpiresIn: expiresIn})
+
+		return rec, err
+	}
+	read := func(name string) *httptest.ResponseRecorder {
+		rec, err := client.read(name)
+		assertions.NoError(err)
+
+		return rec
+	}
+	expiresAt := func(rec *httptest.ResponseRecorder) time.Time {
+		expiresAt, err := http.ParseTime(rec.Header().Get(v1.XExpiresAt))
+		assertions.NoError(err)
+
+		return expiresAt
+	}
+	seconds := func(seconds int) *int { return &seconds }
+
+	// modules expire according to the patterns of the operator unless the client requests otherwise
+	rec, err := write("settings", nil)
+	assertions.NoError(err)
+	assertions.Empty(rec.Header().Get(v1.XExpiresAt))
+	assertions.Empty(read("settings").Header().Get(v1.XExpiresAt))
+
+	rec, err = write("clipboard-1", nil)
+	assertions.NoError(err)
+	assertions.WithinDuration(time.Now().Add(time.Hour), expiresAt(rec), 2*time.Second)
+	assertions.Equal(expiresAt(rec), expiresAt(read("clipboard-1")))
+
+	// requested expiries are capped by the policy
+	rec, err = write("settings", seconds(7*24*60*60))
+	assertions.NoError(err)
+	assertions.WithinDuration(time.Now().Add(24*time.Hour), expiresAt(rec), 2*time.Second)
+
+	_, err = write("settings", seconds(0))
+	assertHTTPError(assertions, err, http.StatusBadRequest)
+
+	// expired modules are gone together with their metadata, history and usage
+	rec, err = write("short", seconds(1))
+	assertions.NoError(err)
+
+	ctx, listRec := client.request(http.MethodGet, "")
+	assertions.NoError(api.ListModules(ctx, REST.ListModulesParams{XDeviceID: deviceID}))
+
+	var list REST.ModuleList
+
+	assertions.NoError(json.Unmarshal(listRec.Body.Bytes(), &list))
+
+	if assertions.Len(list.Items, 3) && assertions.NotNil(list.Items[2].ExpiresAt) {
+		assertions.Equal("short", list.Items[2].Name)
+		assertions.True(expiresAt(rec).Equal(*list.Items[2].ExpiresAt))
+	}
+
+	time.Sleep(time.Until(expiresAt(rec)))
+
+	expired := read("short")
+	assertions.Equal(http.StatusNoContent, expired.Code)
+	assertions.Empty(expired.Header().Get(v1.HeaderETag))
+
+	ctx, rec = client.request(http.MethodGet, "")
+	assertions.NoError(api.GetModuleVersions(ctx, "short", REST.GetModuleVersionsParams{XDeviceID: deviceID}))
+
+	var versions REST.ModuleVersionList
+
+	assertions.NoError(json.Unmarshal(rec.Body.Bytes(), &versions))
+	assertions.Empty(versions.Items)
+
+	ctx, rec = client.request(http.MethodGet, "")
+	assertions.NoError(api.GetAccountUsage(ctx, REST.GetAccountUsageParams{XDeviceID: deviceID}))
+
+	var usage REST.AccountUsage
+
+	assertions.NoError(json.Unmarshal(rec.Body.Bytes(), &usage))
+	assertions.Equal(int64(2), usage.Modules)
+}
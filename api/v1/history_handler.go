@@ -0,0 +1,117 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/jakobmoellerdev/octi-sync-server/api/v1/REST"
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+func (api *API) GetModuleVersions(
+	ctx echo.Context, name REST.ModuleName, params REST.GetModuleVersionsParams,
+) error {
+	acc, device, err := api.resolveDeviceIDAndAccount(ctx, params.DeviceId, &params.XDeviceID)
+	if err != nil {
+		return err
+	}
+
+	id := fmt.Sprintf("%s-%s-%s", acc.Username(), device.ID(), name)
+
+	versions, err := api.History.Versions(ctx.Request().Context(), service.MetadataID(id))
+	if err != nil {
+		return fmt.Errorf("error while fetching module versions: %w", err)
+	}
+
+	items := make([]REST.ModuleVersion, len(versions))
+
+	for i, version := range versions {
+		items[i] = REST.ModuleVersion{
+			Version:    version.GetVersion(),
+			ModifiedAt: REST.ModifiedAtTimestamp(time.Time(version.GetModifiedAt()).UTC()),
+			Size:       version.GetSize(),
+			Etag:       optionalString(entityTag(version)),
+		}
+	}
+
+	if err := ctx.JSON(http.StatusOK, &REST.ModuleVersionList{
+		Count: len(items),
+		Items: items,
+	}); err != nil {
+		return fmt.Errorf("could not write module version list response: %w", err)
+	}
+
+	return nil
+}
+
+func (api *API) GetModuleVersion(
+	ctx echo.Context, name REST.ModuleName, version REST.ModuleVersionPath, params REST.GetModuleVersionParams,
+) error {
+	acc, device, err := api.resolveDeviceIDAndAccount(ctx, params.DeviceId, &params.XDeviceID)
+	if err != nil {
+		return err
+	}
+
+	id := fmt.Sprintf("%s-%s-%s", acc.Username(), device.ID(), name)
+
+	module, metadata, err := api.moduleVersion(ctx, id, version)
+	if err != nil {
+		return err
+	}
+
+	setValidators(ctx, metadata)
+
+	return api.streamModule(ctx, acc, http.StatusOK, module, metadata)
+}
+
+func (api *API) RestoreModuleVersion(
+	ctx echo.Context, name REST.ModuleName, version REST.ModuleVersionPath, params REST.RestoreModuleVersionParams,
+) error {
+	acc, device, err := api.resolveDeviceIDAndAccount(ctx, &params.XDeviceID)
+	if err != nil {
+		return err
+	}
+
+	id := fmt.Sprintf("%s-%s-%s", acc.Username(), device.ID(), name)
+
+	module, metadata, err := api.moduleVersion(ctx, id, version)
+	if err != nil {
+		return err
+	}
+
+	if module, err = api.decodeModule(ctx.Request().Context(), acc, module, metadata); err != nil {
+		return err
+	}
+
+	if _, err := api.writeModule(
+		ctx, acc, device, name, module.Raw(), module.Size(), writeMode{}, contentHeadersOfMetadata(metadata),
+		params.XExpiresIn, params.IfMatch, params.IfUnmodifiedSince,
+	); err != nil {
+		return err
+	}
+
+	if err := ctx.JSON(http.StatusAccepted, nil); err != nil {
+		return fmt.Errorf("could not acknowledge module restore: %w", err)
+	}
+
+	return nil
+}
+
+func (api *API) moduleVersion(
+	ctx echo.Context, id string, version REST.ModuleVersionPath,
+) (service.Module, service.Metadata, error) {
+	module, metadata, err := api.History.Get(ctx.Request().Context(), service.MetadataID(id), version)
+	if errors.Is(err, service.ErrVersionNotFound) {
+		return nil, nil, echo.NewHTTPError(http.StatusNotFound, service.ErrVersionNotFound.Error()).SetInternal(err)
+	}
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("error while fetching module version: %w", err)
+	}
+
+	return module, metadata, nil
+}
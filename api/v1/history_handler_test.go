@@ -0,0 +1,83 @@
+package v1_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	json "github.com/json-iterator/go"
+
+	"github.com/jakobmoellerdev/octi-sync-server/api/v1/REST"
+)
+
+func TestAPI_ModuleHistory(t *testing.T) {
+	t.Parallel()
+	_, assertions, router := SetupAPITest(t)
+	api := API()
+	client := newTestDevice(t, api, router, "history")
+	deviceID := client.deviceID
+
+	for _, data := range []string{"first", "second", "corrupted"} {
+		assertions.NoError(client.write("history", data))
+	}
+
+	ctx, rec := client.request(http.MethodGet, "")
+	if assertions.NoError(api.GetModuleVersions(ctx, "history", REST.GetModuleVersionsParams{XDeviceID: deviceID})) {
+		var versions REST.ModuleVersionList
+		assertions.NoError(json.Unmarshal(rec.Body.Bytes(), &versions))
+		assertions.Equal(3, versions.Count)
+		assertions.Equal(int64(3), versions.Items[0].Version)
+		assertions.Equal(int64(len("corrupted")), versions.Items[0].Size)
+		assertions.Equal(int64(1), versions.Items[2].Version)
+		assertions.NotNil(versions.Items[2].Etag)
+	}
+
+	ctx, rec = client.request(http.MethodGet, "")
+	if assertions.NoError(api.GetModuleVersion(ctx, "history", 2, REST.GetModuleVersionParams{XDeviceID: deviceID})) {
+		assertions.Equal("second", rec.Body.String())
+	}
+
+	ctx, _ = client.request(http.MethodGet, "")
+	assertHTTPError(assertions,
+		api.GetModuleVersion(ctx, "history", 42, REST.GetModuleVersionParams{XDeviceID: deviceID}),
+		http.StatusNotFound,
+	)
+
+	ctx, rec = client.request(http.MethodPost, "")
+	if assertions.NoError(api.RestoreModuleVersion(
+		ctx, "history", 2, REST.RestoreModuleVersionParams{XDeviceID: deviceID},
+	)) {
+		assertions.Equal(http.StatusAccepted, rec.Code)
+	}
+
+	rec, err := client.read("history")
+	if assertions.NoError(err) {
+		assertions.Equal("second", rec.Body.String())
+	}
+
+	ctx, rec = client.request(http.MethodGet, "")
+	if assertions.NoError(api.GetModuleVersions(ctx, "history", REST.GetModuleVersionsParams{XDeviceID: deviceID})) {
+		var versions REST.ModuleVersionList
+		assertions.NoError(json.Unmarshal(rec.Body.Bytes(), &versions))
+		assertions.Equal(4, versions.Count)
+		assertions.Equal(int64(4), versions.Items[0].Version)
+		assertions.Equal(versions.Items[2].Etag, versions.Items[0].Etag)
+	}
+
+	// writing unchanged data neither creates a version nor a change
+	ctx, rec = client.request(http.MethodPost, "second")
+	if assertions.NoError(api.CreateModule(ctx, "history", REST.CreateModuleParams{XDeviceID: deviceID})) {
+		assertions.Equal(http.StatusAccepted, rec.Code)
+	}
+
+	ctx, rec = client.request(http.MethodGet, "")
+	if assertions.NoError(api.GetModuleVersions(ctx, "history", REST.GetModuleVersionsParams{XDeviceID: deviceID})) {
+		var versions REST.ModuleVersionList
+		assertions.NoError(json.Unmarshal(rec.Body.Bytes(), &versions))
+		assertions.Equal(4, versions.Count)
+	}
+
+	changes, err := api.ChangeFeed.Since(context.Background(), client.account, "", 0)
+	assertions.NoError(err)
+	assertions.Len(changes, 4)
+}
@@ -1,13 +1,20 @@
 package v1
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
 
 	"github.com/jakobmoellerdev/octi-sync-server/api/v1/REST"
 	"github.com/jakobmoellerdev/octi-sync-server/middleware/basic"
@@ -17,7 +24,11 @@ import (
 
 const XModifiedAt = "X-Modified-At"
 
-var ErrAccountForVerifyingDeviceNotPresent = errors.New("account for verifying device id is not present")
+var (
+	ErrAccountForVerifyingDeviceNotPresent = errors.New("account for verifying device id is not present")
+	ErrModuleChanged                       = errors.New("module was changed by another write")
+	ErrInvalidModuleName                   = fmt.Errorf("module name does not match %s", moduleNamePattern)
+)
 
 func (api *API) CreateModule(ctx echo.Context, name REST.ModuleName, params REST.CreateModuleParams) error {
 	acc, device, err := api.resolveDeviceIDAndAccount(ctx, &params.XDeviceID)
@@ -25,28 +36,347 @@ func (api *API) CreateModule(ctx echo.Context, name REST.ModuleName, params REST
 		return err
 	}
 
-	id := fmt.Sprintf("%s-%s-%s", acc.Username(), device.ID(), name)
+	if _, err := api.writeModule(
+		ctx, acc, device, name, ctx.Request().Body, int(ctx.Request().ContentLength),
+		writeModeOf(ctx), contentHeadersOf(ctx.Request().Header), params.XExpiresIn,
+		params.IfMatch, params.IfUnmodifiedSince,
+	); err != nil {
+		return err
+	}
 
-	modifiedAt := time.Now()
+	if err := ctx.JSON(http.StatusAccepted, nil); err != nil {
+		return fmt.Errorf("could not acknowledge module creation: %w", err)
+	}
+
+	return nil
+}
 
-	err = api.Modules.Set(
-		ctx.Request().Context(),
-		id,
-		redis.ModuleFromReader(ctx.Request().Body, int(ctx.Request().ContentLength)),
-	)
+// writeModule stores the data as new version of the module if the preconditions of the client are met
+// and records the write in the change feed of the account. The metadata of the new version is returned.
+// With a patch the data is applied to the current data of the module instead, which is read while the module
+// is locked so that the read-modify-write is atomic for concurrent writers. Writes of CRDT modules are always merged.
+// Data of a known size is streamed into the backend unless it has to be patched, merged or validated first.
+// The module expires after the seconds of expiresIn if given, capped by the expiry policy that decides it otherwise.
+// The content headers are stored with the module, unless the data is patched or merged.
+func (api *API) writeModule(
+	ctx echo.Context, acc service.Account, device service.Device, name string, data io.Reader, size int,
+	mode writeMode, headers contentHeaders, expiresIn *int, ifMatch, ifUnmodifiedSince *string,
+) (service.Metadata, error) {
+	requestCtx := ctx.Request().Context()
+
+	if err := validateModuleName(name); err != nil {
+		return nil, err
+	}
+
+	expiresAt, err := api.expiresAt(name, expiresIn)
 	if err != nil {
-		return fmt.Errorf("could not create/update module: %w", err)
+		return nil, err
 	}
 
-	err = api.MetadataProvider.Set(
-		ctx.Request().Context(), service.NewBaseMetadata(id, modifiedAt),
-	)
+	write, err := api.lockModule(requestCtx, acc, device, name)
 	if err != nil {
-		return fmt.Errorf("could not create/update module metadata: %w", err)
+		return nil, err
 	}
+	defer write.release(requestCtx)
 
-	if err := ctx.JSON(http.StatusAccepted, nil); err != nil {
-		return fmt.Errorf("could not acknowledge module creation: %w", err)
+	write.expiresAt = expiresAt
+
+	if err := write.checkPreconditions(ifMatch, ifUnmodifiedSince); err != nil {
+		if write.current != nil {
+			setValidators(ctx, write.current)
+		}
+
+		return nil, err
+	}
+
+	mode, err = write.resolveMode(mode)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := write.resolveHeaders(headers, mode); err != nil {
+		return nil, err
+	}
+
+	module, err := api.moduleContent(requestCtx, write, data, size, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := api.accountUsage(requestCtx, acc)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := api.checkModuleQuota(usage, write.current, int64(module.Size())); err != nil {
+		return nil, err
+	}
+
+	content := service.NewDigestReader(module.Raw())
+
+	encoded, err := api.encodeModule(requestCtx, write, content, module.Size())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := api.Modules.Set(requestCtx, write.id, encoded, write.expiresAt); err != nil {
+		if content.Err() != nil {
+			return nil, readError(content.Err())
+		}
+
+		return nil, fmt.Errorf("could not create/update module: %w", err)
+	}
+
+	metadata, err := api.commitWrite(ctx, acc, device, write, content.Digest(), content.Size())
+	if err != nil {
+		return nil, err
+	}
+
+	setValidators(ctx, metadata)
+	setExpiresAt(ctx, metadata)
+
+	return metadata, nil
+}
+
+// moduleContent returns the content that is written to the module. It is only read into memory if it has to be
+// patched, merged or validated, or if its size is unknown, otherwise it is streamed from the client.
+func (api *API) moduleContent(
+	ctx context.Context, write *moduleWrite, data io.Reader, size int, mode writeMode,
+) (service.Module, error) {
+	_, validated, err := api.Schemas.Match(ctx, write.name)
+	if err != nil {
+		return nil, fmt.Errorf("could not match module schema: %w", err)
+	}
+
+	if size >= 0 && mode.patch == nil && !validated {
+		return redis.ModuleFromReader(data, size), nil
+	}
+
+	content := bytes.NewBuffer(make([]byte, 0, max(size, 0)))
+	if _, err := content.ReadFrom(data); err != nil {
+		return nil, readError(err)
+	}
+
+	if mode.patch != nil {
+		if content, err = api.patchModule(ctx, write, mode.patch, content.Bytes()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := api.validateModule(ctx, write.name, content.Bytes()); err != nil {
+		return nil, err
+	}
+
+	return redis.ModuleFromBytes(content.Bytes()), nil
+}
+
+// readError is returned when the module data cannot be read from the client,
+// errors of the body limit keep their own status.
+func readError(err error) error {
+	var httpError *echo.HTTPError
+	if errors.As(err, &httpError) {
+		return httpError
+	}
+
+	return echo.NewHTTPError(http.StatusBadRequest, "could not read module data").SetInternal(err)
+}
+
+// patchModule applies the patch to the current data of the locked module.
+func (api *API) patchModule(
+	ctx context.Context, write *moduleWrite, patch modulePatch, data []byte,
+) (*bytes.Buffer, error) {
+	module, err := api.Modules.Get(ctx, write.id)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching module to patch: %w", err)
+	}
+
+	if module, err = api.decodeModule(ctx, write.account, module, write.current); err != nil {
+		return nil, err
+	}
+
+	current, err := io.ReadAll(module.Raw())
+	if err != nil {
+		return nil, fmt.Errorf("error while reading module to patch: %w", err)
+	}
+
+	patched, err := patch(current, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.NewBuffer(patched), nil
+}
+
+// moduleWrite is a write of a module that holds the lock of the module until it is released.
+type moduleWrite struct {
+	id      string
+	name    string
+	account service.Account
+	current service.Metadata
+	// tombstone is the metadata of the module if it was deleted, current is nil then
+	tombstone  service.Metadata
+	moduleType service.ModuleType
+	// encoding is the codec the written data is compressed with
+	encoding service.ModuleEncoding
+	// keyID is the data key the written data is encrypted with
+	keyID string
+	// expiresAt is when the written module expires, the zero time if it never expires
+	expiresAt time.Time
+	// headers describe the written data
+	headers contentHeaders
+	unlock  service.Unlock
+}
+
+// moduleNamePattern is the pattern of the ModuleName schema that the names of written modules have to match.
+var moduleNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,127}$`)
+
+func validateModuleName(name string) error {
+	if !moduleNamePattern.MatchString(name) {
+		return echo.NewHTTPError(http.StatusBadRequest, ErrInvalidModuleName.Error())
+	}
+
+	return nil
+}
+
+// lockModule locks the module for a write and reads its current version,
+// which makes checking the preconditions and writing a single step for concurrent writers.
+func (api *API) lockModule(
+	ctx context.Context, acc service.Account, device service.Device, name string,
+) (*moduleWrite, error) {
+	id := fmt.Sprintf("%s-%s-%s", acc.Username(), device.ID(), name)
+
+	unlock, err := api.Locker.Lock(ctx, id)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusServiceUnavailable, "module is locked by another write").
+			SetInternal(err)
+	}
+
+	write := &moduleWrite{id: id, name: name, account: acc, unlock: unlock}
+
+	stored, err := api.storedMetadata(ctx, id)
+	if err != nil {
+		write.release(ctx)
+
+		return nil, err
+	}
+
+	if service.Deleted(stored) {
+		write.tombstone = stored
+	} else {
+		write.current = stored
+	}
+
+	if write.current != nil {
+		write.moduleType = write.current.GetType()
+	}
+
+	return write, nil
+}
+
+func (w *moduleWrite) checkPreconditions(ifMatch, ifUnmodifiedSince *string) error {
+	if preconditionFailed(ifMatch, ifUnmodifiedSince, w.current) {
+		return echo.NewHTTPError(http.StatusPreconditionFailed, ErrModuleChanged.Error()).
+			SetInternal(ErrModuleChanged)
+	}
+
+	return nil
+}
+
+func (w *moduleWrite) release(ctx context.Context) {
+	if err := w.unlock(ctx); err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Str("module", w.id).Msg("could not release module lock")
+	}
+}
+
+// commitWrite stores the metadata and history of data that was just written to the module
+// and records the write in the change feed. Writes that did not change the data keep the current version.
+func (api *API) commitWrite(
+	ctx echo.Context, acc service.Account, device service.Device, write *moduleWrite, digest string, size int64,
+) (service.Metadata, error) {
+	requestCtx := ctx.Request().Context()
+	modifiedAt := time.Now()
+
+	if write.current != nil && write.current.GetHash() == digest {
+		return api.keepVersion(requestCtx, acc, write)
+	}
+
+	metadata := service.NewVersionedMetadata(write.id, modifiedAt, digest, service.NextVersion(write.current))
+	metadata.Size = size
+	metadata.Type = write.moduleType
+	metadata.Encoding = write.encoding
+	metadata.KeyID = write.keyID
+	metadata.SetExpiresAt(write.expiresAt)
+	write.headers.applyTo(metadata)
+
+	if err := api.MetadataProvider.Set(requestCtx, metadata); err != nil {
+		return nil, fmt.Errorf("could not create/update module metadata: %w", err)
+	}
+
+	// the module was written, a version missing in the history must not make the client retry the write
+	if err := api.recordVersion(requestCtx, metadata); err != nil {
+		zerolog.Ctx(requestCtx).Error().Err(err).Str("module", write.id).Msg("could not record module version")
+	}
+
+	if err := api.Usage.Record(requestCtx, acc, write.id, metadata.Size, write.expiresAt); err != nil {
+		zerolog.Ctx(requestCtx).Error().Err(err).Str("module", write.id).Msg("could not record module usage")
+	}
+
+	operation := service.ChangeOperationUpdated
+	if write.current == nil {
+		operation = service.ChangeOperationCreated
+	}
+
+	if err := api.recordChange(ctx, acc, device, write.name, operation, modifiedAt); err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+// keepVersion keeps the current version of a module whose data did not change, but records the codec and data key
+// it was stored with again, as compression or encryption might have changed in the meantime, as well as the
+// content headers it was written with. The write started the expiry of the module anew,
+// so its history and usage expire along with it.
+func (api *API) keepVersion(
+	ctx context.Context, acc service.Account, write *moduleWrite,
+) (service.Metadata, error) {
+	expiryChanged := !write.current.GetExpiresAt().Equal(write.expiresAt)
+
+	if write.current.GetEncoding() == write.encoding && write.current.GetKeyID() == write.keyID && !expiryChanged &&
+		contentHeadersOfMetadata(write.current) == write.headers {
+		return write.current, nil
+	}
+
+	metadata := service.MetadataOf(write.current)
+	metadata.Encoding = write.encoding
+	metadata.KeyID = write.keyID
+	metadata.SetExpiresAt(write.expiresAt)
+	write.headers.applyTo(&metadata)
+
+	if err := api.MetadataProvider.Set(ctx, &metadata); err != nil {
+		return nil, fmt.Errorf("could not update module metadata: %w", err)
+	}
+
+	if !expiryChanged {
+		return &metadata, nil
+	}
+
+	if err := api.recordVersion(ctx, &metadata); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Str("module", write.id).Msg("could not record module version")
+	}
+
+	if err := api.Usage.Record(ctx, acc, write.id, metadata.Size, write.expiresAt); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Str("module", write.id).Msg("could not record module usage")
+	}
+
+	return &metadata, nil
+}
+
+// recordVersion keeps the data that was just written to the module as version in its history,
+// the module is still locked so that the stored data belongs to the version.
+func (api *API) recordVersion(ctx context.Context, metadata service.Metadata) error {
+	if err := api.History.Record(ctx, metadata); err != nil {
+		return fmt.Errorf("could not record version %d: %w", metadata.GetVersion(), err)
 	}
 
 	return nil
@@ -59,8 +389,35 @@ func (api *API) GetModule(ctx echo.Context, name REST.ModuleName, params REST.Ge
 	}
 
 	id := fmt.Sprintf("%s-%s-%s", acc.Username(), device.ID(), name)
+	requestCtx := ctx.Request().Context()
+
+	// metadata is checked first so that unchanged or deleted modules are never read from the backend
+	metadata, err := api.storedMetadata(requestCtx, id)
+	if err != nil {
+		return err
+	}
+
+	if service.Deleted(metadata) {
+		return deleted(ctx, metadata)
+	}
+
+	if notModified(params.IfNoneMatch, params.IfModifiedSince, metadata) {
+		return writeNotModified(ctx, metadata)
+	}
+
+	rng, ranged, err := requestedRange(params.Range, params.IfRange, metadata)
+	if err != nil {
+		return notSatisfiable(ctx, metadata, err)
+	}
 
-	module, err := api.Modules.Get(ctx.Request().Context(), id)
+	if ranged {
+		setValidators(ctx, metadata)
+		setExpiresAt(ctx, metadata)
+
+		return api.streamRange(ctx, acc, id, rng, metadata)
+	}
+
+	module, err := api.Modules.Get(requestCtx, id)
 	if err != nil {
 		return fmt.Errorf("error while fetching module: %w", err)
 	}
@@ -68,22 +425,217 @@ func (api *API) GetModule(ctx echo.Context, name REST.ModuleName, params REST.Ge
 	status := http.StatusOK
 	if module.Size() == 0 {
 		status = http.StatusNoContent
+	} else if metadata != nil {
+		setValidators(ctx, metadata)
+		setExpiresAt(ctx, metadata)
+	}
+
+	return api.streamModule(ctx, acc, status, module, metadata)
+}
+
+// HeadModule answers with the headers GetModule would answer with for the whole module,
+// the module data itself is never read.
+func (api *API) HeadModule(ctx echo.Context, name REST.ModuleName, params REST.HeadModuleParams) error {
+	acc, device, err := api.resolveDeviceIDAndAccount(ctx, params.DeviceId, &params.XDeviceID)
+	if err != nil {
+		return err
+	}
+
+	id := fmt.Sprintf("%s-%s-%s", acc.Username(), device.ID(), name)
+	requestCtx := ctx.Request().Context()
+
+	metadata, err := api.storedMetadata(requestCtx, id)
+	if err != nil {
+		return err
+	}
+
+	if service.Deleted(metadata) {
+		return deleted(ctx, metadata)
+	}
+
+	if notModified(params.IfNoneMatch, params.IfModifiedSince, metadata) {
+		return writeNotModified(ctx, metadata)
+	}
+
+	size, err := api.moduleSize(requestCtx, id, metadata)
+	if err != nil {
+		return err
+	}
+
+	status := http.StatusOK
+	if size == 0 {
+		status = http.StatusNoContent
 	} else {
-		metadata, err := api.MetadataProvider.Get(
-			ctx.Request().Context(), service.MetadataID(id),
-		)
-		if err != nil {
-			return fmt.Errorf("could not create/update module metadata: %w", err)
+		header := ctx.Response().Header()
+		header.Set(echo.HeaderContentType, contentTypeOf(metadata))
+		setContentDisposition(ctx, metadata)
+
+		if metadata != nil {
+			setValidators(ctx, metadata)
+			setExpiresAt(ctx, metadata)
+			header.Set(HeaderAcceptRanges, byteRangeUnit)
 		}
+	}
 
-		ctx.Response().Header().Set(
-			XModifiedAt,
-			REST.ModifiedAtTimestamp(metadata.GetModifiedAt()).Format(time.RFC3339),
-		)
+	// the length of data encoded for the response is only known once it is encoded
+	coding := negotiateCoding(ctx, metadata, size == 0)
+	if size > 0 && !coding.recoded() {
+		ctx.Response().Header().Set(echo.HeaderContentLength, strconv.FormatInt(size, 10))
+	}
+
+	setCoding(ctx, metadata, coding)
+
+	if err := ctx.NoContent(status); err != nil {
+		return fmt.Errorf("could not write module headers: %w", err)
+	}
+
+	return nil
+}
+
+// moduleSize returns the size of the module data as it was written.
+// Modules written before their size was recorded are stored as they were written.
+func (api *API) moduleSize(ctx context.Context, id string, metadata service.Metadata) (int64, error) {
+	if metadata != nil && metadata.GetSize() > 0 {
+		return metadata.GetSize(), nil
+	}
+
+	module, err := api.Modules.Get(ctx, id)
+	if err != nil {
+		return 0, fmt.Errorf("error while fetching module: %w", err)
+	}
+
+	return int64(module.Size()), nil
+}
+
+// writeNotModified answers with the validators of the coding the module would have been sent with.
+func writeNotModified(ctx echo.Context, metadata service.Metadata) error {
+	setValidators(ctx, metadata)
+	setExpiresAt(ctx, metadata)
+	setCoding(ctx, metadata, negotiateCoding(ctx, metadata, metadata.GetSize() == 0))
+
+	if err := ctx.NoContent(http.StatusNotModified); err != nil {
+		return fmt.Errorf("could not write not modified response: %w", err)
+	}
+
+	return nil
+}
+
+// currentMetadata returns the metadata of the module or nil if the module was never written or was deleted.
+func (api *API) currentMetadata(ctx context.Context, id string) (service.Metadata, error) {
+	metadata, err := api.storedMetadata(ctx, id)
+	if err != nil || service.Deleted(metadata) {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+// storedMetadata returns the metadata of the module, which is the tombstone of a deleted module,
+// or nil if the module was never written or its tombstone expired.
+func (api *API) storedMetadata(ctx context.Context, id string) (service.Metadata, error) {
+	metadata, err := api.MetadataProvider.Get(ctx, service.MetadataID(id))
+	if errors.Is(err, service.ErrNoMetadata) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("could not read module metadata: %w", err)
+	}
+
+	return metadata, nil
+}
+
+// storedMetadataOf returns the stored metadata of all modules in a single round trip, in the order of the ids.
+func (api *API) storedMetadataOf(ctx context.Context, ids []string) ([]service.Metadata, error) {
+	metadataIDs := make([]service.MetadataID, len(ids))
+	for i, id := range ids {
+		metadataIDs[i] = service.MetadataID(id)
+	}
+
+	metadata, err := api.MetadataProvider.GetMany(ctx, metadataIDs)
+	if err != nil {
+		return nil, fmt.Errorf("could not read module metadata: %w", err)
+	}
+
+	return metadata, nil
+}
+
+func (api *API) ListModules(ctx echo.Context, params REST.ListModulesParams) error {
+	acc, device, err := api.resolveDeviceIDAndAccount(ctx, params.DeviceId, &params.XDeviceID)
+	if err != nil {
+		return err
+	}
+
+	idPrefix := fmt.Sprintf("%s-%s-", acc.Username(), device.ID())
+	opts := service.ListOptions{Prefix: idPrefix}
+
+	if params.Prefix != nil {
+		opts.Prefix += *params.Prefix
+	}
+
+	if params.Cursor != nil {
+		opts.Cursor = *params.Cursor
+	}
+
+	if params.Limit != nil {
+		opts.Limit = *params.Limit
+	}
+
+	infos, next, err := api.Modules.List(ctx.Request().Context(), opts)
+	if errors.Is(err, service.ErrInvalidCursor) {
+		return echo.NewHTTPError(http.StatusBadRequest, service.ErrInvalidCursor.Error()).SetInternal(err)
+	}
+
+	if err != nil {
+		return fmt.Errorf("error while listing modules: %w", err)
+	}
+
+	ids := make([]string, len(infos))
+	for i, info := range infos {
+		ids[i] = info.ID
+	}
+
+	metadata, err := api.storedMetadataOf(ctx.Request().Context(), ids)
+	if err != nil {
+		return err
 	}
 
-	if err := ctx.Stream(status, echo.MIMEOctetStream, module.Raw()); err != nil {
-		return fmt.Errorf("error while writing module data to response while fetching module: %w", err)
+	items := make([]REST.ModuleInfo, 0, len(infos))
+
+	for i, info := range infos {
+		item := REST.ModuleInfo{
+			Name:      strings.TrimPrefix(info.ID, idPrefix),
+			Size:      info.Size,
+			Device:    device.ID().UUID(),
+			ExpiresAt: optionalTime(info.ExpiresAt),
+		}
+
+		if metadata := metadata[i]; metadata != nil && !service.Deleted(metadata) {
+			modifiedAt := REST.ModifiedAtTimestamp(metadata.GetModifiedAt())
+			item.ModifiedAt = &modifiedAt
+
+			// the size of the data before it was compressed at rest
+			if metadata.GetEncoding() != service.EncodingIdentity {
+				item.Size = metadata.GetSize()
+			}
+
+			if moduleType := metadata.GetType(); moduleType.IsCRDT() {
+				restType := REST.ModuleType(moduleType)
+				item.Type = &restType
+			}
+
+			item.ContentType = optionalString(metadata.GetContentType())
+		}
+
+		items = append(items, item)
+	}
+
+	if err := ctx.JSON(http.StatusOK, &REST.ModuleList{
+		Count: len(items),
+		Items: items,
+		Next:  optionalString(next),
+	}); err != nil {
+		return fmt.Errorf("could not write module list response: %w", err)
 	}
 
 	return nil
@@ -95,19 +647,135 @@ func (api *API) DeleteModules(ctx echo.Context, params REST.DeleteModulesParams)
 		return err
 	}
 
-	idPattern := fmt.Sprintf("%s-%s-*", acc.Username(), device.ID())
+	requestCtx := ctx.Request().Context()
+	idPrefix := fmt.Sprintf("%s-%s-", acc.Username(), device.ID())
 
-	if err := api.Modules.DeleteByPattern(ctx.Request().Context(), idPattern); err != nil {
-		return fmt.Errorf("error while fetching module: %w", err)
+	ids, err := api.moduleIDs(requestCtx, idPrefix)
+	if err != nil {
+		return err
+	}
+
+	deletedAt := time.Now()
+
+	for _, id := range ids {
+		if err := api.deleteLockedModule(ctx, acc, device, strings.TrimPrefix(id, idPrefix), deletedAt); err != nil {
+			return err
+		}
 	}
 
 	if err := ctx.JSON(http.StatusAccepted, nil); err != nil {
-		return fmt.Errorf("could not acknowledge module creation: %w", err)
+		return fmt.Errorf("could not acknowledge module deletion: %w", err)
 	}
 
 	return nil
 }
 
+// deleteLockedModule deletes the module while it is locked, so that concurrent writes are either deleted as well
+// or written after the tombstone. Modules deleted in the meantime are left as they are.
+func (api *API) deleteLockedModule(
+	ctx echo.Context, acc service.Account, device service.Device, name string, deletedAt time.Time,
+) error {
+	requestCtx := ctx.Request().Context()
+
+	write, err := api.lockModule(requestCtx, acc, device, name)
+	if err != nil {
+		return err
+	}
+	defer write.release(requestCtx)
+
+	if write.tombstone != nil {
+		return nil
+	}
+
+	if err := api.deleteModule(requestCtx, acc, write.id, write.current, deletedAt); err != nil {
+		return err
+	}
+
+	return api.recordChange(ctx, acc, device, name, service.ChangeOperationDeleted, deletedAt)
+}
+
+// DeleteModule deletes a single module of the device if the preconditions of the client are met
+// and leaves a tombstone, so that reading the module tells that it was deleted.
+func (api *API) DeleteModule(ctx echo.Context, name REST.ModuleName, params REST.DeleteModuleParams) error {
+	acc, device, err := api.resolveDeviceIDAndAccount(ctx, &params.XDeviceID)
+	if err != nil {
+		return err
+	}
+
+	requestCtx := ctx.Request().Context()
+
+	write, err := api.lockModule(requestCtx, acc, device, name)
+	if err != nil {
+		return err
+	}
+	defer write.release(requestCtx)
+
+	// deleting a deleted module again changes nothing
+	if write.tombstone != nil {
+		return deleted(ctx, write.tombstone)
+	}
+
+	if err := write.checkPreconditions(params.IfMatch, params.IfUnmodifiedSince); err != nil {
+		if write.current != nil {
+			setValidators(ctx, write.current)
+		}
+
+		return err
+	}
+
+	// modules written before metadata was recorded only have data
+	if write.current == nil {
+		size, err := api.moduleSize(requestCtx, write.id, nil)
+		if err != nil {
+			return err
+		}
+
+		if size == 0 {
+			return echo.NewHTTPError(http.StatusNotFound, ErrModuleNotFound.Error())
+		}
+	}
+
+	deletedAt := time.Now()
+
+	if err := api.deleteModule(requestCtx, acc, write.id, write.current, deletedAt); err != nil {
+		return err
+	}
+
+	if err := api.recordChange(ctx, acc, device, name, service.ChangeOperationDeleted, deletedAt); err != nil {
+		return err
+	}
+
+	if err := ctx.JSON(http.StatusAccepted, nil); err != nil {
+		return fmt.Errorf("could not acknowledge module deletion: %w", err)
+	}
+
+	return nil
+}
+
+// moduleIDs returns the ids of all modules starting with the prefix, across all pages of the listing.
+func (api *API) moduleIDs(ctx context.Context, prefix string) ([]string, error) {
+	var ids []string
+
+	opts := service.ListOptions{Prefix: prefix}
+
+	for {
+		infos, next, err := api.Modules.List(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error while listing modules: %w", err)
+		}
+
+		for _, info := range infos {
+			ids = append(ids, info.ID)
+		}
+
+		if next == "" {
+			return ids, nil
+		}
+
+		opts.Cursor = next
+	}
+}
+
 func (api *API) resolveDeviceIDAndAccount(
 	ctx echo.Context, deviceIDs ...*uuid.UUID,
 ) (service.Account, service.Device, error) {
@@ -17,9 +17,11 @@ import (
 
 	v1 "github.com/jakobmoellerdev/octi-sync-server/api/v1"
 	"github.com/jakobmoellerdev/octi-sync-server/api/v1/REST"
+	"github.com/jakobmoellerdev/octi-sync-server/config"
 	"github.com/jakobmoellerdev/octi-sync-server/middleware/basic"
 	"github.com/jakobmoellerdev/octi-sync-server/middleware/logging"
 	"github.com/jakobmoellerdev/octi-sync-server/service"
+	"github.com/jakobmoellerdev/octi-sync-server/service/memory"
 	"github.com/jakobmoellerdev/octi-sync-server/service/mock"
 	"github.com/jakobmoellerdev/octi-sync-server/service/redis"
 )
@@ -35,6 +37,7 @@ type ModuleTestSuite struct {
 	api      *v1.API
 	modules  *mock.MockModules
 	metadata *mock.MockMetadataProvider
+	history  *mock.MockHistory
 	devices  *mock.MockDevices
 	server   *echo.Echo
 	deviceID uuid.UUID
@@ -51,14 +54,21 @@ func (m *ModuleTestSuite) SetupTest() {
 	ctrl := gomock.NewController(m.T())
 	m.modules = mock.NewMockModules(ctrl)
 	m.metadata = mock.NewMockMetadataProvider(ctrl)
+	m.history = mock.NewMockHistory(ctrl)
 	m.devices = mock.NewMockDevices(ctrl)
 	m.server = echo.New()
 	logger := zerolog.New(zerolog.NewConsoleWriter(zerolog.ConsoleTestWriter(m.T())))
-	m.server.Use(logging.RequestLogging(&logger))
+	m.server.Use(logging.RequestLogging(&logger, config.AccessLogSettings{}), logging.ContextLogger(&logger))
 	m.api = &v1.API{
 		Modules:          m.modules,
 		MetadataProvider: m.metadata,
 		Devices:          m.devices,
+		Locker:           memory.NewLocker(),
+		History:          m.history,
+		ChangeFeed:       memory.NewChangeFeed(0),
+		Events:           memory.NewEvents(),
+		Usage:            memory.NewUsage(),
+		Schemas:          memory.NewSchemas(),
 	}
 	deviceID, err := uuid.NewRandom()
 
@@ -83,12 +93,14 @@ func (m *ModuleTestSuite) TestAPI_CreateModule() {
 	ctx.Set(basic.AccountKey, m.user)
 	ctx.Set(basic.Device, m.device)
 
+	m.metadata.EXPECT().Get(ctx.Request().Context(), gomock.Any()).Return(nil, service.ErrNoMetadata)
 	m.modules.EXPECT().Set(
 		ctx.Request().Context(), fmt.Sprintf("%s-%s-%s", m.user.Username(), m.deviceID, moduleName),
-		gomock.Any(),
+		gomock.Any(), time.Time{},
 	).Return(nil)
 
 	m.metadata.EXPECT().Set(ctx.Request().Context(), gomock.Any()).Return(nil)
+	m.history.EXPECT().Record(ctx.Request().Context(), gomock.Any()).Return(nil)
 
 	if m.NoError(
 		m.api.CreateModule(ctx, moduleName, REST.CreateModuleParams{XDeviceID: m.deviceID}),
@@ -108,9 +120,10 @@ func (m *ModuleTestSuite) TestAPI_CreateModule_WriteFails() {
 	ctx.Set(basic.AccountKey, m.user)
 	ctx.Set(basic.Device, service.NewBaseDevice(service.DeviceID(m.deviceID), "test"))
 
+	m.metadata.EXPECT().Get(ctx.Request().Context(), gomock.Any()).Return(nil, service.ErrNoMetadata)
 	m.modules.EXPECT().Set(
 		ctx.Request().Context(), fmt.Sprintf("%s-%s-%s", m.user.Username(), m.deviceID, moduleName),
-		gomock.Any(),
+		gomock.Any(), time.Time{},
 	).Return(errors.New("set error"))
 
 	m.ErrorContains(
@@ -129,9 +142,10 @@ func (m *ModuleTestSuite) TestAPI_CreateModule_MetadataFails() {
 	ctx.Set(basic.AccountKey, m.user)
 	ctx.Set(basic.Device, m.device)
 
+	m.metadata.EXPECT().Get(ctx.Request().Context(), gomock.Any()).Return(nil, service.ErrNoMetadata)
 	m.modules.EXPECT().Set(
 		ctx.Request().Context(), fmt.Sprintf("%s-%s-%s", m.user.Username(), m.deviceID, moduleName),
-		gomock.Any(),
+		gomock.Any(), time.Time{},
 	).Return(nil)
 
 	m.metadata.EXPECT().Set(ctx.Request().Context(), gomock.Any()).Return(
@@ -235,6 +249,10 @@ func (m *ModuleTestSuite) TestAPI_GetModule_By_Param() {
 					id, time.Now(),
 				), nil,
 			)
+		} else {
+			m.metadata.EXPECT().Get(ctx.Request().Context(), service.MetadataID(id)).Return(
+				nil, service.ErrNoMetadata,
+			)
 		}
 
 		m.modules.EXPECT().Get(
@@ -255,3 +273,63 @@ func (m *ModuleTestSuite) TestAPI_GetModule_By_Param() {
 		}
 	}
 }
+
+func (m *ModuleTestSuite) TestAPI_ListModules() {
+	req := emptyRequest(http.MethodGet)
+	ctx := m.server.NewContext(req, m.rec)
+
+	ctx.Set(basic.AccountKey, m.user)
+	ctx.Set(basic.Device, m.device)
+
+	idPrefix := fmt.Sprintf("%s-%s-", m.user.Username(), m.deviceID)
+	modifiedAt := time.Now().UTC().Truncate(time.Second)
+	expiresAt := modifiedAt.Add(time.Hour)
+	prefix, limit := "te", 1
+
+	m.modules.EXPECT().List(ctx.Request().Context(), service.ListOptions{
+		Prefix: idPrefix + prefix, Limit: limit,
+	}).Return([]service.ModuleInfo{
+		{ID: idPrefix + moduleName, Size: int64(len(moduleData)), ExpiresAt: expiresAt},
+	}, "next-cursor", nil)
+
+	m.metadata.EXPECT().GetMany(ctx.Request().Context(), []service.MetadataID{
+		service.MetadataID(idPrefix + moduleName),
+	}).Return([]service.Metadata{service.NewBaseMetadata(idPrefix+moduleName, modifiedAt)}, nil)
+
+	if m.NoError(m.api.ListModules(ctx, REST.ListModulesParams{
+		XDeviceID: m.deviceID, Prefix: &prefix, Limit: &limit,
+	})) {
+		m.Equal(http.StatusOK, m.rec.Code)
+
+		var list REST.ModuleList
+		m.NoError(json.Unmarshal(m.rec.Body.Bytes(), &list))
+		m.Equal(1, list.Count)
+		m.Equal(moduleName, list.Items[0].Name)
+		m.Equal(int64(len(moduleData)), list.Items[0].Size)
+		m.Equal(m.deviceID, list.Items[0].Device)
+		m.Equal(expiresAt, *list.Items[0].ExpiresAt)
+		m.Equal(modifiedAt, time.Time(*list.Items[0].ModifiedAt))
+		m.Equal("next-cursor", *list.Next)
+	}
+}
+
+func (m *ModuleTestSuite) TestAPI_ListModules_InvalidCursor() {
+	req := emptyRequest(http.MethodGet)
+	ctx := m.server.NewContext(req, m.rec)
+
+	ctx.Set(basic.AccountKey, m.user)
+	ctx.Set(basic.Device, m.device)
+
+	cursor := "invalid"
+
+	m.modules.EXPECT().List(ctx.Request().Context(), gomock.Any()).Return(
+		nil, "", fmt.Errorf("could not paginate modules: %w", service.ErrInvalidCursor),
+	)
+
+	err := m.api.ListModules(ctx, REST.ListModulesParams{XDeviceID: m.deviceID, Cursor: &cursor})
+
+	var httpError *echo.HTTPError
+	if m.ErrorAs(err, &httpError) {
+		m.Equal(http.StatusBadRequest, httpError.Code)
+	}
+}
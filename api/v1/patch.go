@@ -0,0 +1,96 @@
+package v1
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	json "github.com/json-iterator/go"
+	"github.com/labstack/echo/v4"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+const (
+	MIMEApplicationMergePatchJSON = "application/merge-patch+json"
+	MIMEApplicationJSONPatchJSON  = "application/json-patch+json"
+)
+
+var (
+	ErrPatchOfMissingModule = errors.New("json patches can only be applied to an existing module")
+	ErrPatchOfNonJSONModule = errors.New("patches can only be applied to modules containing json")
+	ErrInvalidPatch         = errors.New("patch is invalid")
+	ErrPatchTestFailed      = errors.New("test operation of the patch failed")
+	ErrPatchNotApplicable   = errors.New("patch cannot be applied to the module")
+)
+
+// modulePatch computes the new data of a module from its current data, which is empty if the module does not exist.
+type modulePatch func(current, patch []byte) ([]byte, error)
+
+// writeMode decides how written data becomes the new data of a module.
+type writeMode struct {
+	// patch computes the new data from the current data of the module, nil replaces the data
+	patch modulePatch
+	// moduleType is the type of module the client writes
+	moduleType service.ModuleType
+}
+
+// writeModeOf returns the write mode selected by the content type of the request.
+func writeModeOf(ctx echo.Context) writeMode {
+	mediaType, _, _ := mime.ParseMediaType(ctx.Request().Header.Get(echo.HeaderContentType))
+
+	switch mediaType {
+	case MIMEApplicationMergePatchJSON:
+		return writeMode{patch: mergePatch}
+	case MIMEApplicationJSONPatchJSON:
+		return writeMode{patch: jsonPatch}
+	case MIMEApplicationLWWMapJSON:
+		return crdtWriteMode(service.ModuleTypeLWWMap)
+	default:
+		return writeMode{}
+	}
+}
+
+// mergePatch applies a JSON Merge Patch (RFC 7396), a missing module is patched like an empty object.
+func mergePatch(current, patch []byte) ([]byte, error) {
+	if len(current) == 0 {
+		current = []byte("{}")
+	}
+
+	patched, err := jsonpatch.MergePatch(current, patch)
+
+	switch {
+	case errors.Is(err, jsonpatch.ErrBadJSONDoc):
+		return nil, echo.NewHTTPError(http.StatusConflict, ErrPatchOfNonJSONModule.Error()).SetInternal(err)
+	case err != nil:
+		return nil, echo.NewHTTPError(http.StatusBadRequest, ErrInvalidPatch.Error()).SetInternal(err)
+	}
+
+	return patched, nil
+}
+
+// jsonPatch applies all operations of a JSON Patch (RFC 6902) or none of them.
+func jsonPatch(current, patch []byte) ([]byte, error) {
+	operations, err := jsonpatch.DecodePatch(patch)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, ErrInvalidPatch.Error()).SetInternal(err)
+	}
+
+	if len(current) == 0 {
+		return nil, echo.NewHTTPError(http.StatusConflict, ErrPatchOfMissingModule.Error())
+	}
+
+	patched, err := operations.Apply(current)
+
+	switch {
+	case errors.Is(err, jsonpatch.ErrTestFailed):
+		return nil, echo.NewHTTPError(http.StatusConflict, ErrPatchTestFailed.Error()).SetInternal(err)
+	case err != nil && !json.Valid(current):
+		return nil, echo.NewHTTPError(http.StatusConflict, ErrPatchOfNonJSONModule.Error()).SetInternal(err)
+	case err != nil:
+		return nil, echo.NewHTTPError(http.StatusConflict, ErrPatchNotApplicable.Error()).SetInternal(err)
+	}
+
+	return patched, nil
+}
@@ -0,0 +1,75 @@
+package v1_test
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	json "github.com/json-iterator/go"
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/jakobmoellerdev/octi-sync-server/api/v1"
+)
+
+func TestAPI_PatchModule(t *testing.T) {
+	t.Parallel()
+	_, assertions, router := SetupAPITest(t)
+	api := API()
+
+	client := newTestDevice(t, api, router, "patches")
+
+	write := func(name, contentType, body string) error {
+		return client.write(name, body, echo.HeaderContentType, contentType)
+	}
+	read := func(name string) map[string]interface{} {
+		rec, err := client.read(name)
+		assertions.NoError(err)
+
+		var module map[string]interface{}
+
+		assertions.NoError(json.Unmarshal(rec.Body.Bytes(), &module))
+
+		return module
+	}
+
+	assertions.NoError(write("settings", v1.MIMEApplicationMergePatchJSON, `{"theme": "dark", "size": 12}`))
+	assertions.NoError(write("settings", v1.MIMEApplicationMergePatchJSON+"; charset=utf-8", `{"size": null, "lang": "en"}`))
+	assertions.Equal(map[string]interface{}{"theme": "dark", "lang": "en"}, read("settings"))
+
+	assertions.NoError(write("settings", v1.MIMEApplicationJSONPatchJSON,
+		`[{"op": "test", "path": "/theme", "value": "dark"}, {"op": "replace", "path": "/theme", "value": "light"}]`))
+	assertions.Equal("light", read("settings")["theme"])
+
+	// a failing operation discards the operations before it
+	assertHTTPError(assertions, write("settings", v1.MIMEApplicationJSONPatchJSON,
+		`[{"op": "remove", "path": "/lang"}, {"op": "test", "path": "/theme", "value": "dark"}]`), http.StatusConflict)
+	assertHTTPError(assertions, write("settings", v1.MIMEApplicationJSONPatchJSON,
+		`[{"op": "remove", "path": "/missing"}]`), http.StatusConflict)
+	assertions.Equal(map[string]interface{}{"theme": "light", "lang": "en"}, read("settings"))
+
+	assertHTTPError(assertions, write("settings", v1.MIMEApplicationJSONPatchJSON, `{"op": "add"}`),
+		http.StatusBadRequest)
+	assertHTTPError(assertions, write("settings", v1.MIMEApplicationMergePatchJSON, `{"theme":`),
+		http.StatusBadRequest)
+	assertHTTPError(assertions, write("contacts", v1.MIMEApplicationJSONPatchJSON,
+		`[{"op": "add", "path": "/name", "value": "octi"}]`), http.StatusConflict)
+
+	assertions.NoError(write("raw", echo.MIMEOctetStream, "not json"))
+	assertHTTPError(assertions, write("raw", v1.MIMEApplicationMergePatchJSON, `{"a": 1}`), http.StatusConflict)
+
+	// concurrent patches of different members are all applied
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+			assertions.NoError(write("counters", v1.MIMEApplicationMergePatchJSON, fmt.Sprintf(`{"c%d": %d}`, i, i)))
+		}(i)
+	}
+
+	wg.Wait()
+	assertions.Len(read("counters"), 10)
+}
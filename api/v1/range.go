@@ -0,0 +1,170 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+const (
+	HeaderAcceptRanges = "Accept-Ranges"
+	HeaderContentRange = "Content-Range"
+	byteRangeUnit      = "bytes"
+)
+
+var ErrRangeNotSatisfiable = errors.New("range starts after the end of the module data")
+
+// byteRange is a range of the module data that is served on its own.
+type byteRange struct {
+	start, length int64
+}
+
+// parseRange parses a Range header of a single byte range as described in RFC 9110 section 14.1.2
+// for data of the size. Ranges of other units, multiple ranges and invalid ranges are ignored,
+// so that the whole data is served instead. ErrRangeNotSatisfiable is returned for ranges outside the data.
+func parseRange(header string, size int64) (byteRange, bool, error) {
+	unit, spec, found := strings.Cut(strings.TrimSpace(header), "=")
+	if !found || !strings.EqualFold(unit, byteRangeUnit) || strings.Contains(spec, ",") {
+		return byteRange{}, false, nil
+	}
+
+	first, last, found := strings.Cut(strings.TrimSpace(spec), "-")
+	if !found {
+		return byteRange{}, false, nil
+	}
+
+	// a suffix range requests the last bytes of the data
+	if first == "" {
+		suffix, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || suffix < 0 {
+			return byteRange{}, false, nil
+		}
+
+		if suffix == 0 {
+			return byteRange{}, false, ErrRangeNotSatisfiable
+		}
+
+		suffix = min(suffix, size)
+
+		return byteRange{start: size - suffix, length: suffix}, true, nil
+	}
+
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 {
+		return byteRange{}, false, nil
+	}
+
+	end := size - 1
+
+	if last != "" {
+		if end, err = strconv.ParseInt(last, 10, 64); err != nil || end < start {
+			return byteRange{}, false, nil
+		}
+	}
+
+	if start >= size {
+		return byteRange{}, false, ErrRangeNotSatisfiable
+	}
+
+	return byteRange{start: start, length: min(end, size-1) - start + 1}, true, nil
+}
+
+// requestedRange returns the range of the module the client requested, if any. A range is only served if
+// If-Range names the current version of the module by its ETag or the exact date of its last modification.
+func requestedRange(rangeHeader, ifRange *string, metadata service.Metadata) (byteRange, bool, error) {
+	if rangeHeader == nil || metadata == nil || metadata.GetSize() == 0 {
+		return byteRange{}, false, nil
+	}
+
+	if ifRange != nil && !rangeStillValid(*ifRange, metadata) {
+		return byteRange{}, false, nil
+	}
+
+	return parseRange(*rangeHeader, metadata.GetSize())
+}
+
+// rangeStillValid evaluates If-Range as described in RFC 9110 section 13.1.5, ETags are compared strongly.
+func rangeStillValid(ifRange string, metadata service.Metadata) bool {
+	ifRange = strings.TrimSpace(ifRange)
+
+	if strings.HasPrefix(ifRange, `"`) || strings.HasPrefix(ifRange, weakEntityTagIdentifier) {
+		etag := entityTag(metadata)
+
+		return etag != "" && ifRange == etag
+	}
+
+	date, err := http.ParseTime(ifRange)
+
+	return err == nil && time.Time(metadata.GetModifiedAt()).Truncate(time.Second).Equal(date)
+}
+
+// streamRange writes the range of the module to the response. Ranges refer to the data as it was written,
+// so they are never compressed, but data content encoded by the client keeps its encoding.
+func (api *API) streamRange(
+	ctx echo.Context, acc service.Account, id string, rng byteRange, metadata service.Metadata,
+) error {
+	data, err := api.moduleRange(ctx.Request().Context(), acc, id, rng, metadata)
+	if err != nil {
+		return err
+	}
+
+	header := ctx.Response().Header()
+	header.Set(HeaderAcceptRanges, byteRangeUnit)
+	header.Set(HeaderContentRange,
+		fmt.Sprintf("%s %d-%d/%d", byteRangeUnit, rng.start, rng.start+rng.length-1, metadata.GetSize()))
+	header.Set(echo.HeaderContentLength, strconv.FormatInt(rng.length, 10))
+	setContentDisposition(ctx, metadata)
+
+	if contentEncoding := metadata.GetContentEncoding(); contentEncoding != "" {
+		header.Set(echo.HeaderContentEncoding, contentEncoding)
+	}
+
+	return stream(ctx, http.StatusPartialContent, contentTypeOf(metadata), data)
+}
+
+// moduleRange reads the range of the module. Modules stored as written are read ranged from the backend,
+// modules compressed or encrypted at rest have to be decoded from their start.
+func (api *API) moduleRange(
+	ctx context.Context, acc service.Account, id string, rng byteRange, metadata service.Metadata,
+) (io.Reader, error) {
+	if metadata.GetEncoding() == service.EncodingIdentity && metadata.GetKeyID() == "" {
+		module, err := api.Modules.GetRange(ctx, id, rng.start, rng.length)
+		if err != nil {
+			return nil, fmt.Errorf("error while fetching module range: %w", err)
+		}
+
+		return module.Raw(), nil
+	}
+
+	module, err := api.Modules.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching module: %w", err)
+	}
+
+	if module, err = api.decodeModule(ctx, acc, module, metadata); err != nil {
+		return nil, err
+	}
+
+	data := module.Raw()
+	if _, err := io.CopyN(io.Discard, data, rng.start); err != nil {
+		return nil, fmt.Errorf("error while skipping to the module range: %w", err)
+	}
+
+	return io.LimitReader(data, rng.length), nil
+}
+
+// notSatisfiable rejects a range outside of the module data and tells the client the size of the data.
+func notSatisfiable(ctx echo.Context, metadata service.Metadata, err error) error {
+	ctx.Response().Header().Set(HeaderContentRange, fmt.Sprintf("%s */%d", byteRangeUnit, metadata.GetSize()))
+
+	return echo.NewHTTPError(http.StatusRequestedRangeNotSatisfiable, err.Error()).SetInternal(err)
+}
@@ -0,0 +1,124 @@
+package v1_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/jakobmoellerdev/octi-sync-server/api/v1"
+	"github.com/jakobmoellerdev/octi-sync-server/api/v1/REST"
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+func TestAPI_ModuleRanges(t *testing.T) {
+	t.Parallel()
+	_, assertions, router := SetupAPITest(t)
+	api := API()
+
+	client := newTestDevice(t, api, router, "ranges")
+
+	write := func(name, data string) {
+		assertions.NoError(client.write(name, data, echo.HeaderContentType, echo.MIMETextPlain))
+	}
+	read := func(name, rangeHeader string, ifRange *string) (*httptest.ResponseRecorder, error) {
+		ctx, rec := client.request(http.MethodGet, "")
+		err := api.GetModule(ctx, name, REST.GetModuleParams{
+			XDeviceID: client.deviceID, Range: &rangeHeader, IfRange: ifRange,
+		})
+
+		return rec, err
+	}
+
+	write("ranged", "0123456789")
+
+	for rangeHeader, expected := range map[string]string{
+		"bytes=2-5":  "bytes 2-5/10",
+		"bytes=8-":   "bytes 8-9/10",
+		"bytes=-3":   "bytes 7-9/10",
+		"bytes=4-99": "bytes 4-9/10",
+		"bytes=-99":  "bytes 0-9/10",
+	} {
+		rec, err := read("ranged", rangeHeader, nil)
+		assertions.NoError(err)
+		assertions.Equal(http.StatusPartialContent, rec.Code, rangeHeader)
+		assertions.Equal(expected, rec.Header().Get(v1.HeaderContentRange), rangeHeader)
+		assertions.Equal(echo.MIMETextPlain, rec.Header().Get(echo.HeaderContentType))
+		assertions.Empty(rec.Header().Get(echo.HeaderContentEncoding))
+	}
+
+	rec, err := read("ranged", "bytes=2-5", nil)
+	assertions.NoError(err)
+	assertions.Equal("2345", rec.Body.String())
+	assertions.Equal("4", rec.Header().Get(echo.HeaderContentLength))
+
+	// ranges past the end cannot be served, ranges the server cannot serve return the whole module
+	rec, err = read("ranged", "bytes=10-", nil)
+	assertHTTPError(assertions, err, http.StatusRequestedRangeNotSatisfiable)
+	assertions.Equal("bytes */10", rec.Header().Get(v1.HeaderContentRange))
+
+	for _, rangeHeader := range []string{"bytes=0-1,4-5", "bytes=5-2", "lines=1-2", "bytes=x-"} {
+		rec, err = read("ranged", rangeHeader, nil)
+		assertions.NoError(err)
+		assertions.Equal(http.StatusOK, rec.Code, rangeHeader)
+		assertions.Equal("0123456789", rec.Body.String(), rangeHeader)
+	}
+
+	// ranges of a changed module are not served, so that a resumed download cannot mix versions
+	etag := rec.Header().Get(v1.HeaderETag)
+	rec, err = read("ranged", "bytes=5-", &etag)
+	assertions.NoError(err)
+	assertions.Equal("56789", rec.Body.String())
+
+	write("ranged", "9876543210")
+
+	rec, err = read("ranged", "bytes=5-", &etag)
+	assertions.NoError(err)
+	assertions.Equal(http.StatusOK, rec.Code)
+	assertions.Equal("9876543210", rec.Body.String())
+
+	// modules compressed at rest are decoded up to the range
+	api.Compression = service.EncodingZstd
+	write("compressed", strings.Repeat("abc", 100))
+
+	rec, err = read("compressed", "bytes=298-", nil)
+	assertions.NoError(err)
+	assertions.Equal(http.StatusPartialContent, rec.Code)
+	assertions.Equal("bc", rec.Body.String())
+}
+
+func TestAPI_HeadModule(t *testing.T) {
+	t.Parallel()
+	_, assertions, router := SetupAPITest(t)
+	api := API()
+	api.Compression = service.EncodingZstd
+
+	client := newTestDevice(t, api, router, "head")
+	deviceID := client.deviceID
+
+	assertions.NoError(client.write("settings", strings.Repeat("data", 64),
+		echo.HeaderContentType, echo.MIMEApplicationJSON))
+
+	// the size is the one of the data as it was written, not as it is compressed at rest
+	ctx, rec := client.request(http.MethodHead, "")
+	assertions.NoError(api.HeadModule(ctx, "settings", REST.HeadModuleParams{XDeviceID: deviceID}))
+	assertions.Equal(http.StatusOK, rec.Code)
+	assertions.Empty(rec.Body.String())
+	assertions.Equal("256", rec.Header().Get(echo.HeaderContentLength))
+	assertions.Equal(echo.MIMEApplicationJSON, rec.Header().Get(echo.HeaderContentType))
+	assertions.Equal("bytes", rec.Header().Get(v1.HeaderAcceptRanges))
+	assertions.NotEmpty(rec.Header().Get(v1.XModifiedAt))
+
+	etag := rec.Header().Get(v1.HeaderETag)
+	assertions.NotEmpty(etag)
+
+	ctx, rec = client.request(http.MethodHead, "")
+	assertions.NoError(api.HeadModule(ctx, "settings", REST.HeadModuleParams{XDeviceID: deviceID, IfNoneMatch: &etag}))
+	assertions.Equal(http.StatusNotModified, rec.Code)
+
+	ctx, rec = client.request(http.MethodHead, "")
+	assertions.NoError(api.HeadModule(ctx, "missing", REST.HeadModuleParams{XDeviceID: deviceID}))
+	assertions.Equal(http.StatusNoContent, rec.Code)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/labstack/echo/v4"
 
@@ -77,10 +78,28 @@ func (api *API) Register(ctx echo.Context, params REST.RegisterParams) error {
 			return echo.NewHTTPError(http.StatusForbidden).
 				SetInternal(ErrDeviceNotRegistered)
 		}
+
+		if device == nil {
+			if err := api.checkDeviceQuota(ctx.Request().Context(), account); err != nil {
+				return err
+			}
+		}
+	}
+
+	info := deviceInfo(params)
+	info.RemoteIP = ctx.RealIP()
+
+	// devices joining through a share code have to be approved before accessing the account,
+	// unless they were already approved before
+	if shareCode != "" && api.RequireDeviceApproval &&
+		(device == nil || device.Status() == service.DeviceStatusPending) {
+		info.Status = service.DeviceStatusPending
 	}
 
+	added := device == nil
+
 	// if the device is present or there is a valid shareCode is then we are free to (re-)register the device
-	device, err = api.Devices.AddDevice(ctx.Request().Context(), account, deviceID, password)
+	device, err = api.Devices.AddDevice(ctx.Request().Context(), account, deviceID, password, info)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError).SetInternal(
 			fmt.Errorf("cannot register device %s for %s: %w", deviceID, account.Username(), err),
@@ -91,10 +110,27 @@ func (api *API) Register(ctx echo.Context, params REST.RegisterParams) error {
 		return err
 	}
 
+	registeredAt := time.Now()
+
+	if added {
+		api.publish(ctx, account, service.Event{Type: service.EventDeviceAdded, Device: device.ID(), At: registeredAt})
+	}
+
+	if shareCode != "" {
+		api.publish(ctx, account, service.Event{
+			Type: service.EventShareRedeemed, Device: device.ID(), At: registeredAt,
+		})
+	}
+
 	ctx.Response().Header().Set(basic.DeviceIDHeader, device.ID().String())
 
+	status := http.StatusOK
+	if device.Status() == service.DeviceStatusPending {
+		status = http.StatusAccepted
+	}
+
 	if err = ctx.JSON(
-		http.StatusOK, &REST.RegistrationResult{
+		status, &REST.RegistrationResult{
 			Password: password,
 			Username: account.Username(),
 		},
@@ -105,6 +141,24 @@ func (api *API) Register(ctx echo.Context, params REST.RegisterParams) error {
 	return nil
 }
 
+func deviceInfo(params REST.RegisterParams) service.DeviceInfo {
+	var info service.DeviceInfo
+
+	if params.XDeviceName != nil {
+		info.Name = *params.XDeviceName
+	}
+
+	if params.XDevicePlatform != nil {
+		info.Platform = *params.XDevicePlatform
+	}
+
+	if params.XClientVersion != nil {
+		info.ClientVersion = *params.XClientVersion
+	}
+
+	return info
+}
+
 func (api *API) resolveShareCode(ctx echo.Context, share service.ShareCode) (service.Account, error) {
 	// check that if the device code is present, it is actually for the account
 	account, err := api.Sharing.Shared(ctx.Request().Context(), share)
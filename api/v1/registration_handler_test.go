@@ -16,6 +16,7 @@ import (
 	v1 "github.com/jakobmoellerdev/octi-sync-server/api/v1"
 	"github.com/jakobmoellerdev/octi-sync-server/api/v1/REST"
 	"github.com/jakobmoellerdev/octi-sync-server/service"
+	"github.com/jakobmoellerdev/octi-sync-server/service/memory"
 	"github.com/jakobmoellerdev/octi-sync-server/service/mock"
 )
 
@@ -39,6 +40,7 @@ type RegisterTestSuite struct {
 	pass        string
 	deviceID    service.DeviceID
 	share       service.ShareCode
+	remoteIP    string
 
 	rec *httptest.ResponseRecorder
 	ctx echo.Context
@@ -51,6 +53,7 @@ func (r *RegisterTestSuite) SetupSuite() {
 	r.pass = "test-pass"
 	r.deviceID = service.DeviceID(uuid.Must(uuid.NewRandom()))
 	r.share = "test"
+	r.remoteIP = "192.0.2.1" // the remote address of every httptest request
 
 	r.router = echo.New()
 }
@@ -69,6 +72,7 @@ func (r *RegisterTestSuite) SetupTest() {
 		Sharing:           r.sharing,
 		UsernameGenerator: usernameGen,
 		PasswordGenerator: password.NewMockGenerator(r.pass, nil),
+		Events:            memory.NewEvents(),
 	}
 
 	req := emptyRequest(http.MethodGet)
@@ -127,7 +131,7 @@ func (r *RegisterTestSuite) Test_200_account_not_exists_provided_credentials() {
 	r.accounts.EXPECT().Find(r.ctx.Request().Context(), r.user).Times(1).
 		Return(nil, service.ErrAccountNotFound)
 	r.accounts.EXPECT().Create(r.ctx.Request().Context(), r.user).Times(1).Return(acc, nil)
-	r.devices.EXPECT().AddDevice(r.ctx.Request().Context(), acc, r.deviceID, r.pass).Times(1).
+	r.devices.EXPECT().AddDevice(r.ctx.Request().Context(), acc, r.deviceID, r.pass, service.DeviceInfo{RemoteIP: r.remoteIP}).Times(1).
 		Return(service.NewBaseDevice(r.deviceID, HashedPassword(r.pass)), nil)
 	r.ctx.Request().SetBasicAuth(r.user, r.pass)
 
@@ -166,7 +170,7 @@ func (r *RegisterTestSuite) Test_500_device_not_registered_share_code_ok_device_
 		Return(nil, service.ErrDeviceNotFound)
 	r.sharing.EXPECT().Shared(r.ctx.Request().Context(), r.share).Times(1).
 		Return(acc, nil)
-	r.devices.EXPECT().AddDevice(r.ctx.Request().Context(), acc, r.deviceID, r.pass).Times(1).
+	r.devices.EXPECT().AddDevice(r.ctx.Request().Context(), acc, r.deviceID, r.pass, service.DeviceInfo{RemoteIP: r.remoteIP}).Times(1).
 		Return(nil, errors.New(r.errMockText))
 
 	err := r.Register(
@@ -188,7 +192,7 @@ func (r *RegisterTestSuite) Test_500_device_not_registered_share_code_ok_device_
 		Return(nil, service.ErrDeviceNotFound)
 	r.sharing.EXPECT().Shared(r.ctx.Request().Context(), r.share).Times(1).
 		Return(acc, nil)
-	r.devices.EXPECT().AddDevice(r.ctx.Request().Context(), acc, r.deviceID, r.pass).Times(1).
+	r.devices.EXPECT().AddDevice(r.ctx.Request().Context(), acc, r.deviceID, r.pass, service.DeviceInfo{RemoteIP: r.remoteIP}).Times(1).
 		Return(service.NewBaseDevice(r.deviceID, HashedPassword(r.pass)), nil)
 	r.sharing.EXPECT().Revoke(r.ctx.Request().Context(), r.share).Times(1).
 		Return(errors.New(r.errMockText))
@@ -211,7 +215,7 @@ func (r *RegisterTestSuite) Test_200_device_not_registered_share_code_ok_device_
 		Return(acc, nil)
 	r.devices.EXPECT().GetDevice(r.ctx.Request().Context(), acc, r.deviceID).Times(1).
 		Return(nil, service.ErrDeviceNotFound)
-	r.devices.EXPECT().AddDevice(r.ctx.Request().Context(), acc, r.deviceID, r.pass).Times(1).
+	r.devices.EXPECT().AddDevice(r.ctx.Request().Context(), acc, r.deviceID, r.pass, service.DeviceInfo{RemoteIP: r.remoteIP}).Times(1).
 		Return(service.NewBaseDevice(r.deviceID, HashedPassword(r.pass)), nil)
 	r.sharing.EXPECT().Revoke(r.ctx.Request().Context(), r.share).Times(1).Return(nil)
 	err := r.Register(
@@ -232,7 +236,7 @@ func (r *RegisterTestSuite) Test_200_device_not_registered_share_code_ok_device_
 		Return(nil, service.ErrDeviceNotFound)
 	r.sharing.EXPECT().Shared(r.ctx.Request().Context(), r.share).Times(1).
 		Return(acc, nil)
-	r.devices.EXPECT().AddDevice(r.ctx.Request().Context(), acc, r.deviceID, newPass).Times(1).
+	r.devices.EXPECT().AddDevice(r.ctx.Request().Context(), acc, r.deviceID, newPass, service.DeviceInfo{RemoteIP: r.remoteIP}).Times(1).
 		Return(service.NewBaseDevice(r.deviceID, HashedPassword(newPass)), nil)
 	r.sharing.EXPECT().Revoke(r.ctx.Request().Context(), r.share).Times(1).
 		Return(nil)
@@ -247,3 +251,79 @@ func (r *RegisterTestSuite) Test_200_device_not_registered_share_code_ok_device_
 
 	r.NoError(err)
 }
+
+func (r *RegisterTestSuite) Test_200_account_not_exists_device_info_from_headers() {
+	acc := service.NewBaseAccount(r.user, time.Now())
+	name, platform, version := "laptop", "linux", "v1.2.3"
+
+	r.accounts.EXPECT().Find(r.ctx.Request().Context(), r.user).Times(1).
+		Return(nil, service.ErrAccountNotFound)
+	r.accounts.EXPECT().Create(r.ctx.Request().Context(), r.user).Times(1).Return(acc, nil)
+	r.devices.EXPECT().AddDevice(
+		r.ctx.Request().Context(), acc, r.deviceID, r.pass,
+		service.DeviceInfo{Name: name, Platform: platform, ClientVersion: version, RemoteIP: r.remoteIP},
+	).Times(1).Return(service.NewBaseDevice(r.deviceID, HashedPassword(r.pass)), nil)
+	r.ctx.Request().SetBasicAuth(r.user, r.pass)
+
+	err := r.Register(
+		REST.RegisterParams{
+			XDeviceID:       REST.XDeviceID(r.deviceID),
+			XDeviceName:     &name,
+			XDevicePlatform: &platform,
+			XClientVersion:  &version,
+		},
+	)
+
+	r.NoError(err)
+}
+
+func (r *RegisterTestSuite) Test_202_device_not_registered_share_code_ok_requires_approval() {
+	acc := service.NewBaseAccount(r.user, time.Now())
+	r.api.RequireDeviceApproval = true
+
+	r.sharing.EXPECT().Shared(r.ctx.Request().Context(), r.share).Times(1).
+		Return(acc, nil)
+	r.devices.EXPECT().GetDevice(r.ctx.Request().Context(), acc, r.deviceID).Times(1).
+		Return(nil, service.ErrDeviceNotFound)
+
+	info := service.DeviceInfo{RemoteIP: r.remoteIP, Status: service.DeviceStatusPending}
+	r.devices.EXPECT().AddDevice(r.ctx.Request().Context(), acc, r.deviceID, r.pass, info).Times(1).
+		Return(service.NewBaseDeviceWithInfo(
+			r.deviceID, HashedPassword(r.pass), info, time.Now(), time.Time{},
+		), nil)
+	r.sharing.EXPECT().Revoke(r.ctx.Request().Context(), r.share).Times(1).Return(nil)
+
+	err := r.Register(
+		REST.RegisterParams{
+			XDeviceID: REST.XDeviceID(r.deviceID),
+			Share:     (*REST.ShareCode)(&r.share),
+		},
+	)
+
+	r.NoError(err)
+	r.Equal(http.StatusAccepted, r.rec.Code)
+}
+
+func (r *RegisterTestSuite) Test_200_approved_device_share_code_ok_requires_approval_stays_approved() {
+	acc := service.NewBaseAccount(r.user, time.Now())
+	r.api.RequireDeviceApproval = true
+
+	r.sharing.EXPECT().Shared(r.ctx.Request().Context(), r.share).Times(1).
+		Return(acc, nil)
+	r.devices.EXPECT().GetDevice(r.ctx.Request().Context(), acc, r.deviceID).Times(1).
+		Return(service.NewBaseDevice(r.deviceID, HashedPassword(r.pass)), nil)
+	r.devices.EXPECT().AddDevice(
+		r.ctx.Request().Context(), acc, r.deviceID, r.pass, service.DeviceInfo{RemoteIP: r.remoteIP},
+	).Times(1).Return(service.NewBaseDevice(r.deviceID, HashedPassword(r.pass)), nil)
+	r.sharing.EXPECT().Revoke(r.ctx.Request().Context(), r.share).Times(1).Return(nil)
+
+	err := r.Register(
+		REST.RegisterParams{
+			XDeviceID: REST.XDeviceID(r.deviceID),
+			Share:     (*REST.ShareCode)(&r.share),
+		},
+	)
+
+	r.NoError(err)
+	r.Equal(http.StatusOK, r.rec.Code)
+}
@@ -0,0 +1,65 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	json "github.com/json-iterator/go"
+	"github.com/labstack/echo/v4"
+
+	"github.com/jakobmoellerdev/octi-sync-server/api/v1/REST"
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+func (api *API) GetSchemas(ctx echo.Context) error {
+	schemas, err := api.Schemas.List(ctx.Request().Context())
+	if err != nil {
+		return fmt.Errorf("could not list schemas: %w", err)
+	}
+
+	items := make([]REST.Schema, len(schemas))
+
+	for i, schema := range schemas {
+		items[i] = REST.Schema{Name: schema.Name, Pattern: schema.Pattern}
+		if err := json.Unmarshal(schema.Document, &items[i].Schema); err != nil {
+			return fmt.Errorf("could not parse schema %s: %w", schema.Name, err)
+		}
+	}
+
+	if err := ctx.JSON(http.StatusOK, &REST.SchemaList{Count: len(items), Items: items}); err != nil {
+		return fmt.Errorf("could not write schema list response: %w", err)
+	}
+
+	return nil
+}
+
+// validateModule rejects module data that does not validate against the schema registered for the module.
+func (api *API) validateModule(ctx context.Context, name string, data []byte) error {
+	err := api.Schemas.Validate(ctx, name, data)
+
+	var validationError *service.SchemaValidationError
+	if errors.As(err, &validationError) {
+		violations := make([]REST.SchemaViolation, len(validationError.Violations))
+		for i, violation := range validationError.Violations {
+			violations[i] = REST.SchemaViolation{
+				InstanceLocation: violation.InstanceLocation,
+				KeywordLocation:  violation.KeywordLocation,
+				Message:          violation.Message,
+			}
+		}
+
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, &REST.SchemaValidationFailed{
+			Message:    fmt.Sprintf("module %s does not validate against schema %s", name, validationError.Schema),
+			Schema:     validationError.Schema,
+			Violations: violations,
+		}).SetInternal(err)
+	}
+
+	if err != nil {
+		return fmt.Errorf("could not validate module: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package v1_test
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	json "github.com/json-iterator/go"
+	"github.com/labstack/echo/v4"
+
+	"github.com/jakobmoellerdev/octi-sync-server/api/v1/REST"
+	"github.com/jakobmoellerdev/octi-sync-server/service/memory"
+)
+
+func TestAPI_ModuleSchemas(t *testing.T) {
+	t.Parallel()
+	_, assertions, router := SetupAPITest(t)
+
+	dir := t.TempDir()
+	assertions.NoError(os.WriteFile(filepath.Join(dir, "definitions.json"), []byte(`{
+		"$defs": {"theme": {"enum": ["light", "dark"]}}
+	}`), 0o600))
+	assertions.NoError(os.WriteFile(filepath.Join(dir, "settings.json"), []byte(`{
+		"type": "object",
+		"properties": {"theme": {"$ref": "definitions.json#/$defs/theme"}, "size": {"type": "integer"}},
+		"required": ["theme"]
+	}`), 0o600))
+	assertions.NoError(os.WriteFile(filepath.Join(dir, "sms-*.json"), []byte(`{"type": "array"}`), 0o600))
+	assertions.NoError(os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a schema"), 0o600))
+
+	schemas, err := memory.LoadSchemas(dir)
+	assertions.NoError(err)
+
+	api := API()
+	api.Schemas = schemas
+
+	client := newTestDevice(t, api, router, "schemas")
+
+	assertions.NoError(client.write("settings", `{"theme": "dark", "size": 12}`))
+	assertions.NoError(client.write("sms-inbox", `[]`))
+	assertions.NoError(client.write("contacts", "not validated"))
+
+	err = client.write("settings", `{"theme": "blue", "size": "large"}`)
+	assertHTTPError(assertions, err, http.StatusUnprocessableEntity)
+
+	var httpError *echo.HTTPError
+	if assertions.ErrorAs(err, &httpError) {
+		if failed, isValidation := httpError.Message.(*REST.SchemaValidationFailed); assertions.True(isValidation) {
+			assertions.Equal("settings", failed.Schema)
+
+			locations := make([]string, 0, len(failed.Violations))
+			for _, violation := range failed.Violations {
+				locations = append(locations, violation.InstanceLocation)
+			}
+
+			assertions.ElementsMatch([]string{"/theme", "/size"}, locations)
+		}
+	}
+
+	assertHTTPError(assertions, client.write("settings", `{"theme": "dark"} trailing`), http.StatusUnprocessableEntity)
+	assertHTTPError(assertions, client.write("sms-outbox", `{}`), http.StatusUnprocessableEntity)
+
+	ctx, rec := client.request(http.MethodGet, "")
+	assertions.NoError(api.GetSchemas(ctx))
+	assertions.Equal(http.StatusOK, rec.Code)
+
+	var list REST.SchemaList
+
+	assertions.NoError(json.Unmarshal(rec.Body.Bytes(), &list))
+
+	if assertions.Equal(3, list.Count) {
+		assertions.Equal("definitions", list.Items[0].Name)
+		assertions.Equal("settings", list.Items[1].Pattern)
+		assertions.Equal("sms-*", list.Items[2].Pattern)
+		assertions.Equal("array", list.Items[2].Schema["type"])
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -16,7 +17,11 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	v1 "github.com/jakobmoellerdev/octi-sync-server/api/v1"
+	"github.com/jakobmoellerdev/octi-sync-server/api/v1/REST"
+	"github.com/jakobmoellerdev/octi-sync-server/config"
+	"github.com/jakobmoellerdev/octi-sync-server/middleware/basic"
 	"github.com/jakobmoellerdev/octi-sync-server/middleware/logging"
+	"github.com/jakobmoellerdev/octi-sync-server/service"
 	"github.com/jakobmoellerdev/octi-sync-server/service/memory"
 )
 
@@ -29,16 +34,35 @@ func SetupAPITest(t *testing.T) (zerolog.Logger, *assert.Assertions, *echo.Echo)
 	t.Helper()
 	logger := zerolog.New(zerolog.NewConsoleWriter(zerolog.ConsoleTestWriter(t)))
 	api := echo.New()
-	api.Use(logging.RequestLogging(&logger))
+	api.Use(logging.RequestLogging(&logger, config.AccessLogSettings{}), logging.ContextLogger(&logger))
 
 	return logger, assert.New(t), api
 }
 
 func API() *v1.API {
+	modules := memory.NewModules()
+
 	return &v1.API{
-		Accounts: memory.NewAccounts(),
-		Devices:  memory.NewDevices(),
-		Modules:  memory.NewModules(),
+		Accounts:   memory.NewAccounts(),
+		Devices:    memory.NewDevices(),
+		Modules:    modules,
+		Locker:     memory.NewLocker(),
+		History:    memory.NewHistory(modules, service.HistoryRetention{}),
+		ChangeFeed: memory.NewChangeFeed(0),
+		Events:     memory.NewEvents(),
+		Presence:   memory.NewPresence(),
+		Uploads:    memory.NewUploads(0),
+		Usage:      memory.NewUsage(),
+		Schemas:    memory.NewSchemas(),
+
+		MetadataProvider: memory.NewMetadataProvider(),
+	}
+}
+
+func assertHTTPError(assertions *assert.Assertions, err error, code int) {
+	var httpError *echo.HTTPError
+	if assertions.ErrorAs(err, &httpError) {
+		assertions.Equal(code, httpError.Code)
 	}
 }
 
@@ -65,6 +89,69 @@ func testAPI(
 	}
 }
 
+// testDevice sends requests of a device of an account to the API, as if the basic authentication accepted it.
+type testDevice struct {
+	api      *v1.API
+	router   *echo.Echo
+	deviceID uuid.UUID
+	account  service.Account
+	device   service.Device
+}
+
+func newTestDevice(t *testing.T, api *v1.API, router *echo.Echo, username string) *testDevice {
+	t.Helper()
+
+	deviceID := RandomUUID(t)
+
+	return &testDevice{
+		api:      api,
+		router:   router,
+		deviceID: deviceID,
+		account:  service.NewBaseAccount(username, time.Now()),
+		device:   service.NewBaseDevice(service.DeviceID(deviceID), ""),
+	}
+}
+
+// request builds the context of a request of the device, header holds pairs of header names and values.
+func (d *testDevice) request(method, body string, header ...string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(method, "/", strings.NewReader(body))
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+
+	rec := httptest.NewRecorder()
+	ctx := d.router.NewContext(req, rec)
+	d.authenticate(ctx)
+
+	return ctx, rec
+}
+
+// authenticate sets the account and device on the context, as the basic authentication does.
+func (d *testDevice) authenticate(ctx echo.Context) {
+	ctx.Set(basic.AccountKey, d.account)
+	ctx.Set(basic.Device, d.device)
+}
+
+// write writes the data to the module of the device.
+func (d *testDevice) write(name, data string, header ...string) error {
+	ctx, _ := d.request(http.MethodPost, data, header...)
+
+	return d.api.CreateModule(ctx, name, REST.CreateModuleParams{XDeviceID: d.deviceID})
+}
+
+// read reads the module of the device.
+func (d *testDevice) read(name string, header ...string) (*httptest.ResponseRecorder, error) {
+	ctx, rec := d.request(http.MethodGet, "", header...)
+	err := d.api.GetModule(ctx, name, REST.GetModuleParams{XDeviceID: d.deviceID})
+
+	return rec, err
+}
+
+// moduleID is the id the module of the device is stored under.
+func (d *testDevice) moduleID(name string) string {
+	return fmt.Sprintf("%s-%s-%s", d.account.Username(), d.deviceID, name)
+}
+
 func RandomUUID(t *testing.T) uuid.UUID {
 	t.Helper()
 
@@ -0,0 +1,67 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+const (
+	XDeletedAt = "X-Deleted-At"
+
+	// DefaultTombstoneRetention is the time deleted modules are reported as deleted if not configured otherwise.
+	DefaultTombstoneRetention = 30 * 24 * time.Hour
+)
+
+var (
+	ErrModuleDeleted  = errors.New("module was deleted")
+	ErrModuleNotFound = errors.New("module does not exist")
+)
+
+// deleted answers requests for a deleted module with the time it was deleted.
+func deleted(ctx echo.Context, tombstone service.Metadata) error {
+	ctx.Response().Header().Set(XDeletedAt, tombstone.GetDeletedAt().UTC().Format(http.TimeFormat))
+
+	return echo.NewHTTPError(http.StatusGone, ErrModuleDeleted.Error())
+}
+
+// deleteModule drops the data, history and usage of the module and leaves a tombstone in place of its metadata.
+// The data is dropped first, so that a failed delete never leaves a tombstone in front of existing data.
+func (api *API) deleteModule(
+	ctx context.Context, acc service.Account, id string, current service.Metadata, deletedAt time.Time,
+) error {
+	if err := api.Modules.Delete(ctx, id); err != nil {
+		return fmt.Errorf("error while deleting module: %w", err)
+	}
+
+	if err := api.leaveTombstone(ctx, id, current, deletedAt); err != nil {
+		return err
+	}
+
+	if err := api.History.Delete(ctx, service.MetadataID(id)); err != nil {
+		return fmt.Errorf("error while deleting module history: %w", err)
+	}
+
+	if err := api.Usage.Remove(ctx, acc, id); err != nil {
+		return fmt.Errorf("error while deleting module usage: %w", err)
+	}
+
+	return nil
+}
+
+// leaveTombstone replaces the metadata of the deleted module with a tombstone that expires after the retention.
+func (api *API) leaveTombstone(ctx context.Context, id string, current service.Metadata, deletedAt time.Time) error {
+	if err := api.MetadataProvider.Set(
+		ctx, service.NewTombstone(current, id, deletedAt, api.TombstoneRetention),
+	); err != nil {
+		return fmt.Errorf("could not leave module tombstone: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package v1_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v1 "github.com/jakobmoellerdev/octi-sync-server/api/v1"
+	"github.com/jakobmoellerdev/octi-sync-server/api/v1/REST"
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+func TestAPI_DeleteModule(t *testing.T) {
+	t.Parallel()
+	_, assertions, router := SetupAPITest(t)
+	api := API()
+	api.TombstoneRetention = time.Hour
+
+	client := newTestDevice(t, api, router, "tombstones")
+	deviceID := client.deviceID
+
+	remove := func(name string, ifMatch *string) (*httptest.ResponseRecorder, error) {
+		ctx, rec := client.request(http.MethodDelete, "")
+		err := api.DeleteModule(ctx, name, REST.DeleteModuleParams{XDeviceID: deviceID, IfMatch: ifMatch})
+
+		return rec, err
+	}
+
+	assertions.NoError(client.write("settings", "data"))
+
+	// deletes of another version of the module are rejected
+	stale := `"stale"`
+	_, err := remove("settings", &stale)
+	assertHTTPError(assertions, err, http.StatusPreconditionFailed)
+
+	rec, err := remove("settings", nil)
+	assertions.NoError(err)
+	assertions.Equal(http.StatusAccepted, rec.Code)
+
+	// deleted modules are told apart from modules that never existed
+	rec, err = client.read("settings")
+	assertHTTPError(assertions, err, http.StatusGone)
+	assertions.NotEmpty(rec.Header().Get(v1.XDeletedAt))
+
+	rec, err = client.read("missing")
+	assertions.NoError(err)
+	assertions.Equal(http.StatusNoContent, rec.Code)
+
+	ctx, rec := client.request(http.MethodHead, "")
+	assertHTTPError(assertions, api.HeadModule(ctx, "settings", REST.HeadModuleParams{XDeviceID: deviceID}),
+		http.StatusGone)
+	assertions.NotEmpty(rec.Header().Get(v1.XDeletedAt))
+
+	tombstone, err := api.MetadataProvider.Get(ctx.Request().Context(), service.MetadataID(client.moduleID("settings")))
+	assertions.NoError(err)
+	assertions.True(service.Deleted(tombstone))
+	assertions.WithinDuration(time.Now().Add(time.Hour), tombstone.GetExpiresAt(), 2*time.Second)
+
+	// deleting the module again neither changes the tombstone nor records another change
+	changes, err := api.ChangeFeed.Since(ctx.Request().Context(), client.account, "", 100)
+	assertions.NoError(err)
+
+	rec, err = remove("settings", nil)
+	assertHTTPError(assertions, err, http.StatusGone)
+	assertions.Equal(tombstone.GetDeletedAt().UTC().Format(http.TimeFormat), rec.Header().Get(v1.XDeletedAt))
+
+	again, err := api.MetadataProvider.Get(ctx.Request().Context(), service.MetadataID(client.moduleID("settings")))
+	assertions.NoError(err)
+	assertions.Equal(tombstone, again)
+
+	unchanged, err := api.ChangeFeed.Since(ctx.Request().Context(), client.account, "", 100)
+	assertions.NoError(err)
+	assertions.Len(unchanged, len(changes))
+
+	_, err = remove("missing", nil)
+	assertHTTPError(assertions, err, http.StatusNotFound)
+
+	// writing the module again replaces its tombstone
+	assertions.NoError(client.write("settings", "new data"))
+
+	rec, err = client.read("settings")
+	assertions.NoError(err)
+	assertions.Equal(http.StatusOK, rec.Code)
+	assertions.Equal("new data", rec.Body.String())
+	assertions.Empty(rec.Header().Get(v1.XDeletedAt))
+
+	// deleting all modules of the device leaves tombstones as well
+	ctx, _ = client.request(http.MethodDelete, "")
+	assertions.NoError(api.DeleteModules(ctx, REST.DeleteModulesParams{XDeviceID: deviceID}))
+
+	_, err = client.read("settings")
+	assertHTTPError(assertions, err, http.StatusGone)
+}
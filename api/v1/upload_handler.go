@@ -0,0 +1,289 @@
+package v1
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+
+	"github.com/jakobmoellerdev/octi-sync-server/api/v1/REST"
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+const (
+	UploadPath = "/uploads/:id"
+
+	HeaderUploadOffset = "Upload-Offset"
+
+	// MIMEApplicationOffsetOctetStream is the content type of upload chunks, like in the tus protocol.
+	MIMEApplicationOffsetOctetStream = "application/offset+octet-stream"
+
+	// DefaultMaxModuleSize limits modules written through uploads if not configured otherwise.
+	DefaultMaxModuleSize = "16MB"
+	// DefaultMaxUploadChunkSize limits the chunks of uploads if not configured otherwise.
+	DefaultMaxUploadChunkSize = "1MB"
+)
+
+var (
+	ErrUploadExceedsMaxModuleSize = errors.New("upload exceeds the maximum module size")
+	ErrUploadIncomplete           = errors.New("upload did not receive all announced data yet")
+	ErrUploadChecksumMismatch     = errors.New("upload data does not match its checksum")
+	ErrUploadChecksumUnsupported  = errors.New("upload checksum is not a base64 encoded sha256 digest")
+	ErrUploadOfOtherDevice        = errors.New("upload was started by another device")
+)
+
+// IsUploadChunk reports whether the request appends a chunk to an upload.
+// Chunks are limited by the maximum chunk size instead of the request body limit.
+func IsUploadChunk(ctx echo.Context) bool {
+	return ctx.Request().Method == http.MethodPatch && ctx.Path() == Prefix+UploadPath
+}
+
+func (api *API) CreateUpload(ctx echo.Context, name REST.ModuleName, params REST.CreateUploadParams) error {
+	acc, device, err := api.resolveDeviceIDAndAccount(ctx, &params.XDeviceID)
+	if err != nil {
+		return err
+	}
+
+	if err := validateModuleName(name); err != nil {
+		return err
+	}
+
+	if api.MaxModuleSize > 0 && params.UploadLength > api.MaxModuleSize {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, ErrUploadExceedsMaxModuleSize.Error())
+	}
+
+	// uploads that could never be finished due to the quota are rejected right away
+	id := fmt.Sprintf("%s-%s-%s", acc.Username(), device.ID(), name)
+
+	current, err := api.currentMetadata(ctx.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+
+	usage, err := api.accountUsage(ctx.Request().Context(), acc)
+	if err != nil {
+		return err
+	}
+
+	if _, err := api.checkModuleQuota(usage, current, params.UploadLength); err != nil {
+		return err
+	}
+
+	headers := contentHeadersOfUpload(params.UploadMetadata)
+
+	upload, err := api.Uploads.Create(ctx.Request().Context(), acc, service.Upload{
+		Module:      name,
+		Device:      device.ID(),
+		Length:      params.UploadLength,
+		ContentType: headers.contentType,
+		Filename:    headers.filename,
+	})
+	if err != nil {
+		return fmt.Errorf("could not create upload: %w", err)
+	}
+
+	ctx.Response().Header().Set(echo.HeaderLocation, fmt.Sprintf("%s/uploads/%s", Prefix, upload.ID))
+	ctx.Response().Header().Set(HeaderUploadOffset, strconv.FormatInt(upload.Offset, 10))
+
+	if err := ctx.JSON(http.StatusCreated, toRESTUpload(upload)); err != nil {
+		return fmt.Errorf("could not write upload response: %w", err)
+	}
+
+	return nil
+}
+
+func (api *API) GetUpload(ctx echo.Context, id REST.UploadIDPath, params REST.GetUploadParams) error {
+	_, _, upload, err := api.resolveUpload(ctx, id, params.XDeviceID)
+	if err != nil {
+		return err
+	}
+
+	ctx.Response().Header().Set(HeaderUploadOffset, strconv.FormatInt(upload.Offset, 10))
+
+	if err := ctx.JSON(http.StatusOK, toRESTUpload(upload)); err != nil {
+		return fmt.Errorf("could not write upload response: %w", err)
+	}
+
+	return nil
+}
+
+func (api *API) AppendUpload(ctx echo.Context, id REST.UploadIDPath, params REST.AppendUploadParams) error {
+	if contentType := ctx.Request().Header.Get(echo.HeaderContentType); !strings.HasPrefix(
+		contentType, MIMEApplicationOffsetOctetStream,
+	) {
+		return echo.NewHTTPError(http.StatusUnsupportedMediaType,
+			fmt.Sprintf("chunks have to be sent as %s", MIMEApplicationOffsetOctetStream))
+	}
+
+	acc, _, _, err := api.resolveUpload(ctx, id, params.XDeviceID)
+	if err != nil {
+		return err
+	}
+
+	chunk, err := io.ReadAll(ctx.Request().Body)
+	if err != nil {
+		var httpError *echo.HTTPError
+		if errors.As(err, &httpError) {
+			return httpError
+		}
+
+		return echo.NewHTTPError(http.StatusBadRequest, "could not read chunk").SetInternal(err)
+	}
+
+	upload, err := api.Uploads.Append(ctx.Request().Context(), acc, id, params.UploadOffset, chunk)
+
+	switch {
+	case errors.Is(err, service.ErrUploadNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, service.ErrUploadNotFound.Error()).SetInternal(err)
+	case errors.Is(err, service.ErrUploadOffsetMismatch):
+		ctx.Response().Header().Set(HeaderUploadOffset, strconv.FormatInt(upload.Offset, 10))
+
+		return echo.NewHTTPError(http.StatusConflict, service.ErrUploadOffsetMismatch.Error()).SetInternal(err)
+	case errors.Is(err, service.ErrUploadTooLarge):
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, service.ErrUploadTooLarge.Error()).SetInternal(err)
+	case err != nil:
+		return fmt.Errorf("could not append chunk to upload: %w", err)
+	}
+
+	ctx.Response().Header().Set(HeaderUploadOffset, strconv.FormatInt(upload.Offset, 10))
+
+	if err := ctx.NoContent(http.StatusNoContent); err != nil {
+		return fmt.Errorf("could not acknowledge chunk: %w", err)
+	}
+
+	return nil
+}
+
+func (api *API) DeleteUpload(ctx echo.Context, id REST.UploadIDPath, params REST.DeleteUploadParams) error {
+	acc, _, _, err := api.resolveUpload(ctx, id, params.XDeviceID)
+	if err != nil {
+		return err
+	}
+
+	if err := api.Uploads.Delete(ctx.Request().Context(), acc, id); err != nil {
+		return fmt.Errorf("could not delete upload: %w", err)
+	}
+
+	if err := ctx.NoContent(http.StatusNoContent); err != nil {
+		return fmt.Errorf("could not acknowledge upload deletion: %w", err)
+	}
+
+	return nil
+}
+
+func (api *API) FinalizeUpload(ctx echo.Context, id REST.UploadIDPath, params REST.FinalizeUploadParams) error {
+	acc, device, upload, err := api.resolveUpload(ctx, id, params.XDeviceID)
+	if err != nil {
+		return err
+	}
+
+	if !upload.Done() {
+		ctx.Response().Header().Set(HeaderUploadOffset, strconv.FormatInt(upload.Offset, 10))
+
+		return echo.NewHTTPError(http.StatusConflict, ErrUploadIncomplete.Error())
+	}
+
+	requestCtx := ctx.Request().Context()
+
+	// the staged data is streamed twice, once to verify it and once to write it, instead of holding it in memory
+	if params.UploadChecksum != nil {
+		module, err := api.Uploads.Data(requestCtx, acc, id)
+		if err != nil {
+			return fmt.Errorf("could not read upload data: %w", err)
+		}
+
+		if err := verifyUploadChecksum(*params.UploadChecksum, module.Raw()); err != nil {
+			return err
+		}
+	}
+
+	module, err := api.Uploads.Data(requestCtx, acc, id)
+	if err != nil {
+		return fmt.Errorf("could not read upload data: %w", err)
+	}
+
+	if _, err := api.writeModule(
+		ctx, acc, device, upload.Module, module.Raw(), module.Size(), writeMode{},
+		contentHeaders{contentType: upload.ContentType, filename: upload.Filename}, params.XExpiresIn,
+		params.IfMatch, params.IfUnmodifiedSince,
+	); err != nil {
+		return err
+	}
+
+	// the module was written, a left over upload expires on its own
+	if err := api.Uploads.Delete(requestCtx, acc, id); err != nil {
+		zerolog.Ctx(requestCtx).Warn().Err(err).Str("upload", id).Msg("could not delete finished upload")
+	}
+
+	if err := ctx.JSON(http.StatusAccepted, nil); err != nil {
+		return fmt.Errorf("could not acknowledge module creation: %w", err)
+	}
+
+	return nil
+}
+
+// resolveUpload returns the upload together with the account and device it belongs to.
+// Uploads can only be continued by the device that started them.
+func (api *API) resolveUpload(
+	ctx echo.Context, id string, deviceID REST.XDeviceID,
+) (service.Account, service.Device, service.Upload, error) {
+	acc, device, err := api.resolveDeviceIDAndAccount(ctx, &deviceID)
+	if err != nil {
+		return nil, nil, service.Upload{}, err
+	}
+
+	upload, err := api.Uploads.Get(ctx.Request().Context(), acc, id)
+	if errors.Is(err, service.ErrUploadNotFound) {
+		return nil, nil, service.Upload{}, echo.NewHTTPError(http.StatusNotFound, service.ErrUploadNotFound.Error()).
+			SetInternal(err)
+	}
+
+	if err != nil {
+		return nil, nil, service.Upload{}, fmt.Errorf("could not read upload: %w", err)
+	}
+
+	if upload.Device != device.ID() {
+		return nil, nil, service.Upload{}, echo.NewHTTPError(http.StatusForbidden, ErrUploadOfOtherDevice.Error())
+	}
+
+	return acc, device, upload, nil
+}
+
+// verifyUploadChecksum compares the data against a checksum in the format of the tus checksum extension.
+func verifyUploadChecksum(checksum string, data io.Reader) error {
+	algorithm, encoded, _ := strings.Cut(checksum, " ")
+
+	digest, err := base64.StdEncoding.DecodeString(encoded)
+	if !strings.EqualFold(algorithm, "sha256") || err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, ErrUploadChecksumUnsupported.Error())
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, data); err != nil {
+		return fmt.Errorf("could not read upload data: %w", err)
+	}
+
+	if !bytes.Equal(hash.Sum(nil), digest) {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, ErrUploadChecksumMismatch.Error())
+	}
+
+	return nil
+}
+
+func toRESTUpload(upload service.Upload) REST.Upload {
+	return REST.Upload{
+		Id:        upload.ID,
+		Name:      upload.Module,
+		Length:    upload.Length,
+		Offset:    upload.Offset,
+		ExpiresAt: upload.ExpiresAt,
+	}
+}
@@ -0,0 +1,115 @@
+package v1_test
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	json "github.com/json-iterator/go"
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/jakobmoellerdev/octi-sync-server/api/v1"
+	"github.com/jakobmoellerdev/octi-sync-server/api/v1/REST"
+)
+
+func TestAPI_Uploads(t *testing.T) {
+	t.Parallel()
+	_, assertions, router := SetupAPITest(t)
+	api := API()
+	api.MaxModuleSize = 64
+
+	client := newTestDevice(t, api, router, "uploads")
+	deviceID := client.deviceID
+	data := []byte("a module that is uploaded in multiple chunks")
+	hash := sha256.Sum256(data)
+	checksum := "sha256 " + base64.StdEncoding.EncodeToString(hash[:])
+	contentType := []string{echo.HeaderContentType, v1.MIMEApplicationOffsetOctetStream}
+
+	appendChunk := func(id string, offset int, chunk []byte) (*httptest.ResponseRecorder, error) {
+		ctx, rec := client.request(http.MethodPatch, string(chunk), contentType...)
+
+		return rec, api.AppendUpload(ctx, id, REST.AppendUploadParams{XDeviceID: deviceID, UploadOffset: int64(offset)})
+	}
+
+	// uploads are only staged for modules that can be written
+	ctx, _ := client.request(http.MethodPost, "", contentType...)
+	assertHTTPError(assertions, api.CreateUpload(ctx, "../large", REST.CreateUploadParams{
+		XDeviceID: deviceID, UploadLength: 1,
+	}), http.StatusBadRequest)
+
+	ctx, _ = client.request(http.MethodPost, "", contentType...)
+	assertHTTPError(assertions, api.CreateUpload(ctx, "large", REST.CreateUploadParams{
+		XDeviceID: deviceID, UploadLength: 65,
+	}), http.StatusRequestEntityTooLarge)
+
+	uploadMetadata := "filename " + base64.StdEncoding.EncodeToString([]byte("large.txt")) +
+		",filetype " + base64.StdEncoding.EncodeToString([]byte("text/plain"))
+
+	ctx, rec := client.request(http.MethodPost, "", contentType...)
+	assertions.NoError(api.CreateUpload(ctx, "large", REST.CreateUploadParams{
+		XDeviceID: deviceID, UploadLength: int64(len(data)), UploadMetadata: &uploadMetadata,
+	}))
+	assertions.Equal(http.StatusCreated, rec.Code)
+
+	var upload REST.Upload
+
+	assertions.NoError(json.Unmarshal(rec.Body.Bytes(), &upload))
+	assertions.Equal(v1.Prefix+"/uploads/"+upload.Id, rec.Header().Get(echo.HeaderLocation))
+	assertions.Equal(int64(len(data)), upload.Length)
+	assertions.Zero(upload.Offset)
+
+	rec, err := appendChunk(upload.Id, 0, data[:20])
+	assertions.NoError(err)
+	assertions.Equal(http.StatusNoContent, rec.Code)
+	assertions.Equal("20", rec.Header().Get(v1.HeaderUploadOffset))
+
+	// a retried chunk must not be applied twice
+	rec, err = appendChunk(upload.Id, 0, data[:20])
+	assertHTTPError(assertions, err, http.StatusConflict)
+	assertions.Equal("20", rec.Header().Get(v1.HeaderUploadOffset))
+
+	_, err = appendChunk(upload.Id, 20, append(data[20:], '!'))
+	assertHTTPError(assertions, err, http.StatusRequestEntityTooLarge)
+
+	ctx, _ = client.request(http.MethodPost, "", contentType...)
+	assertHTTPError(assertions, api.FinalizeUpload(ctx, upload.Id, REST.FinalizeUploadParams{XDeviceID: deviceID}),
+		http.StatusConflict)
+
+	_, err = appendChunk(upload.Id, 20, data[20:])
+	assertions.NoError(err)
+
+	ctx, rec = client.request(http.MethodGet, "", contentType...)
+	assertions.NoError(api.GetUpload(ctx, upload.Id, REST.GetUploadParams{XDeviceID: deviceID}))
+	assertions.Equal(strconv.Itoa(len(data)), rec.Header().Get(v1.HeaderUploadOffset))
+
+	wrongChecksum := "sha256 " + base64.StdEncoding.EncodeToString(make([]byte, sha256.Size))
+	ctx, _ = client.request(http.MethodPost, "", contentType...)
+	assertHTTPError(assertions, api.FinalizeUpload(ctx, upload.Id, REST.FinalizeUploadParams{
+		XDeviceID: deviceID, UploadChecksum: &wrongChecksum,
+	}), http.StatusUnprocessableEntity)
+
+	ctx, rec = client.request(http.MethodPost, "", contentType...)
+	assertions.NoError(api.FinalizeUpload(ctx, upload.Id, REST.FinalizeUploadParams{
+		XDeviceID: deviceID, UploadChecksum: &checksum,
+	}))
+	assertions.Equal(http.StatusAccepted, rec.Code)
+	assertions.NotEmpty(rec.Header().Get("ETag"))
+
+	ctx, rec = client.request(http.MethodGet, "", contentType...)
+	assertions.NoError(api.GetModule(ctx, "large", REST.GetModuleParams{XDeviceID: deviceID}))
+	assertions.Equal(data, rec.Body.Bytes())
+	assertions.Equal("text/plain", rec.Header().Get(echo.HeaderContentType))
+	assertions.Equal("inline; filename=large.txt", rec.Header().Get(echo.HeaderContentDisposition))
+
+	ctx, _ = client.request(http.MethodGet, "", contentType...)
+	assertHTTPError(assertions, api.GetUpload(ctx, upload.Id, REST.GetUploadParams{XDeviceID: deviceID}),
+		http.StatusNotFound)
+
+	ctx, _ = client.request(http.MethodPatch, "", contentType...)
+	ctx.Request().Header.Set(echo.HeaderContentType, echo.MIMEOctetStream)
+	assertHTTPError(assertions, api.AppendUpload(ctx, upload.Id, REST.AppendUploadParams{XDeviceID: deviceID}),
+		http.StatusUnsupportedMediaType)
+}
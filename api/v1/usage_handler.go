@@ -0,0 +1,143 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/jakobmoellerdev/octi-sync-server/api/v1/REST"
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+var ErrQuotaExceeded = errors.New("quota of the account exceeded")
+
+func (api *API) GetAccountUsage(ctx echo.Context, params REST.GetAccountUsageParams) error {
+	acc, _, err := api.resolveDeviceIDAndAccount(ctx, &params.XDeviceID)
+	if err != nil {
+		return err
+	}
+
+	usage, err := api.accountUsage(ctx.Request().Context(), acc)
+	if err != nil {
+		return err
+	}
+
+	if usage.Devices, err = api.deviceCount(ctx.Request().Context(), acc); err != nil {
+		return err
+	}
+
+	if err := ctx.JSON(http.StatusOK, &REST.AccountUsage{
+		Bytes:   usage.Bytes,
+		Modules: usage.Modules,
+		Devices: usage.Devices,
+		Quota: REST.Quota{
+			Bytes:   optionalLimit(api.Quota.Bytes),
+			Modules: optionalLimit(api.Quota.Modules),
+			Devices: optionalLimit(api.Quota.Devices),
+		},
+	}); err != nil {
+		return fmt.Errorf("could not write account usage response: %w", err)
+	}
+
+	return nil
+}
+
+func (api *API) accountUsage(ctx context.Context, acc service.Account) (service.AccountUsage, error) {
+	usage, err := api.Usage.Get(ctx, acc)
+	if err != nil {
+		return service.AccountUsage{}, fmt.Errorf("could not read account usage: %w", err)
+	}
+
+	return usage, nil
+}
+
+func (api *API) deviceCount(ctx context.Context, acc service.Account) (int64, error) {
+	devices, err := api.Devices.GetDevices(ctx, acc)
+	if err != nil {
+		return 0, fmt.Errorf("could not fetch devices from account: %w", err)
+	}
+
+	return int64(len(devices)), nil
+}
+
+// checkModuleQuota verifies that replacing the current version of a module with size bytes
+// keeps the account within its quota and returns the usage after the write.
+// Quotas are checked before writing, so concurrent writes of different modules might exceed them slightly.
+func (api *API) checkModuleQuota(
+	usage service.AccountUsage, current service.Metadata, size int64,
+) (service.AccountUsage, error) {
+	after := usage
+	after.Bytes += size
+
+	if current != nil {
+		after.Bytes -= current.GetSize()
+	} else {
+		after.Modules++
+	}
+
+	if api.Quota.Bytes > 0 && size > api.Quota.Bytes {
+		return usage, quotaExceeded(http.StatusRequestEntityTooLarge, REST.Bytes, api.Quota.Bytes, usage.Bytes, size)
+	}
+
+	if api.Quota.Bytes > 0 && after.Bytes > usage.Bytes && after.Bytes > api.Quota.Bytes {
+		return usage, quotaExceeded(http.StatusInsufficientStorage, REST.Bytes, api.Quota.Bytes, usage.Bytes, size)
+	}
+
+	if api.Quota.Modules > 0 && after.Modules > usage.Modules && after.Modules > api.Quota.Modules {
+		return usage, quotaExceeded(http.StatusInsufficientStorage, REST.Modules, api.Quota.Modules, usage.Modules, 1)
+	}
+
+	return after, nil
+}
+
+// checkDeviceQuota verifies that another device can be added to the account.
+func (api *API) checkDeviceQuota(ctx context.Context, acc service.Account) error {
+	if api.Quota.Devices <= 0 {
+		return nil
+	}
+
+	devices, err := api.deviceCount(ctx, acc)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError).SetInternal(err)
+	}
+
+	if devices+1 > api.Quota.Devices {
+		return quotaExceeded(http.StatusInsufficientStorage, REST.Devices, api.Quota.Devices, devices, 1)
+	}
+
+	return nil
+}
+
+// quotaExceeded rejects a write with a body describing the exceeded quota.
+func quotaExceeded(status int, resource REST.QuotaResource, limit, usage, requested int64) error {
+	return echo.NewHTTPError(status, &REST.QuotaExceeded{
+		Message:   fmt.Sprintf("%s quota of %d would be exceeded", resource, limit),
+		Resource:  resource,
+		Limit:     limit,
+		Usage:     usage,
+		Requested: requested,
+	}).SetInternal(fmt.Errorf("%w: %s", ErrQuotaExceeded, resource))
+}
+
+func optionalLimit(limit int64) *int64 {
+	if limit <= 0 {
+		return nil
+	}
+
+	return &limit
+}
+
+// httpErrorMessage returns the message of an error that is reported to clients outside an error response.
+func httpErrorMessage(httpError *echo.HTTPError) string {
+	switch message := httpError.Message.(type) {
+	case *REST.QuotaExceeded:
+		return message.Message
+	case *REST.SchemaValidationFailed:
+		return message.Message
+	}
+
+	return fmt.Sprint(httpError.Message)
+}
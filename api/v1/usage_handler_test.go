@@ -0,0 +1,77 @@
+package v1_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	json "github.com/json-iterator/go"
+	"github.com/labstack/echo/v4"
+
+	"github.com/jakobmoellerdev/octi-sync-server/api/v1/REST"
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+func TestAPI_AccountUsageAndQuota(t *testing.T) {
+	t.Parallel()
+	_, assertions, router := SetupAPITest(t)
+	api := API()
+	api.Quota = service.Quota{Bytes: 10, Modules: 2}
+
+	client := newTestDevice(t, api, router, "quota")
+	deviceID := client.deviceID
+
+	_, err := api.Devices.AddDevice(context.Background(), client.account, client.device.ID(), "", service.DeviceInfo{})
+	assertions.NoError(err)
+	usage := func() REST.AccountUsage {
+		ctx, rec := client.request(http.MethodGet, "")
+		assertions.NoError(api.GetAccountUsage(ctx, REST.GetAccountUsageParams{XDeviceID: deviceID}))
+
+		var usage REST.AccountUsage
+
+		assertions.NoError(json.Unmarshal(rec.Body.Bytes(), &usage))
+
+		return usage
+	}
+
+	err = client.write("large", "more than ten bytes")
+	assertHTTPError(assertions, err, http.StatusRequestEntityTooLarge)
+
+	var httpError *echo.HTTPError
+	if assertions.ErrorAs(err, &httpError) {
+		if exceeded, isQuota := httpError.Message.(*REST.QuotaExceeded); assertions.True(isQuota) {
+			assertions.Equal(REST.Bytes, exceeded.Resource)
+			assertions.Equal(int64(10), exceeded.Limit)
+			assertions.Equal(int64(19), exceeded.Requested)
+		}
+	}
+
+	assertions.NoError(client.write("settings", "123456"))
+	assertHTTPError(assertions, client.write("sms", "12345"), http.StatusInsufficientStorage)
+
+	// replacing a module only counts the difference in size
+	assertions.NoError(client.write("settings", "1234567890"))
+	assertHTTPError(assertions, client.write("sms", "1"), http.StatusInsufficientStorage)
+	assertions.NoError(client.write("settings", "12345"))
+	assertions.NoError(client.write("sms", "12345"))
+	assertHTTPError(assertions, client.write("contacts", ""), http.StatusInsufficientStorage)
+
+	current := usage()
+	assertions.Equal(int64(10), current.Bytes)
+	assertions.Equal(int64(2), current.Modules)
+	assertions.Equal(int64(1), current.Devices)
+
+	if assertions.NotNil(current.Quota.Bytes) && assertions.NotNil(current.Quota.Modules) {
+		assertions.Equal(int64(10), *current.Quota.Bytes)
+		assertions.Equal(int64(2), *current.Quota.Modules)
+	}
+
+	assertions.Nil(current.Quota.Devices)
+
+	ctx, _ := client.request(http.MethodDelete, "")
+	assertions.NoError(api.DeleteModules(ctx, REST.DeleteModulesParams{XDeviceID: deviceID}))
+
+	current = usage()
+	assertions.Zero(current.Bytes)
+	assertions.Zero(current.Modules)
+}
@@ -0,0 +1,374 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+
+	"github.com/jakobmoellerdev/octi-sync-server/api/v1/REST"
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+const (
+	WebSocketPath = "/ws"
+
+	// WebSocketHeartbeatInterval is the time between two heartbeats of the server.
+	// Clients that did not send any message for two intervals are disconnected.
+	WebSocketHeartbeatInterval = 30 * time.Second
+	// WebSocketWriteTimeout bounds writing a single message to a client.
+	WebSocketWriteTimeout = 10 * time.Second
+	// WebSocketSendBuffer is the amount of messages queued for a client before it is disconnected as too slow.
+	WebSocketSendBuffer = 32
+
+	// webSocketMessageOverhead is allowed on top of the encoded module data in a single message.
+	webSocketMessageOverhead = 4 << 10
+)
+
+var (
+	ErrWebSocketClientTooSlow          = errors.New("client does not keep up with its messages")
+	ErrWebSocketModuleMissing          = errors.New("module is required")
+	ErrWebSocketUnsupportedMessageType = errors.New("unsupported message type")
+)
+
+// webSocketConnection serves the sync channel of a single device. Messages are read and answered one after another,
+// all writes go through send so that only the writer touches the connection.
+type webSocketConnection struct {
+	api     *API
+	ctx     echo.Context
+	conn    *websocket.Conn
+	account service.Account
+	device  service.Device
+	id      string
+
+	send       chan REST.WebSocketMessage
+	cancel     context.CancelCauseFunc
+	subscribed bool
+}
+
+func (api *API) ConnectWebSocket(ctx echo.Context, params REST.ConnectWebSocketParams) error {
+	acc, device, err := api.resolveDeviceIDAndAccount(ctx, &params.XDeviceID)
+	if err != nil {
+		return err
+	}
+
+	upgrader := websocket.Upgrader{CheckOrigin: api.allowedOrigin}
+
+	conn, err := upgrader.Upgrade(ctx.Response(), ctx.Request(), nil)
+	if err != nil {
+		// the upgrader already answered the request
+		zerolog.Ctx(ctx.Request().Context()).Debug().Err(err).Msg("could not upgrade to websocket")
+
+		return nil
+	}
+
+	if api.MaxModuleSize > 0 {
+		conn.SetReadLimit(int64(base64.StdEncoding.EncodedLen(int(api.MaxModuleSize))) + webSocketMessageOverhead)
+	}
+
+	connCtx, cancel := context.WithCancelCause(ctx.Request().Context())
+	defer cancel(nil)
+
+	connection := &webSocketConnection{
+		api:     api,
+		ctx:     ctx,
+		conn:    conn,
+		account: acc,
+		device:  device,
+		id:      uuid.NewString(),
+		send:    make(chan REST.WebSocketMessage, WebSocketSendBuffer),
+		cancel:  cancel,
+	}
+
+	connection.online(connCtx)
+
+	written := make(chan struct{})
+
+	go func() {
+		defer close(written)
+		connection.write(connCtx)
+	}()
+
+	cancel(connection.read(connCtx))
+	<-written
+
+	return nil
+}
+
+// allowedOrigin accepts clients without origin, like the native clients, and browsers from the CORS origins.
+func (api *API) allowedOrigin(req *http.Request) bool {
+	origin := req.Header.Get(echo.HeaderOrigin)
+	if origin == "" {
+		return true
+	}
+
+	for _, allowed := range api.AllowedOrigins {
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+	}
+
+	originURL, err := url.Parse(origin)
+
+	return err == nil && originURL.Host == req.Host
+}
+
+// read handles messages of the client until the connection fails or is closed.
+func (c *webSocketConnection) read(ctx context.Context) error {
+	for {
+		if err := c.conn.SetReadDeadline(time.Now().Add(2 * WebSocketHeartbeatInterval)); err != nil {
+			return fmt.Errorf("could not extend websocket read deadline: %w", err)
+		}
+
+		var message REST.WebSocketMessage
+		if err := c.conn.ReadJSON(&message); err != nil {
+			return fmt.Errorf("could not read websocket message: %w", err)
+		}
+
+		switch message.Type {
+		case REST.WebSocketMessageTypeHeartbeat, REST.WebSocketMessageTypeAck:
+			// receiving them already extended the read deadline
+		case REST.WebSocketMessageTypeSubscribe:
+			c.enqueue(c.subscribe(ctx, message))
+		case REST.WebSocketMessageTypePut:
+			c.enqueue(c.put(message))
+		case REST.WebSocketMessageTypeGet:
+			c.enqueue(c.get(message))
+		default:
+			c.enqueue(c.failed(message, ErrWebSocketUnsupportedMessageType))
+		}
+	}
+}
+
+// write sends queued messages and heartbeats until the connection is done and closes it afterwards.
+func (c *webSocketConnection) write(ctx context.Context) {
+	heartbeat := time.NewTicker(WebSocketHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	defer c.offline(ctx)
+	defer c.conn.Close()
+
+	for {
+		var message REST.WebSocketMessage
+
+		select {
+		case <-ctx.Done():
+			c.close(ctx)
+
+			return
+		case <-heartbeat.C:
+			c.online(ctx)
+
+			message = REST.WebSocketMessage{Type: REST.WebSocketMessageTypeHeartbeat}
+		case message = <-c.send:
+		}
+
+		if err := c.conn.SetWriteDeadline(time.Now().Add(WebSocketWriteTimeout)); err != nil {
+			c.cancel(err)
+
+			return
+		}
+
+		if err := c.conn.WriteJSON(message); err != nil {
+			c.cancel(err)
+
+			return
+		}
+	}
+}
+
+// enqueue hands the message to the writer, clients that let the queue run full are disconnected
+// instead of blocking the server.
+func (c *webSocketConnection) enqueue(message REST.WebSocketMessage) {
+	select {
+	case c.send <- message:
+	default:
+		c.cancel(ErrWebSocketClientTooSlow)
+	}
+}
+
+func (c *webSocketConnection) close(ctx context.Context) {
+	code, reason := websocket.CloseNormalClosure, ""
+	if errors.Is(context.Cause(ctx), ErrWebSocketClientTooSlow) {
+		code, reason = websocket.CloseTryAgainLater, ErrWebSocketClientTooSlow.Error()
+	}
+
+	// the client might already be gone, so failing to say goodbye is fine
+	_ = c.conn.WriteControl(
+		websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(WebSocketWriteTimeout),
+	)
+}
+
+// online keeps the device online for as long as the client could still send a message in time.
+func (c *webSocketConnection) online(ctx context.Context) {
+	until := time.Now().Add(2 * WebSocketHeartbeatInterval)
+	if err := c.api.Presence.Connected(ctx, c.account, c.device.ID(), c.id, until); err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Msg("could not mark device as online")
+	}
+}
+
+func (c *webSocketConnection) offline(ctx context.Context) {
+	ctx = context.WithoutCancel(ctx)
+	if err := c.api.Presence.Disconnected(ctx, c.account, c.device.ID(), c.id); err != nil {
+		zerolog.Ctx(ctx).Warn().Err(err).Msg("could not mark device as offline")
+	}
+}
+
+func (c *webSocketConnection) subscribe(ctx context.Context, message REST.WebSocketMessage) REST.WebSocketMessage {
+	if c.subscribed {
+		return c.ack(message, http.StatusOK)
+	}
+
+	events, err := c.api.Events.Subscribe(ctx, c.account)
+	if err != nil {
+		return c.failed(message, fmt.Errorf("could not subscribe to account events: %w", err))
+	}
+
+	c.subscribed = true
+
+	go func() {
+		for event := range events {
+			restEvent := toRESTEvent(event)
+			c.enqueue(REST.WebSocketMessage{Type: REST.WebSocketMessageTypeEvent, Event: &restEvent})
+		}
+	}()
+
+	return c.ack(message, http.StatusOK)
+}
+
+func (c *webSocketConnection) put(message REST.WebSocketMessage) REST.WebSocketMessage {
+	if message.Module == nil || *message.Module == "" {
+		return c.failed(message, echo.NewHTTPError(http.StatusBadRequest, ErrWebSocketModuleMissing.Error()))
+	}
+
+	var data []byte
+	if message.Data != nil {
+		data = *message.Data
+	}
+
+	// websocket messages carry the data as is, so any content encoding is kept
+	headers := contentHeaders{}
+	if message.ContentType != nil {
+		headers.contentType = normalizeContentType(*message.ContentType)
+	}
+
+	if message.ContentEncoding != nil {
+		headers.contentEncoding = normalizeContentEncoding(*message.ContentEncoding)
+	}
+
+	metadata, err := c.api.writeModule(
+		c.ctx, c.account, c.device, *message.Module, bytes.NewReader(data), len(data), writeMode{}, headers,
+		message.ExpiresIn, message.IfMatch, nil,
+	)
+	if err != nil {
+		return c.failed(message, err)
+	}
+
+	ack := c.ack(message, http.StatusAccepted)
+	ack.Etag = optionalString(entityTag(metadata))
+
+	return ack
+}
+
+func (c *webSocketConnection) get(message REST.WebSocketMessage) REST.WebSocketMessage {
+	if message.Module == nil || *message.Module == "" {
+		return c.failed(message, echo.NewHTTPError(http.StatusBadRequest, ErrWebSocketModuleMissing.Error()))
+	}
+
+	if err := validateModuleName(*message.Module); err != nil {
+		return c.failed(message, err)
+	}
+
+	requestCtx := c.ctx.Request().Context()
+	device := c.device.ID()
+
+	if message.Device != nil && *message.Device != device.UUID() {
+		other, err := c.api.GetDevice(requestCtx, c.account, service.DeviceID(*message.Device))
+		if err != nil {
+			return c.failed(message, echo.NewHTTPError(http.StatusForbidden,
+				fmt.Errorf("device could not be verified against account: %w", err).Error()))
+		}
+
+		device = other.ID()
+	}
+
+	id := fmt.Sprintf("%s-%s-%s", c.account.Username(), device, *message.Module)
+
+	metadata, err := c.api.storedMetadata(requestCtx, id)
+	if err != nil {
+		return c.failed(message, err)
+	}
+
+	if service.Deleted(metadata) {
+		return c.failed(message, echo.NewHTTPError(http.StatusGone, ErrModuleDeleted.Error()))
+	}
+
+	module, err := c.api.Modules.Get(requestCtx, id)
+	if err != nil {
+		return c.failed(message, fmt.Errorf("error while fetching module: %w", err))
+	}
+
+	if module.Size() == 0 {
+		return c.ack(message, http.StatusNoContent)
+	}
+
+	if module, err = c.api.decodeModule(requestCtx, c.account, module, metadata); err != nil {
+		return c.failed(message, err)
+	}
+
+	data, err := io.ReadAll(module.Raw())
+	if err != nil {
+		return c.failed(message, fmt.Errorf("error while reading module data: %w", err))
+	}
+
+	ack := c.ack(message, http.StatusOK)
+	ack.Data = &data
+	ack.ContentType = optionalString(contentTypeOf(metadata))
+
+	if metadata != nil {
+		ack.Etag = optionalString(entityTag(metadata))
+		ack.ContentEncoding = optionalString(metadata.GetContentEncoding())
+	}
+
+	return ack
+}
+
+func (c *webSocketConnection) ack(message REST.WebSocketMessage, status int) REST.WebSocketMessage {
+	return REST.WebSocketMessage{
+		Type:   REST.WebSocketMessageTypeAck,
+		Id:     message.Id,
+		Module: message.Module,
+		Status: &status,
+	}
+}
+
+// failed acknowledges the message with the status the error would have caused on the HTTP endpoints.
+func (c *webSocketConnection) failed(message REST.WebSocketMessage, err error) REST.WebSocketMessage {
+	status, reason := errorStatus(err)
+
+	var httpError *echo.HTTPError
+
+	switch {
+	case errors.Is(err, ErrWebSocketUnsupportedMessageType):
+		status, reason = http.StatusBadRequest, err.Error()
+	case !errors.As(err, &httpError):
+		// errors without a status are unexpected and only logged here
+		zerolog.Ctx(c.ctx.Request().Context()).Error().Err(err).
+			Str("type", string(message.Type)).Msg("websocket request failed")
+	}
+
+	ack := c.ack(message, status)
+	ack.Message = &reason
+
+	return ack
+}
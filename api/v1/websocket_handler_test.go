@@ -0,0 +1,117 @@
+package v1_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+
+	v1 "github.com/jakobmoellerdev/octi-sync-server/api/v1"
+	"github.com/jakobmoellerdev/octi-sync-server/api/v1/REST"
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+func TestAPI_ConnectWebSocket(t *testing.T) {
+	t.Parallel()
+	_, assertions, router := SetupAPITest(t)
+	api := API()
+	client := newTestDevice(t, api, router, "websocket")
+	deviceID, account := client.deviceID, client.account
+
+	router.GET(v1.Prefix+v1.WebSocketPath, func(ctx echo.Context) error {
+		client.authenticate(ctx)
+
+		return api.ConnectWebSocket(ctx, REST.ConnectWebSocketParams{XDeviceID: deviceID})
+	})
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + v1.Prefix + v1.WebSocketPath
+
+	_, res, err := websocket.DefaultDialer.Dial(url, http.Header{echo.HeaderOrigin: {"https://elsewhere.example"}})
+	assertions.ErrorIs(err, websocket.ErrBadHandshake)
+
+	if res != nil {
+		assertions.Equal(http.StatusForbidden, res.StatusCode)
+		res.Body.Close()
+	}
+
+	conn, res, err := websocket.DefaultDialer.Dial(url, nil)
+	if !assertions.NoError(err) {
+		return
+	}
+	defer res.Body.Close()
+
+	online, err := api.Presence.Online(context.Background(), account)
+	assertions.NoError(err)
+	assertions.True(online[service.DeviceID(deviceID)])
+
+	request := func(message REST.WebSocketMessage) REST.WebSocketMessage {
+		assertions.NoError(conn.WriteJSON(message))
+
+		var response REST.WebSocketMessage
+		assertions.NoError(conn.ReadJSON(&response))
+
+		return response
+	}
+	id := func(id string) *string { return &id }
+	module := "settings"
+	data := []byte("data")
+
+	ack := request(REST.WebSocketMessage{Type: REST.WebSocketMessageTypeSubscribe, Id: id("1")})
+	assertions.Equal(REST.WebSocketMessageTypeAck, ack.Type)
+	assertions.Equal("1", *ack.Id)
+	assertions.Equal(http.StatusOK, *ack.Status)
+
+	ack = request(REST.WebSocketMessage{Type: REST.WebSocketMessageTypePut, Id: id("2"), Module: &module, Data: &data})
+	assertions.Equal(http.StatusAccepted, *ack.Status)
+	assertions.NotNil(ack.Etag)
+
+	var event REST.WebSocketMessage
+	assertions.NoError(conn.ReadJSON(&event))
+
+	if assertions.Equal(REST.WebSocketMessageTypeEvent, event.Type) && assertions.NotNil(event.Event) {
+		assertions.Equal(REST.ModuleChanged, event.Event.Type)
+		assertions.Equal(module, *event.Event.Module)
+	}
+
+	ack = request(REST.WebSocketMessage{Type: REST.WebSocketMessageTypeGet, Id: id("3"), Module: &module})
+	assertions.Equal(http.StatusOK, *ack.Status)
+	assertions.Equal(data, *ack.Data)
+
+	stale := `"stale"`
+	ack = request(REST.WebSocketMessage{
+		Type: REST.WebSocketMessageTypePut, Id: id("4"), Module: &module, Data: &data, IfMatch: &stale,
+	})
+	assertions.Equal(http.StatusPreconditionFailed, *ack.Status)
+
+	ack = request(REST.WebSocketMessage{Type: REST.WebSocketMessageTypeGet, Id: id("5")})
+	assertions.Equal(http.StatusBadRequest, *ack.Status)
+
+	invalid := "../settings"
+	ack = request(REST.WebSocketMessage{Type: REST.WebSocketMessageTypeGet, Id: id("5"), Module: &invalid})
+	assertions.Equal(http.StatusBadRequest, *ack.Status)
+
+	ack = request(REST.WebSocketMessage{Type: "unknown", Id: id("6")})
+	assertions.Equal(http.StatusBadRequest, *ack.Status)
+
+	assertions.NoError(conn.WriteMessage(
+		websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+	))
+
+	_, _, err = conn.ReadMessage()
+	assertions.True(websocket.IsCloseError(err, websocket.CloseNormalClosure))
+	conn.Close()
+
+	assertions.Eventually(func() bool {
+		online, err := api.Presence.Online(context.Background(), account)
+
+		return err == nil && !online[service.DeviceID(deviceID)]
+	}, time.Second, 10*time.Millisecond)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/sethvargo/go-password/password"
 	"gopkg.in/yaml.v3"
 
+	"github.com/jakobmoellerdev/octi-sync-server/metrics"
 	"github.com/jakobmoellerdev/octi-sync-server/service"
 )
 
@@ -29,6 +30,46 @@ const (
 
 type LogSettings struct {
 	Format LogSettingsFormat `yaml:"format"`
+
+	Access AccessLogSettings `yaml:"access"`
+}
+
+type AccessLogSettings struct {
+	// Level of the access log entries, defaults to debug.
+	// Requests that fail with a server error are always logged at error level.
+	Level string `yaml:"level"`
+
+	// SampleRate only logs every n-th request that did not fail with a server error,
+	// values of 0 and 1 log every request
+	SampleRate uint32 `yaml:"sampleRate"`
+
+	// Redact lists access log fields whose values are replaced before they are written, e.g. remote-ip
+	Redact []string `yaml:"redact"`
+}
+
+type TracingExporter string
+
+//goland:noinspection ALL
+const (
+	TracingExporterOTLP   TracingExporter = "otlp"
+	TracingExporterStdout TracingExporter = "stdout"
+	TracingExporterNone   TracingExporter = ""
+)
+
+type TracingSettings struct {
+	// Exporter decides where spans are sent to, tracing is disabled if no exporter is configured
+	Exporter TracingExporter `yaml:"exporter"`
+
+	// Endpoint is the host and port of the OTLP HTTP collector,
+	// if empty the OTEL_EXPORTER_OTLP_ENDPOINT environment or localhost:4318 is used
+	Endpoint string `yaml:"endpoint"`
+
+	// Insecure disables TLS when connecting to the OTLP HTTP collector
+	Insecure bool `yaml:"insecure"`
+
+	// SampleRatio is the fraction of traces that are sampled if no parent decided about sampling,
+	// values of 0 or less sample everything
+	SampleRatio float64 `yaml:"sampleRatio"`
 }
 
 // Config struct for webapp config.
@@ -81,13 +122,94 @@ type Config struct {
 		} `yaml:"ping"`
 
 		Module struct {
+			// Expiration is the time the history of modules is kept after their last write,
+			// and the time modules are kept if modules.expiry.default is not set
 			Expiration time.Duration `yaml:"expiration"`
+
+			// GarbageCollectionInterval is the time between two runs dropping blobs no module references anymore,
+			// defaults to 1h
+			GarbageCollectionInterval time.Duration `yaml:"garbageCollectionInterval"`
+
+			// ChunkSize bounds the module data held in memory per read or write while streaming it, defaults to 256KB
+			ChunkSize string `yaml:"chunkSize"`
 		}
 	} `yaml:"redis"`
 
+	Registration struct {
+		// RequireApproval makes devices joining an account through a share code pending
+		// until an already registered device of the account approves them
+		RequireApproval bool `yaml:"requireApproval"`
+	} `yaml:"registration"`
+
+	// History decides how many versions of each module are kept to restore them later
+	History service.HistoryRetention `yaml:"history"`
+
+	Modules struct {
+		// MaxSize limits the data of a single module written through uploads or websockets, defaults to 16MB.
+		// Modules written in a single request are limited by the request body size as well.
+		MaxSize string `yaml:"maxSize"`
+
+		// Schemas is a directory of JSON Schemas that modules are validated against, e.g. schemas/settings.json
+		// validates the module settings and schemas/sms-*.json all modules prefixed sms-.
+		// Modules are not validated if empty.
+		Schemas string `yaml:"schemas"`
+
+		// Compression is the codec module data is compressed with at rest, zstd, gzip or empty to store it as is.
+		// Modules keep the codec they were written with, so it can be changed at any time.
+		Compression string `yaml:"compression"`
+
+		// Expiry decides how long modules are kept after their last write. Clients may request an expiry per write,
+		// which is capped by its max. The default falls back to redis.module.expiration if 0.
+		Expiry service.ExpiryPolicy `yaml:"expiry"`
+
+		// TombstoneRetention is the time the metadata of deleted modules is kept as tombstone, so that other
+		// devices can tell deleted modules apart from modules that never existed, defaults to 30 days.
+		TombstoneRetention time.Duration `yaml:"tombstoneRetention"`
+	} `yaml:"modules"`
+
+	// Encryption encrypts module data at rest with a data key per account, which is wrapped by a master key.
+	// Modules are stored unencrypted if no master keys are configured.
+	Encryption struct {
+		// KeyFile contains the master keys as id:base64-key, one per line. The first key is the active one,
+		// master keys are rotated by adding a new key in front and removing the old one once data keys were rewrapped.
+		KeyFile string `yaml:"keyFile"`
+		// KeyEnv is the environment variable the master keys are read from if no KeyFile is set,
+		// separated by commas, defaults to OCTI_MASTER_KEYS
+		KeyEnv string `yaml:"keyEnv"`
+		// RewrapInterval is the interval data keys are rewrapped with the active master key in, defaults to 1h
+		RewrapInterval time.Duration `yaml:"rewrapInterval"`
+	} `yaml:"encryption"`
+
+	// Quota limits the storage of every account, limits of 0 or empty are not enforced
+	Quota struct {
+		// Bytes limits the size of the data of all modules, e.g. 100MB
+		Bytes string `yaml:"bytes"`
+		// Modules limits the amount of modules
+		Modules int64 `yaml:"modules"`
+		// Devices limits the amount of devices
+		Devices int64 `yaml:"devices"`
+	} `yaml:"quota"`
+
+	Uploads struct {
+		// MaxChunkSize limits the chunks of resumable uploads instead of the request body size, defaults to 1MB
+		MaxChunkSize string `yaml:"maxChunkSize"`
+		// Expiration is the time unfinished uploads are kept, defaults to 24h
+		Expiration time.Duration `yaml:"expiration"`
+	} `yaml:"uploads"`
+
+	Changes struct {
+		// Retain is the amount of changes kept per account in the change feed
+		Retain int `yaml:"retain"`
+	} `yaml:"changes"`
+
 	LogSettings `yaml:"log"`
 	Logger      *zerolog.Logger `yaml:"-"`
 
+	TracingSettings `yaml:"tracing"`
+
+	// Metrics are exposed under metrics.Path if present
+	Metrics *metrics.Metrics `yaml:"-"`
+
 	password.PasswordGenerator `yaml:"-"`
 	service.UsernameGenerator  `yaml:"-"`
 
@@ -98,6 +220,15 @@ type Config struct {
 		service.Devices
 		service.Health
 		service.MetadataProvider
+		service.Locker
+		service.History
+		service.ChangeFeed
+		service.Events
+		service.Presence
+		service.Uploads
+		service.Usage
+		service.Schemas
+		service.Encryption
 	} `yaml:"-"`
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"os"
 	"runtime/debug"
 
 	"github.com/google/uuid"
@@ -14,6 +15,7 @@ import (
 	"github.com/jakobmoellerdev/octi-sync-server/config"
 	"github.com/jakobmoellerdev/octi-sync-server/server"
 	"github.com/jakobmoellerdev/octi-sync-server/service"
+	"github.com/jakobmoellerdev/octi-sync-server/tracing"
 )
 
 var (
@@ -74,8 +76,19 @@ func main() {
 
 	uuid.EnableRandPool()
 
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.TracingSettings, version, os.Stdout)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	// Run the server
-	if err := server.Run(context.Background(), cfg); err != nil {
+	err = server.Run(context.Background(), cfg)
+
+	if err := shutdownTracing(context.Background()); err != nil {
+		logger.Warn().Err(err).Msg("could not flush pending spans")
+	}
+
+	if err != nil {
 		log.Fatal(err)
 	}
 }
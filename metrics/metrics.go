@@ -0,0 +1,136 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	Namespace = "octi"
+
+	// Path is the route under which the metrics are exposed in Prometheus text format.
+	Path = "/metrics"
+
+	ModuleBytesWritten = "written"
+	ModuleBytesRead    = "read"
+)
+
+// Metrics holds all collectors of the server together with the registry they are exposed from.
+type Metrics struct {
+	Registry *prometheus.Registry
+
+	requests         *prometheus.CounterVec
+	requestDuration  *prometheus.HistogramVec
+	backendDuration  *prometheus.HistogramVec
+	backendErrors    *prometheus.CounterVec
+	registrations    prometheus.Counter
+	shareRedemptions prometheus.Counter
+	moduleBytes      *prometheus.CounterVec
+}
+
+// New creates a new set of collectors on a dedicated registry that also exposes go runtime and process metrics.
+func New() *Metrics {
+	registry := prometheus.NewRegistry()
+
+	metrics := &Metrics{
+		Registry: registry,
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "Total number of HTTP requests by route, method and status code.",
+		}, []string{"method", "route", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of HTTP requests by route, method and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		backendDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Subsystem: "backend",
+			Name:      "operation_duration_seconds",
+			Help:      "Latency of storage backend operations by service and method.",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"service", "method"}),
+		backendErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: "backend",
+			Name:      "operation_errors_total",
+			Help:      "Total number of failed storage backend operations by service and method.",
+		}, []string{"service", "method"}),
+		registrations: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "device_registrations_total",
+			Help:      "Total number of successful device (re-)registrations.",
+		}),
+		shareRedemptions: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "share_redemptions_total",
+			Help:      "Total number of share codes redeemed to join an account.",
+		}),
+		moduleBytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: "module",
+			Name:      "bytes_total",
+			Help:      "Total number of module bytes written to and read from the storage backend.",
+		}, []string{"direction"}),
+	}
+
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		metrics.requests,
+		metrics.requestDuration,
+		metrics.backendDuration,
+		metrics.backendErrors,
+		metrics.registrations,
+		metrics.shareRedemptions,
+		metrics.moduleBytes,
+	)
+
+	return metrics
+}
+
+// Handler serves all registered metrics in the Prometheus text format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.Registry, promhttp.HandlerOpts{Registry: m.Registry})
+}
+
+// ObserveRequest records a finished HTTP request.
+func (m *Metrics) ObserveRequest(method, route, status string, latency time.Duration) {
+	m.requests.WithLabelValues(method, route, status).Inc()
+	m.requestDuration.WithLabelValues(method, route, status).Observe(latency.Seconds())
+}
+
+// ObserveOperation starts measuring a backend operation.
+// The returned function has to be called with the result of the operation once it finished.
+func (m *Metrics) ObserveOperation(service, method string) func(err error) {
+	start := time.Now()
+
+	return func(err error) {
+		m.backendDuration.WithLabelValues(service, method).Observe(time.Since(start).Seconds())
+
+		if err != nil {
+			m.backendErrors.WithLabelValues(service, method).Inc()
+		}
+	}
+}
+
+func (m *Metrics) Registered() {
+	m.registrations.Inc()
+}
+
+func (m *Metrics) ShareRedeemed() {
+	m.shareRedemptions.Inc()
+}
+
+// ModuleBytes records the given amount of module bytes moved in the given direction.
+func (m *Metrics) ModuleBytes(direction string, bytes int) {
+	m.moduleBytes.WithLabelValues(direction).Add(float64(bytes))
+}
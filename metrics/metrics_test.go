@@ -0,0 +1,75 @@
+package metrics_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jakobmoellerdev/octi-sync-server/metrics"
+)
+
+type staticPool struct{ stats redis.PoolStats }
+
+func (p *staticPool) PoolStats() *redis.PoolStats { return &p.stats }
+
+func scrape(t *testing.T, m *metrics.Metrics) string {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, metrics.Path, nil))
+
+	body, err := io.ReadAll(rec.Body)
+	assert.NoError(t, err)
+
+	return string(body)
+}
+
+func TestMetrics(t *testing.T) {
+	t.Parallel()
+	assertions := assert.New(t)
+
+	m := metrics.New()
+
+	m.ObserveRequest(http.MethodGet, "/v1/module/:name", "200", 10*time.Millisecond)
+	m.ObserveOperation("Modules", "Get")(nil)
+	m.ObserveOperation("Modules", "Set")(errors.New("failed"))
+	m.Registered()
+	m.ShareRedeemed()
+	m.ModuleBytes(metrics.ModuleBytesWritten, 42)
+
+	body := scrape(t, m)
+
+	assertions.Contains(body, `octi_http_requests_total{method="GET",route="/v1/module/:name",status="200"} 1`)
+	assertions.Contains(body, `octi_http_request_duration_seconds_count{method="GET",route="/v1/module/:name",status="200"} 1`)
+	assertions.Contains(body, `octi_backend_operation_duration_seconds_count{method="Get",service="Modules"} 1`)
+	assertions.Contains(body, `octi_backend_operation_errors_total{method="Set",service="Modules"} 1`)
+	assertions.NotContains(body, `octi_backend_operation_errors_total{method="Get",service="Modules"}`)
+	assertions.Contains(body, `octi_device_registrations_total 1`)
+	assertions.Contains(body, `octi_share_redemptions_total 1`)
+	assertions.Contains(body, `octi_module_bytes_total{direction="written"} 42`)
+	assertions.Contains(body, `go_goroutines`)
+}
+
+func TestMetrics_RegisterRedisPool(t *testing.T) {
+	t.Parallel()
+	assertions := assert.New(t)
+
+	m := metrics.New()
+	pool := &staticPool{redis.PoolStats{Hits: 3, Misses: 1, TotalConns: 5, IdleConns: 2}}
+
+	assertions.NoError(m.RegisterRedisPool("default", pool))
+	assertions.Error(m.RegisterRedisPool("default", pool), "registering the same client twice should fail")
+
+	body := scrape(t, m)
+
+	assertions.Contains(body, `octi_redis_pool_hits_total{client="default"} 3`)
+	assertions.Contains(body, `octi_redis_pool_misses_total{client="default"} 1`)
+	assertions.Contains(body, `octi_redis_pool_connections{client="default"} 5`)
+	assertions.Contains(body, `octi_redis_pool_idle_connections{client="default"} 2`)
+}
@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+)
+
+// PoolStatsProvider is implemented by all go-redis clients that manage a connection pool.
+type PoolStatsProvider interface {
+	PoolStats() *redis.PoolStats
+}
+
+// RegisterRedisPool exposes the connection pool statistics of a redis client under the given client name.
+func (m *Metrics) RegisterRedisPool(name string, client PoolStatsProvider) error {
+	return m.Registry.Register(newRedisPoolCollector(name, client)) //nolint:wrapcheck
+}
+
+type redisPoolCollector struct {
+	client PoolStatsProvider
+
+	hits, misses, timeouts       *prometheus.Desc
+	totalConns, idleConns, stale *prometheus.Desc
+}
+
+func newRedisPoolCollector(name string, client PoolStatsProvider) *redisPoolCollector {
+	desc := func(metric, help string) *prometheus.Desc {
+		return prometheus.NewDesc(
+			prometheus.BuildFQName(Namespace, "redis_pool", metric), help,
+			nil, prometheus.Labels{"client": name},
+		)
+	}
+
+	return &redisPoolCollector{
+		client:     client,
+		hits:       desc("hits_total", "Number of times a free connection was found in the pool."),
+		misses:     desc("misses_total", "Number of times a free connection was not found in the pool."),
+		timeouts:   desc("timeouts_total", "Number of times a wait for a free connection timed out."),
+		totalConns: desc("connections", "Number of total connections in the pool."),
+		idleConns:  desc("idle_connections", "Number of idle connections in the pool."),
+		stale:      desc("stale_connections_total", "Number of stale connections removed from the pool."),
+	}
+}
+
+func (c *redisPoolCollector) Describe(descs chan<- *prometheus.Desc) {
+	descs <- c.hits
+	descs <- c.misses
+	descs <- c.timeouts
+	descs <- c.totalConns
+	descs <- c.idleConns
+	descs <- c.stale
+}
+
+func (c *redisPoolCollector) Collect(metrics chan<- prometheus.Metric) {
+	stats := c.client.PoolStats()
+
+	metrics <- prometheus.MustNewConstMetric(c.hits, prometheus.CounterValue, float64(stats.Hits))
+	metrics <- prometheus.MustNewConstMetric(c.misses, prometheus.CounterValue, float64(stats.Misses))
+	metrics <- prometheus.MustNewConstMetric(c.timeouts, prometheus.CounterValue, float64(stats.Timeouts))
+	metrics <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stats.TotalConns))
+	metrics <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stats.IdleConns))
+	metrics <- prometheus.MustNewConstMetric(c.stale, prometheus.CounterValue, float64(stats.StaleConns))
+}
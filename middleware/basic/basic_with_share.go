@@ -3,12 +3,18 @@ package basic
 import (
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 
 	"github.com/jakobmoellerdev/octi-sync-server/service"
+	"github.com/jakobmoellerdev/octi-sync-server/tracing"
 )
 
 // AccountKey is the cookie name for user credential in basic auth.
@@ -20,7 +26,14 @@ const Device = "device"
 // DeviceIDHeader holds Device Authentication.
 const DeviceIDHeader = "X-Device-ID"
 
-var ErrDevicePassVerificationFailed = errors.New("device pass verification failed")
+// LastSeenUpdateInterval is the minimum amount of time between two recordings of device activity.
+// Authentications within this interval do not cause writes against the device backend.
+const LastSeenUpdateInterval = time.Minute
+
+var (
+	ErrDevicePassVerificationFailed = errors.New("device pass verification failed")
+	ErrDevicePendingApproval        = errors.New("device is pending approval by another device of the account")
+)
 
 // AuthWithShare returns a Basic HTTP Authorization Handler. It takes as argument a map[string]string where
 // the key is the username and the value is the password.
@@ -28,11 +41,24 @@ func AuthWithShare(accounts service.Accounts, devices service.Devices) echo.Midd
 	return middleware.BasicAuthWithConfig(
 		middleware.BasicAuthConfig{
 			Skipper: middleware.DefaultSkipper,
-			Validator: func(username, password string, context echo.Context) (bool, error) {
-				ctx := context.Request().Context()
+			Validator: func(username, password string, context echo.Context) (valid bool, err error) {
+				ctx, span := otel.Tracer(tracing.InstrumentationName).Start(
+					context.Request().Context(), "basic.AuthWithShare",
+				)
+				defer func() {
+					span.SetAttributes(attribute.Bool("auth.valid", valid))
+					if err != nil {
+						span.RecordError(err)
+						span.SetStatus(codes.Error, "authentication failed")
+					}
+					span.End()
+				}()
+
 				// Search account in the slice of allowed credentials
 				account, err := accounts.Find(ctx, username)
 				if err != nil {
+					zerolog.Ctx(ctx).Debug().Err(err).Msg("account for basic authentication not found")
+
 					return false, echo.ErrUnauthorized
 				}
 
@@ -40,6 +66,7 @@ func AuthWithShare(accounts service.Accounts, devices service.Devices) echo.Midd
 				// the account's id can be read later using
 				// context.MustGet(auth.AccountKey).
 				context.Set(AccountKey, account)
+				span.SetAttributes(attribute.String("auth.account", account.Username()))
 
 				deviceIDFromHeader := context.Request().Header.Get(DeviceIDHeader)
 				if deviceIDFromHeader == "" {
@@ -56,21 +83,47 @@ func AuthWithShare(accounts service.Accounts, devices service.Devices) echo.Midd
 					)
 				}
 
+				span.SetAttributes(attribute.String("auth.device", deviceID.String()))
+
 				device, err := devices.GetDevice(ctx, account, service.DeviceID(deviceID))
 
 				if errors.Is(err, service.ErrDeviceNotFound) {
 					return false, echo.NewHTTPError(http.StatusForbidden).SetInternal(err)
 				}
 
+				if err != nil {
+					return false, echo.NewHTTPError(http.StatusInternalServerError).SetInternal(err)
+				}
+
 				if !device.Verify(password) {
+					zerolog.Ctx(ctx).Debug().Str("device", deviceID.String()).Msg("device password verification failed")
+
 					return false, echo.ErrForbidden.SetInternal(ErrDevicePassVerificationFailed)
 				}
 
+				if device.Status() == service.DeviceStatusPending {
+					return false, echo.NewHTTPError(
+						http.StatusForbidden, ErrDevicePendingApproval.Error(),
+					).SetInternal(ErrDevicePendingApproval)
+				}
+
 				// The account credentials was found, set account's id to key Device in this context,
 				// the account's id can be read later using
 				// context.MustGet(auth.Device).
 				context.Set(Device, device)
 
+				logger := zerolog.Ctx(ctx)
+				logger.UpdateContext(func(c zerolog.Context) zerolog.Context {
+					return c.Str("account", account.Username()).Str("device", device.ID().String())
+				})
+
+				if now := time.Now(); now.Sub(device.LastSeenAt()) > LastSeenUpdateInterval {
+					// failing to record activity should never prevent a device from authenticating
+					if err := devices.MarkSeen(ctx, account, device.ID(), now); err != nil {
+						logger.Warn().Err(err).Msg("could not record device activity")
+					}
+				}
+
 				return true, nil
 			},
 			Realm: "",
@@ -13,6 +13,7 @@ import (
 	"github.com/sethvargo/go-password/password"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/jakobmoellerdev/octi-sync-server/config"
 	auth "github.com/jakobmoellerdev/octi-sync-server/middleware/basic"
 	"github.com/jakobmoellerdev/octi-sync-server/middleware/logging"
 	"github.com/jakobmoellerdev/octi-sync-server/service"
@@ -34,7 +35,7 @@ type BasicAuthTestSuite struct {
 func (suite *BasicAuthTestSuite) SetupSuite() {
 	logger := zerolog.New(zerolog.NewTestWriter(suite.T()))
 	api := echo.New()
-	api.Use(logging.RequestLogging(&logger))
+	api.Use(logging.RequestLogging(&logger, config.AccessLogSettings{}), logging.ContextLogger(&logger))
 	suite.api = api
 
 	suite.accounts, suite.devices = memory.NewAccounts(), memory.NewDevices()
@@ -64,7 +65,7 @@ func (suite *BasicAuthTestSuite) registerAndSetDeviceHeader(
 	passLength, minSpecial, minNum := 32, 6, 6
 
 	pass := password.MustGenerate(passLength, minNum, minSpecial, false, false)
-	dev, err := suite.devices.AddDevice(context.Background(), acc, deviceID, pass)
+	dev, err := suite.devices.AddDevice(context.Background(), acc, deviceID, pass, service.DeviceInfo{})
 
 	suite.NoError(err)
 	suite.req.Header.Set(auth.DeviceIDHeader, deviceID.String())
@@ -106,9 +107,15 @@ func (suite *BasicAuthTestSuite) TestAuthWithSharing() {
 	// Valid Token
 	acc = suite.register(suite.randomUsername())
 	dev = suite.registerAndSetDeviceHeader(acc, suite.randomDeviceID())
+	suite.True(dev.LastSeenAt().IsZero())
 	suite.NoError(testMiddleware(suite))
 	suite.ResetRequest()
 
+	// a successful authentication records the device activity
+	seen, err := suite.devices.GetDevice(context.Background(), acc, dev.ID())
+	suite.NoError(err)
+	suite.False(seen.LastSeenAt().IsZero())
+
 	// Trying to access account from random other device
 	suite.req.Header.Set(auth.DeviceIDHeader, suite.randomDeviceID().String())
 	suite.Equal(http.StatusForbidden, suite.asHTTPError(testMiddleware(suite)).Code)
@@ -132,7 +139,7 @@ func (suite *BasicAuthTestSuite) TestAuthWithSharing() {
 	suite.ResetRequest()
 
 	// Now we share an account
-	_, err := suite.devices.AddDevice(context.Background(), acc, dev.ID(), "test")
+	_, err = suite.devices.AddDevice(context.Background(), acc, dev.ID(), "test", service.DeviceInfo{})
 	suite.NoError(err)
 	// at first the device is not shared, the call should be forbidden
 	suite.req.Header.Set(auth.DeviceIDHeader, suite.randomDeviceID().String())
@@ -149,6 +156,22 @@ func (suite *BasicAuthTestSuite) TestAuthWithSharing() {
 	suite.req.SetBasicAuth(acc.Username(), "test")
 	suite.NoError(testMiddleware(suite))
 	suite.ResetRequest()
+
+	// a device pending approval is known but cannot access the account
+	pendingID := suite.randomDeviceID()
+	_, err = suite.devices.AddDevice(context.Background(), acc, pendingID, "pending",
+		service.DeviceInfo{Status: service.DeviceStatusPending})
+	suite.NoError(err)
+	suite.req.Header.Set(auth.DeviceIDHeader, pendingID.String())
+	suite.req.SetBasicAuth(acc.Username(), "pending")
+	suite.ErrorIs(suite.asHTTPError(testMiddleware(suite)), auth.ErrDevicePendingApproval)
+	suite.ResetRequest()
+
+	// once approved, the device can access the account
+	_, err = suite.devices.ApproveDevice(context.Background(), acc, pendingID)
+	suite.NoError(err)
+	suite.NoError(testMiddleware(suite))
+	suite.ResetRequest()
 }
 
 // In order for 'go test' to run this suite, we need to create
@@ -0,0 +1,31 @@
+package logging
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ContextLogger stores a logger for the request in the request context that handlers and services
+// retrieve through zerolog.Ctx. It carries the request and trace ID, authentication adds account and device.
+func ContextLogger(logger *zerolog.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			request := ctx.Request()
+			loggerContext := logger.With()
+
+			if requestID := ctx.Response().Header().Get(echo.HeaderXRequestID); requestID != "" {
+				loggerContext = loggerContext.Str("x-request-id", requestID)
+			}
+
+			if spanContext := trace.SpanContextFromContext(request.Context()); spanContext.HasTraceID() {
+				loggerContext = loggerContext.Str("trace-id", spanContext.TraceID().String())
+			}
+
+			requestLogger := loggerContext.Logger()
+			ctx.SetRequest(request.WithContext(requestLogger.WithContext(request.Context())))
+
+			return next(ctx)
+		}
+	}
+}
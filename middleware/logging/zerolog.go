@@ -2,15 +2,46 @@ package logging
 
 import (
 	"net"
+	"net/http"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
 
+	"github.com/jakobmoellerdev/octi-sync-server/config"
 	"github.com/jakobmoellerdev/octi-sync-server/middleware/basic"
+	"github.com/jakobmoellerdev/octi-sync-server/service"
 )
 
-func RequestLogging(logger *zerolog.Logger) echo.MiddlewareFunc {
+// Redacted replaces the value of access log fields configured for redaction.
+const Redacted = "[REDACTED]"
+
+// RequestLogging writes an access log entry for every request after it was handled.
+func RequestLogging(logger *zerolog.Logger, settings config.AccessLogSettings) echo.MiddlewareFunc {
+	level, err := zerolog.ParseLevel(settings.Level)
+	if err != nil || level == zerolog.NoLevel {
+		level = zerolog.DebugLevel
+	}
+
+	sampled := *logger
+	if settings.SampleRate > 1 {
+		sampled = logger.Sample(&zerolog.BasicSampler{N: settings.SampleRate})
+	}
+
+	redact := make(map[string]bool, len(settings.Redact))
+	for _, field := range settings.Redact {
+		redact[field] = true
+	}
+
+	str := func(event *zerolog.Event, key, value string) *zerolog.Event {
+		if redact[key] {
+			value = Redacted
+		}
+
+		return event.Str(key, value)
+	}
+
 	return middleware.RequestLoggerWithConfig(middleware.RequestLoggerConfig{
 		LogURI:           true,
 		LogStatus:        true,
@@ -23,18 +54,37 @@ func RequestLogging(logger *zerolog.Logger) echo.MiddlewareFunc {
 		LogResponseSize:  true,
 		LogUserAgent:     true,
 		LogValuesFunc: func(context echo.Context, values middleware.RequestLoggerValues) error {
-			logger.Debug().
-				Str("Method", values.Method).
-				Str("URI", values.URI).
+			event := sampled.WithLevel(level)
+			if values.Status >= http.StatusInternalServerError {
+				event = logger.Error()
+			}
+
+			if spanContext := trace.SpanContextFromContext(context.Request().Context()); spanContext.HasTraceID() {
+				event = str(event, "trace-id", spanContext.TraceID().String())
+			}
+
+			if account, ok := context.Get(basic.AccountKey).(service.Account); ok {
+				event = str(event, "account", account.Username())
+			}
+
+			if redact["remote-ip"] {
+				event = event.Str("remote-ip", Redacted)
+			} else {
+				event = event.IPAddr("remote-ip", net.ParseIP(values.RemoteIP))
+			}
+
+			event = str(event, "Method", values.Method)
+			event = str(event, "URI", values.URI)
+			event = str(event, "content-length", values.ContentLength)
+			event = str(event, "x-request-id", values.RequestID)
+			event = str(event, "x-device-id", context.Request().Header.Get(basic.DeviceIDHeader))
+			event = str(event, "user-agent", values.UserAgent)
+
+			event.
 				Int("status", values.Status).
-				Str("content-length", values.ContentLength).
-				Str("x-request-id", values.RequestID).
-				Str("x-device-id", context.Request().Header.Get(basic.DeviceIDHeader)).
 				Int64("response-size", values.ResponseSize).
-				Str("user-agent", values.UserAgent).
 				Err(values.Error).
 				Dur("latency", values.Latency).
-				IPAddr("remote-ip", net.ParseIP(values.RemoteIP)).
 				Msg("request")
 
 			return nil
@@ -12,7 +12,9 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
 
+	"github.com/jakobmoellerdev/octi-sync-server/config"
 	"github.com/jakobmoellerdev/octi-sync-server/middleware/basic"
 	"github.com/jakobmoellerdev/octi-sync-server/middleware/logging"
 )
@@ -31,7 +33,7 @@ func TestRequestLogging(t *testing.T) {
 	ctx := echo.New().NewContext(req, rec)
 	assertions := assert.New(t)
 
-	err := logging.RequestLogging(&log)(func(ctx echo.Context) error {
+	err := logging.RequestLogging(&log, config.AccessLogSettings{})(func(ctx echo.Context) error {
 		return ctx.String(http.StatusOK, "test") //nolint:wrapcheck
 	})(ctx)
 
@@ -60,6 +62,111 @@ func TestRequestLogging(t *testing.T) {
 	assertions.NotEmpty(message["remote-ip"])
 }
 
+func TestRequestLoggingWithTrace(t *testing.T) {
+	t.Parallel()
+
+	logBuf := bytes.NewBufferString("")
+	log := zerolog.New(logBuf)
+	rec := httptest.NewRecorder()
+	req := emptyRequest(http.MethodGet)
+
+	traceID := trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36}
+	req = req.WithContext(trace.ContextWithSpanContext(req.Context(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+	})))
+
+	ctx := echo.New().NewContext(req, rec)
+	assertions := assert.New(t)
+
+	assertions.NoError(logging.RequestLogging(&log, config.AccessLogSettings{})(func(ctx echo.Context) error {
+		return ctx.NoContent(http.StatusOK) //nolint:wrapcheck
+	})(ctx))
+
+	message := map[string]any{}
+	assertions.NoError(json.NewDecoder(logBuf).Decode(&message))
+	assertions.Equal(traceID.String(), message["trace-id"])
+}
+
+func TestRequestLoggingRedactionAndLevel(t *testing.T) {
+	t.Parallel()
+
+	logBuf := bytes.NewBufferString("")
+	log := zerolog.New(logBuf)
+	rec := httptest.NewRecorder()
+	req := emptyRequest(http.MethodGet)
+	req.Header.Set("User-Agent", "secret-agent")
+
+	ctx := echo.New().NewContext(req, rec)
+	assertions := assert.New(t)
+
+	assertions.NoError(logging.RequestLogging(&log, config.AccessLogSettings{
+		Level:  "info",
+		Redact: []string{"remote-ip", "user-agent"},
+	})(func(ctx echo.Context) error {
+		return ctx.NoContent(http.StatusOK) //nolint:wrapcheck
+	})(ctx))
+
+	message := map[string]any{}
+	assertions.NoError(json.NewDecoder(logBuf).Decode(&message))
+	assertions.Equal(zerolog.LevelInfoValue, message["level"])
+	assertions.Equal(logging.Redacted, message["remote-ip"])
+	assertions.Equal(logging.Redacted, message["user-agent"])
+	assertions.Equal("/", message["URI"])
+}
+
+func TestRequestLoggingSampling(t *testing.T) {
+	t.Parallel()
+
+	logBuf := bytes.NewBufferString("")
+	log := zerolog.New(logBuf)
+	assertions := assert.New(t)
+
+	middleware := logging.RequestLogging(&log, config.AccessLogSettings{SampleRate: 3})
+	serve := func(status int) {
+		ctx := echo.New().NewContext(emptyRequest(http.MethodGet), httptest.NewRecorder())
+		assertions.NoError(middleware(func(ctx echo.Context) error {
+			return ctx.NoContent(status) //nolint:wrapcheck
+		})(ctx))
+	}
+
+	for i := 0; i < 6; i++ {
+		serve(http.StatusOK)
+	}
+
+	assertions.Equal(2, strings.Count(logBuf.String(), "\n"))
+
+	// server errors are never sampled away
+	logBuf.Reset()
+	serve(http.StatusInternalServerError)
+	serve(http.StatusInternalServerError)
+
+	assertions.Equal(2, strings.Count(logBuf.String(), `"level":"error"`))
+}
+
+func TestContextLogger(t *testing.T) {
+	t.Parallel()
+
+	logBuf := bytes.NewBufferString("")
+	log := zerolog.New(logBuf)
+	rec := httptest.NewRecorder()
+	rec.Header().Set(echo.HeaderXRequestID, "test")
+
+	ctx := echo.New().NewContext(emptyRequest(http.MethodGet), rec)
+	assertions := assert.New(t)
+
+	assertions.NoError(logging.ContextLogger(&log)(func(ctx echo.Context) error {
+		zerolog.Ctx(ctx.Request().Context()).Info().Msg("from handler")
+
+		return nil
+	})(ctx))
+
+	message := map[string]any{}
+	assertions.NoError(json.NewDecoder(logBuf).Decode(&message))
+	assertions.Equal("from handler", message["message"])
+	assertions.Equal("test", message["x-request-id"])
+}
+
 func emptyRequest(method string) *http.Request {
 	return httptest.NewRequest(method, "/",
 		strings.NewReader(make(url.Values).Encode()))
@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/jakobmoellerdev/octi-sync-server/metrics"
+)
+
+// UnmatchedRoute is used as route label for requests that did not match any registered route,
+// to avoid unbounded label cardinality.
+const UnmatchedRoute = "unmatched"
+
+// RequestMetrics records count and latency of every request by method, route and status code.
+func RequestMetrics(recorder *metrics.Metrics) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			start := time.Now()
+			err := next(ctx)
+
+			route := ctx.Path()
+			if route == "" {
+				route = UnmatchedRoute
+			}
+
+			recorder.ObserveRequest(
+				ctx.Request().Method, route, strconv.Itoa(statusOf(ctx, err)), time.Since(start),
+			)
+
+			return err
+		}
+	}
+}
+
+// statusOf determines the status code that will be sent for the request.
+// Errors are only turned into responses by the echo error handler after all middlewares ran.
+func statusOf(ctx echo.Context, err error) int {
+	if err == nil || ctx.Response().Committed {
+		return ctx.Response().Status
+	}
+
+	var httpError *echo.HTTPError
+	if errors.As(err, &httpError) {
+		return httpError.Code
+	}
+
+	return http.StatusInternalServerError
+}
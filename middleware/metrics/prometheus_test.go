@@ -0,0 +1,48 @@
+package metrics_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jakobmoellerdev/octi-sync-server/metrics"
+	requestMetrics "github.com/jakobmoellerdev/octi-sync-server/middleware/metrics"
+)
+
+func TestRequestMetrics(t *testing.T) {
+	t.Parallel()
+	assertions := assert.New(t)
+
+	recorder := metrics.New()
+	router := echo.New()
+	router.Use(requestMetrics.RequestMetrics(recorder))
+	router.GET("/ok/:name", func(ctx echo.Context) error {
+		return ctx.String(http.StatusOK, "ok") //nolint:wrapcheck
+	})
+	router.GET("/teapot", func(ctx echo.Context) error {
+		return echo.NewHTTPError(http.StatusTeapot)
+	})
+	router.GET("/fail", func(ctx echo.Context) error {
+		return errors.New("unexpected")
+	})
+
+	for _, path := range []string{"/ok/a", "/ok/b", "/teapot", "/fail", "/unknown"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	rec := httptest.NewRecorder()
+	recorder.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, metrics.Path, nil))
+	body, err := io.ReadAll(rec.Body)
+
+	assertions.NoError(err)
+	assertions.Contains(string(body), `octi_http_requests_total{method="GET",route="/ok/:name",status="200"} 2`)
+	assertions.Contains(string(body), `octi_http_requests_total{method="GET",route="/teapot",status="418"} 1`)
+	assertions.Contains(string(body), `octi_http_requests_total{method="GET",route="/fail",status="500"} 1`)
+	assertions.Contains(string(body), `status="404"} 1`)
+	assertions.NotContains(string(body), `route="/unknown"`)
+}
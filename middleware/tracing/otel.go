@@ -0,0 +1,85 @@
+package tracing
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/jakobmoellerdev/octi-sync-server/tracing"
+)
+
+// RequestIDAttribute links spans to the request logs written for the same request.
+const RequestIDAttribute = attribute.Key("http.request.id")
+
+// RequestTracing starts a server span for every request and continues traces propagated by the client.
+// The span is stored in the request context so that spans of later middlewares and services become its children.
+func RequestTracing(provider trace.TracerProvider, propagator propagation.TextMapPropagator) echo.MiddlewareFunc {
+	tracer := provider.Tracer(tracing.InstrumentationName)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			request := ctx.Request()
+			parent := propagator.Extract(request.Context(), propagation.HeaderCarrier(request.Header))
+
+			route := ctx.Path()
+			name := request.Method
+			if route != "" {
+				name += " " + route
+			}
+
+			spanCtx, span := tracer.Start(parent, name,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					semconv.HTTPRequestMethodKey.String(request.Method),
+					semconv.HTTPRoute(route),
+					semconv.URLPath(request.URL.Path),
+					semconv.ClientAddress(ctx.RealIP()),
+					semconv.UserAgentOriginal(request.UserAgent()),
+				),
+			)
+			defer span.End()
+
+			if requestID := ctx.Response().Header().Get(echo.HeaderXRequestID); requestID != "" {
+				span.SetAttributes(RequestIDAttribute.String(requestID))
+			}
+
+			ctx.SetRequest(request.WithContext(spanCtx))
+
+			err := next(ctx)
+
+			status := statusOf(ctx, err)
+			span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+
+			if err != nil {
+				span.RecordError(err)
+			}
+
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(status))
+			}
+
+			return err
+		}
+	}
+}
+
+// statusOf determines the status code that will be sent for the request.
+// Errors are only turned into responses by the echo error handler after all middlewares ran.
+func statusOf(ctx echo.Context, err error) int {
+	if err == nil || ctx.Response().Committed {
+		return ctx.Response().Status
+	}
+
+	var httpError *echo.HTTPError
+	if errors.As(err, &httpError) {
+		return httpError.Code
+	}
+
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,71 @@
+package tracing_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/jakobmoellerdev/octi-sync-server/middleware/tracing"
+)
+
+func TestRequestTracing(t *testing.T) {
+	t.Parallel()
+	assertions := assert.New(t)
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	var handlerSpan trace.SpanContext
+
+	router := echo.New()
+	router.Use(middleware.RequestID())
+	router.Use(tracing.RequestTracing(provider, propagation.TraceContext{}))
+	router.GET("/ok/:name", func(ctx echo.Context) error {
+		handlerSpan = trace.SpanContextFromContext(ctx.Request().Context())
+
+		return ctx.String(http.StatusOK, "ok") //nolint:wrapcheck
+	})
+	router.GET("/fail", func(ctx echo.Context) error {
+		return echo.NewHTTPError(http.StatusBadGateway)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/ok/a", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/fail", nil))
+
+	spans := recorder.Ended()
+	assertions.Len(spans, 2)
+
+	okSpan := spans[0]
+	assertions.Equal("GET /ok/:name", okSpan.Name())
+	assertions.Equal(trace.SpanKindServer, okSpan.SpanKind())
+	assertions.Equal("4bf92f3577b34da6a3ce929d0e0e4736", okSpan.SpanContext().TraceID().String())
+	assertions.Equal("00f067aa0ba902b7", okSpan.Parent().SpanID().String())
+	assertions.Equal(okSpan.SpanContext().SpanID(), handlerSpan.SpanID())
+	assertions.Contains(okSpan.Attributes(), attribute.Int("http.response.status_code", http.StatusOK))
+	assertions.Equal(codes.Unset, okSpan.Status().Code)
+
+	hasRequestID := false
+	for _, attr := range okSpan.Attributes() {
+		hasRequestID = hasRequestID || (attr.Key == tracing.RequestIDAttribute && attr.Value.AsString() != "")
+	}
+
+	assertions.True(hasRequestID)
+
+	failSpan := spans[1]
+	assertions.Equal("GET /fail", failSpan.Name())
+	assertions.False(failSpan.Parent().IsValid())
+	assertions.Contains(failSpan.Attributes(), attribute.Int("http.response.status_code", http.StatusBadGateway))
+	assertions.Equal(codes.Error, failSpan.Status().Code)
+}
@@ -7,28 +7,58 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"time"
 
+	"github.com/labstack/gommon/bytes"
 	goredis "github.com/redis/go-redis/v9"
 
 	"github.com/jakobmoellerdev/octi-sync-server/api"
 	"github.com/jakobmoellerdev/octi-sync-server/config"
+	"github.com/jakobmoellerdev/octi-sync-server/metrics"
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+	"github.com/jakobmoellerdev/octi-sync-server/service/instrumented"
+	"github.com/jakobmoellerdev/octi-sync-server/service/memory"
 	"github.com/jakobmoellerdev/octi-sync-server/service/redis"
 )
 
+const (
+	// DefaultGarbageCollectionInterval is the time between two runs of the blob garbage collection if not configured.
+	DefaultGarbageCollectionInterval = time.Hour
+	// DefaultRewrapInterval is the time between two runs rewrapping data keys with the active master key.
+	DefaultRewrapInterval = time.Hour
+	// DefaultMasterKeyEnv is the environment variable master keys are read from if no key file is configured.
+	DefaultMasterKeyEnv = "OCTI_MASTER_KEYS"
+)
+
 // Run will run the HTTP Server.
 func Run(ctx context.Context, cfg *config.Config) error {
 	startUpContext, cancelStartUpContext := context.WithCancel(ctx)
 	defer cancelStartUpContext()
 
+	mutators := DefaultClientMutators("default")
+	if cfg.TracingSettings.Exporter != config.TracingExporterNone {
+		mutators["default"] = redis.TracingMutator(cfg.Logger)
+	}
+
 	clients, err := redis.NewClientsWithRegularPing(
 		startUpContext, cfg,
 		DefaultUniversalClient(),
-		DefaultClientMutators("default"),
+		mutators,
 	)
 	if err != nil {
 		return fmt.Errorf("error while starting up redis client")
 	}
 
+	if cfg.Metrics == nil {
+		cfg.Metrics = metrics.New()
+	}
+
+	for name, client := range clients {
+		if err := cfg.Metrics.RegisterRedisPool(name, client); err != nil {
+			return fmt.Errorf("error while registering redis pool metrics: %w", err)
+		}
+	}
+
 	srv := createServer(startUpContext, clients, cfg)
 
 	idleConsClosed := make(chan struct{})
@@ -68,11 +98,70 @@ func Run(ctx context.Context, cfg *config.Config) error {
 func createServer(startUpContext context.Context, clients redis.Clients, cfg *config.Config) *http.Server {
 	accounts := &redis.Accounts{Client: clients["default"]}
 
-	cfg.Services.Accounts = accounts
-	cfg.Services.Sharing = accounts
-	cfg.Services.Modules = &redis.Modules{Client: clients["default"], Expiration: cfg.Redis.Module.Expiration}
-	cfg.Services.Devices = &redis.Devices{Client: clients["default"]}
-	cfg.Services.MetadataProvider = &redis.MetadataProvider{Client: clients["default"]}
+	cfg.Services.Accounts = &instrumented.Accounts{Accounts: accounts, Metrics: cfg.Metrics}
+	cfg.Services.Sharing = &instrumented.Sharing{Sharing: accounts, Metrics: cfg.Metrics}
+	chunkSize := redis.DefaultChunkSize
+
+	if cfg.Redis.Module.ChunkSize != "" {
+		size, err := bytes.Parse(cfg.Redis.Module.ChunkSize)
+		if err != nil {
+			cfg.Logger.Fatal().Err(err).Msg("error while parsing module chunk size")
+		}
+
+		chunkSize = int(size)
+	}
+
+	modules := &redis.Modules{Client: clients["default"], ChunkSize: chunkSize}
+
+	cfg.Services.Modules = &instrumented.Modules{Modules: modules, Metrics: cfg.Metrics}
+	cfg.Services.Devices = &instrumented.Devices{Devices: &redis.Devices{Client: clients["default"]}, Metrics: cfg.Metrics}
+	cfg.Services.MetadataProvider = &instrumented.MetadataProvider{
+		MetadataProvider: &redis.MetadataProvider{Client: clients["default"]},
+		Metrics:          cfg.Metrics,
+	}
+	cfg.Services.History = &instrumented.History{
+		History: &redis.History{
+			Client: clients["default"], Retention: cfg.History, Expiration: cfg.Redis.Module.Expiration,
+			ChunkSize: chunkSize,
+		},
+		Metrics: cfg.Metrics,
+	}
+	cfg.Services.ChangeFeed = &instrumented.ChangeFeed{
+		ChangeFeed: &redis.ChangeFeed{Client: clients["default"], Retain: int64(cfg.Changes.Retain)},
+		Metrics:    cfg.Metrics,
+	}
+	cfg.Services.Events = &instrumented.Events{Events: &redis.Events{Client: clients["default"]}, Metrics: cfg.Metrics}
+	cfg.Services.Presence = &instrumented.Presence{
+		Presence: &redis.Presence{Client: clients["default"]},
+		Metrics:  cfg.Metrics,
+	}
+	cfg.Services.Uploads = &instrumented.Uploads{
+		Uploads: &redis.Uploads{
+			Client: clients["default"], Expiration: cfg.Uploads.Expiration, ChunkSize: chunkSize,
+		},
+		Metrics: cfg.Metrics,
+	}
+	cfg.Services.Usage = &instrumented.Usage{Usage: &redis.Usage{Client: clients["default"]}, Metrics: cfg.Metrics}
+	cfg.Services.Locker = &instrumented.Locker{Locker: &redis.Locker{Client: clients["default"]}, Metrics: cfg.Metrics}
+	cfg.Services.Schemas = memory.NewSchemas()
+
+	if cfg.Modules.Schemas != "" {
+		schemas, err := memory.LoadSchemas(cfg.Modules.Schemas)
+		if err != nil {
+			cfg.Logger.Fatal().Err(err).Msg("error while loading module schemas")
+		}
+
+		cfg.Services.Schemas = schemas
+	}
+
+	gcInterval := cfg.Redis.Module.GarbageCollectionInterval
+	if gcInterval <= 0 {
+		gcInterval = DefaultGarbageCollectionInterval
+	}
+
+	redis.StartCollectingGarbage(startUpContext, gcInterval, modules, cfg.Logger)
+
+	configureEncryption(startUpContext, clients, cfg)
 
 	// Define server options
 	srv := &http.Server{
@@ -87,6 +176,42 @@ func createServer(startUpContext context.Context, clients redis.Clients, cfg *co
 	return srv
 }
 
+// configureEncryption encrypts module data at rest if master keys are configured
+// and regularly rewraps the data keys of accounts with the active master key.
+func configureEncryption(startUpContext context.Context, clients redis.Clients, cfg *config.Config) {
+	if cfg.Encryption.KeyEnv == "" {
+		cfg.Encryption.KeyEnv = DefaultMasterKeyEnv
+	}
+
+	keyring, err := service.LoadKeyring(cfg.Encryption.KeyFile, cfg.Encryption.KeyEnv)
+	if err != nil {
+		cfg.Logger.Fatal().Err(err).Msg("error while loading master keys")
+	}
+
+	if keyring == nil {
+		cfg.Logger.Warn().Msg("no master keys configured, module data is stored unencrypted")
+
+		return
+	}
+
+	envelope := &service.Envelope{
+		Keyring: keyring,
+		DataKeys: &instrumented.DataKeys{
+			DataKeys: &redis.DataKeys{Client: clients["default"]},
+			Metrics:  cfg.Metrics,
+		},
+	}
+
+	cfg.Services.Encryption = envelope
+
+	rewrapInterval := cfg.Encryption.RewrapInterval
+	if rewrapInterval <= 0 {
+		rewrapInterval = DefaultRewrapInterval
+	}
+
+	service.StartRewrappingDataKeys(startUpContext, rewrapInterval, envelope, cfg.Logger)
+}
+
 func DefaultClientMutators(identifier string) redis.ClientMutators {
 	return redis.ClientMutators{
 		identifier: nil,
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+)
+
+// Digest is the content address of module data, the hex encoded SHA-256 that is also recorded as hash
+// in the metadata of the module. Modules with the same data share the same stored blob.
+func Digest(data []byte) string {
+	hash := sha256.Sum256(data)
+
+	return hex.EncodeToString(hash[:])
+}
+
+// DigestReader computes the digest and size of the data read through it,
+// so that streamed module data is content addressed without buffering it.
+type DigestReader struct {
+	reader io.Reader
+	hash   hash.Hash
+	size   int64
+	err    error
+}
+
+func NewDigestReader(reader io.Reader) *DigestReader {
+	return &DigestReader{reader: reader, hash: sha256.New()}
+}
+
+func (r *DigestReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.hash.Write(p[:n])
+	r.size += int64(n)
+
+	if err != nil && !errors.Is(err, io.EOF) && r.err == nil {
+		r.err = err
+	}
+
+	return n, err //nolint:wrapcheck
+}
+
+// Digest returns the digest of the data read so far.
+func (r *DigestReader) Digest() string {
+	return hex.EncodeToString(r.hash.Sum(nil))
+}
+
+// Size returns the amount of bytes read so far.
+func (r *DigestReader) Size() int64 {
+	return r.size
+}
+
+// Err returns the first error of the underlying reader, which is not the error of whoever consumes the data.
+func (r *DigestReader) Err() error {
+	return r.err
+}
+
+// GarbageCollector drops stored blobs that are no longer referenced by any module,
+// e.g. because the referencing modules expired.
+type GarbageCollector interface {
+	// CollectGarbage returns the amount of dropped blobs.
+	CollectGarbage(ctx context.Context) (int, error)
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// DefaultRetainedChanges is the amount of changes kept per account if not configured otherwise.
+const DefaultRetainedChanges = 10000
+
+// ErrCursorExpired tells clients that changes after their cursor were no longer retained,
+// so that they have to sync all modules again instead of relying on the feed.
+var ErrCursorExpired = errors.New("cursor expired, resync")
+
+type ChangeOperation string
+
+//goland:noinspection ALL
+const (
+	ChangeOperationCreated ChangeOperation = "created"
+	ChangeOperationUpdated ChangeOperation = "updated"
+	ChangeOperationDeleted ChangeOperation = "deleted"
+)
+
+// Change describes a single write to a module of an account.
+type Change struct {
+	// Cursor identifies the change in the feed, it is assigned when the change is appended
+	Cursor     string
+	Module     string
+	Device     DeviceID
+	Operation  ChangeOperation
+	ModifiedAt time.Time
+}
+
+//go:generate mockgen -source changes.go -package mock -destination mock/changes.go ChangeFeed
+type ChangeFeed interface {
+	// Append adds the change to the end of the feed of the account and returns its cursor.
+	// Cursors of later changes are always greater than the ones of earlier changes.
+	Append(ctx context.Context, account Account, change Change) (string, error)
+	// Since returns up to limit changes of the account that were appended after the change with the cursor,
+	// from the oldest retained change if the cursor is empty. ErrInvalidCursor is returned for unknown cursor formats,
+	// ErrCursorExpired for cursors older than the oldest retained change, as changes after them might be lost.
+	Since(ctx context.Context, account Account, cursor string, limit int) ([]Change, error)
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// ModuleEncoding is the codec module data is compressed with at rest, named after its HTTP content coding
+// so that clients accepting it receive the stored data as is.
+type ModuleEncoding string
+
+const (
+	// EncodingIdentity stores module data uncompressed.
+	EncodingIdentity ModuleEncoding = ""
+	EncodingGzip     ModuleEncoding = "gzip"
+	EncodingZstd     ModuleEncoding = "zstd"
+)
+
+var ErrUnsupportedEncoding = errors.New("unsupported module encoding")
+
+// ParseModuleEncoding returns the encoding with the name, "identity" and "none" are accepted for EncodingIdentity.
+func ParseModuleEncoding(name string) (ModuleEncoding, error) {
+	switch encoding := ModuleEncoding(name); encoding {
+	case EncodingIdentity, "identity", "none":
+		return EncodingIdentity, nil
+	case EncodingGzip, EncodingZstd:
+		return encoding, nil
+	default:
+		return EncodingIdentity, fmt.Errorf("%w: %q", ErrUnsupportedEncoding, name)
+	}
+}
+
+// Encode returns the data compressed with the encoding. The data is compressed chunk by chunk while it is read,
+// so that only a chunk and the window of the codec are held in memory.
+func (e ModuleEncoding) Encode(data io.Reader) io.Reader {
+	if e == EncodingIdentity {
+		return data
+	}
+
+	reader := &encodingReader{source: data, chunk: make([]byte, encodingChunkSize)}
+
+	switch e {
+	case EncodingGzip:
+		reader.encoder = gzip.NewWriter(&reader.encoded)
+	case EncodingZstd:
+		// a single goroutine makes the encoder compress synchronously within Write
+		reader.encoder, reader.err = zstd.NewWriter(&reader.encoded, zstd.WithEncoderConcurrency(1))
+	default:
+		reader.err = fmt.Errorf("%w: %q", ErrUnsupportedEncoding, e)
+	}
+
+	return reader
+}
+
+const encodingChunkSize = 32 << 10
+
+// encodingReader compresses the source while it is read.
+type encodingReader struct {
+	source  io.Reader
+	encoder io.WriteCloser
+	encoded bytes.Buffer
+	chunk   []byte
+	done    bool
+	err     error
+}
+
+func (r *encodingReader) Read(p []byte) (int, error) {
+	for r.err == nil && !r.done && r.encoded.Len() == 0 {
+		n, err := r.source.Read(r.chunk)
+		if n > 0 {
+			if _, writeErr := r.encoder.Write(r.chunk[:n]); writeErr != nil {
+				r.err = fmt.Errorf("could not compress module data: %w", writeErr)
+			}
+		}
+
+		switch {
+		case r.err != nil:
+		case errors.Is(err, io.EOF):
+			r.done = true
+
+			if err := r.encoder.Close(); err != nil {
+				r.err = fmt.Errorf("could not compress module data: %w", err)
+			}
+		case err != nil:
+			r.err = err
+		}
+	}
+
+	if r.encoded.Len() > 0 {
+		return r.encoded.Read(p) //nolint:wrapcheck
+	}
+
+	if r.err != nil {
+		return 0, r.err
+	}
+
+	return 0, io.EOF
+}
+
+// Decode returns the data decompressed with the encoding while it is read.
+func (e ModuleEncoding) Decode(data io.Reader) (io.Reader, error) {
+	switch e {
+	case EncodingIdentity:
+		return data, nil
+	case EncodingGzip:
+		decoder, err := gzip.NewReader(data)
+		if err != nil {
+			return nil, fmt.Errorf("could not decompress module data: %w", err)
+		}
+
+		return decoder, nil
+	case EncodingZstd:
+		// a single goroutine makes the decoder decompress synchronously within Read
+		decoder, err := zstd.NewReader(data, zstd.WithDecoderConcurrency(1), zstd.WithDecoderLowmem(true))
+		if err != nil {
+			return nil, fmt.Errorf("could not decompress module data: %w", err)
+		}
+
+		return &zstdReader{decoder}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedEncoding, e)
+	}
+}
+
+// zstdReader releases the decoder once all data was read.
+type zstdReader struct {
+	*zstd.Decoder
+}
+
+func (r *zstdReader) Read(p []byte) (int, error) {
+	n, err := r.Decoder.Read(p)
+	if err != nil {
+		r.Decoder.Close()
+	}
+
+	return n, err //nolint:wrapcheck
+}
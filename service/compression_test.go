@@ -0,0 +1,44 @@
+package service_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+func TestModuleEncoding(t *testing.T) {
+	t.Parallel()
+	assertions := assert.New(t)
+
+	// larger than a compression chunk so that the data is compressed over several reads
+	data := bytes.Repeat([]byte(`{"theme": "dark", "lang": "en"}`), 4096)
+
+	for _, encoding := range []service.ModuleEncoding{
+		service.EncodingIdentity, service.EncodingGzip, service.EncodingZstd,
+	} {
+		encoded, err := io.ReadAll(encoding.Encode(bytes.NewReader(data)))
+		assertions.NoError(err)
+
+		if encoding != service.EncodingIdentity {
+			assertions.Less(len(encoded), len(data)/10, encoding)
+		}
+
+		decoder, err := encoding.Decode(bytes.NewReader(encoded))
+		if assertions.NoError(err) {
+			decoded, err := io.ReadAll(decoder)
+			assertions.NoError(err)
+			assertions.Equal(data, decoded, encoding)
+		}
+	}
+
+	encoding, err := service.ParseModuleEncoding("none")
+	assertions.NoError(err)
+	assertions.Equal(service.EncodingIdentity, encoding)
+
+	_, err = service.ParseModuleEncoding("brotli")
+	assertions.ErrorIs(err, service.ErrUnsupportedEncoding)
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ModuleType decides how a write is combined with the stored data of a module.
+type ModuleType string
+
+const (
+	// ModuleTypeOpaque modules are replaced by every write, the last writer wins.
+	ModuleTypeOpaque ModuleType = ""
+	// ModuleTypeLWWMap modules are last-writer-wins element maps, writes are merged into them per key.
+	ModuleTypeLWWMap ModuleType = "lww-map"
+)
+
+var ErrInvalidCRDTState = errors.New("invalid crdt state")
+
+// IsCRDT reports whether writes of the module type are merged instead of replacing the module.
+func (t ModuleType) IsCRDT() bool {
+	return t != ModuleTypeOpaque
+}
+
+// Merge merges the incoming state of a CRDT module into the current state, which is empty for new modules.
+// Merging is commutative, associative and idempotent, so devices converge no matter in which order they sync.
+func (t ModuleType) Merge(current, incoming []byte) ([]byte, error) {
+	switch t {
+	case ModuleTypeLWWMap:
+		return MergeLWWMaps(current, incoming)
+	default:
+		return nil, fmt.Errorf("%w: %q is not a crdt module type", ErrInvalidCRDTState, t)
+	}
+}
+
+// LWWMapEntry is the latest write of a key in a LWWMap. Removed keys are kept as deleted entries,
+// so that a removal wins over older writes that are merged later.
+type LWWMapEntry struct {
+	Value json.RawMessage `json:"value,omitempty"`
+	// Timestamp orders the writes of the key, e.g. the unix milliseconds of the device when it changed the key
+	Timestamp int64 `json:"timestamp"`
+	// Device that wrote the entry, it orders writes with the same timestamp
+	Device  string `json:"device,omitempty"`
+	Deleted bool   `json:"deleted,omitempty"`
+}
+
+// LWWMap is a last-writer-wins element map, every key holds the entry with the latest timestamp.
+type LWWMap map[string]LWWMapEntry
+
+// after reports whether the entry replaces the other one. Ties are broken by device, removal and value
+// so that every device picks the same entry.
+func (e LWWMapEntry) after(other LWWMapEntry) bool {
+	if e.Timestamp != other.Timestamp {
+		return e.Timestamp > other.Timestamp
+	}
+
+	if e.Device != other.Device {
+		return e.Device > other.Device
+	}
+
+	if e.Deleted != other.Deleted {
+		return e.Deleted
+	}
+
+	return bytes.Compare(e.Value, other.Value) > 0
+}
+
+// Merge keeps the later entry of every key.
+func (m LWWMap) Merge(other LWWMap) {
+	for key, entry := range other {
+		if current, found := m[key]; !found || entry.after(current) {
+			m[key] = entry
+		}
+	}
+}
+
+// MergeLWWMaps merges two encoded LWWMaps, the result is encoded with sorted keys and compact values.
+func MergeLWWMaps(current, incoming []byte) ([]byte, error) {
+	merged, err := decodeLWWMap(current)
+	if err != nil {
+		return nil, fmt.Errorf("stored state: %w", err)
+	}
+
+	other, err := decodeLWWMap(incoming)
+	if err != nil {
+		return nil, err
+	}
+
+	merged.Merge(other)
+
+	encoded, err := json.Marshal(merged)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode lww-map: %w", err)
+	}
+
+	return encoded, nil
+}
+
+func decodeLWWMap(data []byte) (LWWMap, error) {
+	state := make(LWWMap)
+	if len(bytes.TrimSpace(data)) == 0 {
+		return state, nil
+	}
+
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCRDTState, err)
+	}
+
+	for key, entry := range state {
+		switch {
+		case entry.Deleted:
+			entry.Value = nil
+		case entry.Value == nil:
+			return nil, fmt.Errorf("%w: %s has neither a value nor is deleted", ErrInvalidCRDTState, key)
+		default:
+			compact := new(bytes.Buffer)
+			if err := json.Compact(compact, entry.Value); err != nil {
+				return nil, fmt.Errorf("%w: %s: %w", ErrInvalidCRDTState, key, err)
+			}
+
+			entry.Value = compact.Bytes()
+		}
+
+		state[key] = entry
+	}
+
+	return state, nil
+}
@@ -0,0 +1,68 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+func TestMergeLWWMaps(t *testing.T) {
+	t.Parallel()
+	assertions := assert.New(t)
+
+	laptop := []byte(`{
+		"theme": {"value": "dark", "timestamp": 2, "device": "laptop"},
+		"lang": {"timestamp": 3, "device": "laptop", "deleted": true}
+	}`)
+	phone := []byte(`{
+		"theme": {"value": "light", "timestamp": 1, "device": "phone"},
+		"lang": {"value": "en", "timestamp": 2, "device": "phone"},
+		"size": {"value": { "px": 12 }, "timestamp": 1, "device": "phone"}
+	}`)
+	expected := `{"lang":{"timestamp":3,"device":"laptop","deleted":true},` +
+		`"size":{"value":{"px":12},"timestamp":1,"device":"phone"},` +
+		`"theme":{"value":"dark","timestamp":2,"device":"laptop"}}`
+
+	merged, err := service.MergeLWWMaps(laptop, phone)
+	assertions.NoError(err)
+	assertions.JSONEq(expected, string(merged))
+
+	// devices converge no matter in which order and how often states are merged
+	reversed, err := service.MergeLWWMaps(phone, laptop)
+	assertions.NoError(err)
+	assertions.Equal(merged, reversed)
+
+	again, err := service.MergeLWWMaps(merged, phone)
+	assertions.NoError(err)
+	assertions.Equal(merged, again)
+
+	created, err := service.ModuleTypeLWWMap.Merge(nil, laptop)
+	assertions.NoError(err)
+	assertions.JSONEq(string(laptop), string(created))
+
+	// ties are broken by the device and then by removals
+	tie, err := service.MergeLWWMaps(
+		[]byte(`{"k": {"value": 1, "timestamp": 5, "device": "a"}}`),
+		[]byte(`{"k": {"value": 2, "timestamp": 5, "device": "b"}}`),
+	)
+	assertions.NoError(err)
+	assertions.JSONEq(`{"k": {"value": 2, "timestamp": 5, "device": "b"}}`, string(tie))
+
+	tie, err = service.MergeLWWMaps(
+		[]byte(`{"k": {"timestamp": 5, "deleted": true}}`),
+		[]byte(`{"k": {"value": 2, "timestamp": 5}}`),
+	)
+	assertions.NoError(err)
+	assertions.JSONEq(`{"k": {"timestamp": 5, "deleted": true}}`, string(tie))
+
+	_, err = service.MergeLWWMaps(nil, []byte(`{"k": {"timestamp": 1}}`))
+	assertions.ErrorIs(err, service.ErrInvalidCRDTState)
+
+	_, err = service.MergeLWWMaps(nil, []byte(`["not", "a", "map"]`))
+	assertions.ErrorIs(err, service.ErrInvalidCRDTState)
+
+	_, err = service.ModuleTypeOpaque.Merge(nil, laptop)
+	assertions.ErrorIs(err, service.ErrInvalidCRDTState)
+}
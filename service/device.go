@@ -4,6 +4,7 @@ import (
 	"crypto/sha256"
 	"crypto/subtle"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -13,8 +14,27 @@ type Device interface {
 	ID() DeviceID
 	Verify(password string) bool
 	HashedPass() string
+
+	Name() string
+	Platform() string
+	ClientVersion() string
+	RegisteredAt() time.Time
+	LastSeenAt() time.Time
+
+	Status() DeviceStatus
+	RemoteIP() string
 }
 
+// DeviceStatus describes whether a Device is allowed to access the data of its Account.
+type DeviceStatus string
+
+const (
+	// DeviceStatusApproved devices have full access to their Account.
+	DeviceStatusApproved DeviceStatus = "approved"
+	// DeviceStatusPending devices joined an Account but still await approval from an approved device.
+	DeviceStatusPending DeviceStatus = "pending"
+)
+
 type DeviceID uuid.UUID
 
 func (i DeviceID) String() string {
@@ -25,9 +45,58 @@ func (i DeviceID) UUID() uuid.UUID {
 	return uuid.UUID(i)
 }
 
+// DeviceInfo describes a Device as reported by its client and observed by the server during registration.
+type DeviceInfo struct {
+	Name          string       `json:"name,omitempty"          yaml:"name,omitempty"`
+	Platform      string       `json:"platform,omitempty"      yaml:"platform,omitempty"`
+	ClientVersion string       `json:"clientVersion,omitempty" yaml:"clientVersion,omitempty"`
+	RemoteIP      string       `json:"remoteIP,omitempty"      yaml:"remoteIP,omitempty"`
+	Status        DeviceStatus `json:"status,omitempty"        yaml:"status,omitempty"`
+}
+
+// Merge returns a copy of the info in which all empty fields are filled from the given defaults.
+func (i DeviceInfo) Merge(defaults DeviceInfo) DeviceInfo {
+	if i.Name == "" {
+		i.Name = defaults.Name
+	}
+
+	if i.Platform == "" {
+		i.Platform = defaults.Platform
+	}
+
+	if i.ClientVersion == "" {
+		i.ClientVersion = defaults.ClientVersion
+	}
+
+	if i.RemoteIP == "" {
+		i.RemoteIP = defaults.RemoteIP
+	}
+
+	if i.Status == "" {
+		i.Status = defaults.Status
+	}
+
+	return i
+}
+
+// InfoOf extracts the DeviceInfo from an existing Device.
+func InfoOf(device Device) DeviceInfo {
+	return DeviceInfo{
+		Name:          device.Name(),
+		Platform:      device.Platform(),
+		ClientVersion: device.ClientVersion(),
+		RemoteIP:      device.RemoteIP(),
+		Status:        device.Status(),
+	}
+}
+
 type BaseDevice struct {
 	id         DeviceID
 	hashedPass string
+	info       DeviceInfo
+
+	registeredAt time.Time
+	lastSeenAt   time.Time
 }
 
 func (r *BaseDevice) ID() DeviceID {
@@ -43,6 +112,45 @@ func (r *BaseDevice) Verify(password string) bool {
 		[]byte(fmt.Sprintf("%x", sha256.Sum256([]byte(password))))) == 1
 }
 
+func (r *BaseDevice) Name() string {
+	return r.info.Name
+}
+
+func (r *BaseDevice) Platform() string {
+	return r.info.Platform
+}
+
+func (r *BaseDevice) ClientVersion() string {
+	return r.info.ClientVersion
+}
+
+func (r *BaseDevice) RegisteredAt() time.Time {
+	return r.registeredAt
+}
+
+func (r *BaseDevice) LastSeenAt() time.Time {
+	return r.lastSeenAt
+}
+
+// Status defaults to DeviceStatusApproved for devices registered before approvals were introduced.
+func (r *BaseDevice) Status() DeviceStatus {
+	if r.info.Status == "" {
+		return DeviceStatusApproved
+	}
+
+	return r.info.Status
+}
+
+func (r *BaseDevice) RemoteIP() string {
+	return r.info.RemoteIP
+}
+
 func NewBaseDevice(deviceId DeviceID, hashedPass string) *BaseDevice {
-	return &BaseDevice{deviceId, hashedPass}
+	return &BaseDevice{id: deviceId, hashedPass: hashedPass}
+}
+
+func NewBaseDeviceWithInfo(
+	deviceID DeviceID, hashedPass string, info DeviceInfo, registeredAt, lastSeenAt time.Time,
+) *BaseDevice {
+	return &BaseDevice{deviceID, hashedPass, info, registeredAt, lastSeenAt}
 }
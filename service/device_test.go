@@ -0,0 +1,20 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+func Test_DeviceInfoMerge(t *testing.T) {
+	t.Parallel()
+	assertions := assert.New(t)
+
+	merged := service.DeviceInfo{Name: "new"}.Merge(service.DeviceInfo{Name: "old", Platform: "linux"})
+
+	assertions.Equal("new", merged.Name)
+	assertions.Equal("linux", merged.Platform)
+	assertions.Empty(merged.ClientVersion)
+}
@@ -3,15 +3,23 @@ package service
 import (
 	"context"
 	"errors"
+	"time"
 )
 
 //go:generate mockgen -source devices.go -package mock -destination mock/devices.go Devices
 type Devices interface {
-	AddDevice(ctx context.Context, account Account, id DeviceID, password string) (Device, error)
+	AddDevice(ctx context.Context, account Account, id DeviceID, password string, info DeviceInfo) (Device, error)
 	GetDevices(ctx context.Context, account Account) (map[DeviceID]Device, error)
 	GetDevice(ctx context.Context, account Account, id DeviceID) (Device, error)
 	DeleteDevice(ctx context.Context, account Account, id DeviceID) error
 
+	// RenameDevice changes the display name of an existing Device.
+	RenameDevice(ctx context.Context, account Account, id DeviceID, name string) (Device, error)
+	// MarkSeen records the time a Device was last authenticated.
+	MarkSeen(ctx context.Context, account Account, id DeviceID, at time.Time) error
+	// ApproveDevice grants a pending Device access to its Account.
+	ApproveDevice(ctx context.Context, account Account, id DeviceID) (Device, error)
+
 	HealthCheck() HealthCheck
 }
 
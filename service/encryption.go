@@ -0,0 +1,336 @@
+package service
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service/util"
+)
+
+// MasterKeySize is the size of master and data keys, which are AES-256 keys.
+const MasterKeySize = 32
+
+var (
+	ErrNoDataKey          = errors.New("no data key found")
+	ErrUnknownMasterKey   = errors.New("data key is wrapped by an unknown master key")
+	ErrInvalidMasterKey   = errors.New("invalid master key")
+	ErrDataKeyUnwrapFails = errors.New("data key could not be unwrapped")
+)
+
+//go:generate mockgen -source encryption.go -package mock -destination mock/encryption.go Encryption DataKeys
+type Encryption interface {
+	// Encrypt returns the data encrypted with the data key of the account while it is read,
+	// together with the ID of the data key that has to be passed to Decrypt.
+	Encrypt(ctx context.Context, account Account, data io.Reader) (io.Reader, string, error)
+	// Decrypt returns the data decrypted with the data key of the account while it is read.
+	Decrypt(ctx context.Context, account Account, keyID string, data io.Reader) (io.Reader, error)
+}
+
+// WrappedDataKey is a data key of an account encrypted with a master key, data keys are never stored in plaintext.
+type WrappedDataKey struct {
+	ID          string `json:"id"`
+	MasterKeyID string `json:"masterKeyId"`
+	Ciphertext  []byte `json:"ciphertext"`
+}
+
+// DataKeys stores the wrapped data key of every account.
+type DataKeys interface {
+	// Get returns the data key of the account or ErrNoDataKey if it has none yet.
+	Get(ctx context.Context, username string) (WrappedDataKey, error)
+	// Create stores the data key for the account unless it already has one, the stored data key is returned.
+	Create(ctx context.Context, username string, key WrappedDataKey) (WrappedDataKey, error)
+	// Set replaces the data key of the account, e.g. after it was wrapped by another master key.
+	Set(ctx context.Context, username string, key WrappedDataKey) error
+	// List returns the usernames of all accounts with a data key.
+	List(ctx context.Context) ([]string, error)
+}
+
+// MasterKey wraps the data keys of accounts, it is only held in memory of the server.
+type MasterKey struct {
+	ID  string
+	Key []byte
+}
+
+// Keyring holds the master keys. New data keys are wrapped by the active key,
+// the other keys only unwrap data keys until they are rewrapped by the active one.
+type Keyring struct {
+	active MasterKey
+	keys   map[string]MasterKey
+}
+
+// ParseKeyring parses master keys separated by newlines or commas, each as id:base64-key.
+// The first key is the active one, keys are rotated by adding a new key in front of the old ones.
+func ParseKeyring(data string) (*Keyring, error) {
+	keyring := &Keyring{keys: make(map[string]MasterKey)}
+
+	for _, entry := range strings.FieldsFunc(data, func(r rune) bool { return r == '\n' || r == ',' }) {
+		if entry = strings.TrimSpace(entry); entry == "" || strings.HasPrefix(entry, "#") {
+			continue
+		}
+
+		id, encoded, found := strings.Cut(entry, ":")
+		if !found || id == "" {
+			return nil, fmt.Errorf("%w: expected id:base64-key", ErrInvalidMasterKey)
+		}
+
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(key) != MasterKeySize {
+			return nil, fmt.Errorf("%w: %s is not a base64 encoded %d byte key", ErrInvalidMasterKey, id, MasterKeySize)
+		}
+
+		if _, duplicate := keyring.keys[id]; duplicate {
+			return nil, fmt.Errorf("%w: %s is defined twice", ErrInvalidMasterKey, id)
+		}
+
+		keyring.keys[id] = MasterKey{ID: id, Key: key}
+
+		if keyring.active.ID == "" {
+			keyring.active = keyring.keys[id]
+		}
+	}
+
+	if len(keyring.keys) == 0 {
+		return nil, fmt.Errorf("%w: no master keys found", ErrInvalidMasterKey)
+	}
+
+	return keyring, nil
+}
+
+// LoadKeyring reads the master keys from the file or else from the environment variable.
+// Without master keys, nil is returned and modules are stored unencrypted.
+func LoadKeyring(file, env string) (*Keyring, error) {
+	data := ""
+
+	if file != "" {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("could not read master keys: %w", err)
+		}
+
+		data = string(content)
+	} else if env != "" {
+		data = os.Getenv(env)
+	}
+
+	if strings.TrimSpace(data) == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	return ParseKeyring(data)
+}
+
+// Active returns the ID of the master key wrapping new data keys.
+func (k *Keyring) Active() string {
+	return k.active.ID
+}
+
+// Envelope encrypts modules with a data key per account, which is wrapped by the active master key.
+// Rotating the master key only requires rewrapping the data keys, not re-encrypting the modules.
+// Encryption is deterministic per data key, the same data of an account is always encrypted to the same ciphertext.
+type Envelope struct {
+	Keyring  *Keyring
+	DataKeys DataKeys
+}
+
+func (e *Envelope) Encrypt(ctx context.Context, account Account, data io.Reader) (io.Reader, string, error) {
+	wrapped, err := e.DataKeys.Get(ctx, account.Username())
+	if errors.Is(err, ErrNoDataKey) {
+		wrapped, err = e.createDataKey(ctx, account.Username())
+	}
+
+	if err != nil {
+		return nil, "", fmt.Errorf("could not resolve data key: %w", err)
+	}
+
+	key, err := e.unwrap(account.Username(), wrapped)
+	if err != nil {
+		return nil, "", err
+	}
+
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return sealStream(aead, nonceKey(key), data), wrapped.ID, nil
+}
+
+func (e *Envelope) Decrypt(ctx context.Context, account Account, keyID string, data io.Reader) (io.Reader, error) {
+	wrapped, err := e.DataKeys.Get(ctx, account.Username())
+	if err != nil {
+		return nil, fmt.Errorf("could not resolve data key: %w", err)
+	}
+
+	if wrapped.ID != keyID {
+		return nil, fmt.Errorf("%w: data key %s of %s", ErrNoDataKey, keyID, account.Username())
+	}
+
+	aead, err := e.dataKeyCipher(account.Username(), wrapped)
+	if err != nil {
+		return nil, err
+	}
+
+	return openStream(aead, data)
+}
+
+// RewrapDataKeys wraps all data keys that are not wrapped by the active master key with it
+// and returns the amount of rewrapped keys. Afterwards, older master keys can be removed from the keyring.
+func (e *Envelope) RewrapDataKeys(ctx context.Context) (int, error) {
+	usernames, err := e.DataKeys.List(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("could not list data keys: %w", err)
+	}
+
+	rewrapped := 0
+
+	for _, username := range usernames {
+		wrapped, err := e.DataKeys.Get(ctx, username)
+		if err != nil {
+			return rewrapped, fmt.Errorf("could not read data key of %s: %w", username, err)
+		}
+
+		if wrapped.MasterKeyID == e.Keyring.active.ID {
+			continue
+		}
+
+		key, err := e.unwrap(username, wrapped)
+		if err != nil {
+			return rewrapped, err
+		}
+
+		if wrapped, err = e.wrap(username, wrapped.ID, key); err != nil {
+			return rewrapped, err
+		}
+
+		if err := e.DataKeys.Set(ctx, username, wrapped); err != nil {
+			return rewrapped, fmt.Errorf("could not store rewrapped data key of %s: %w", username, err)
+		}
+
+		rewrapped++
+	}
+
+	return rewrapped, nil
+}
+
+func (e *Envelope) createDataKey(ctx context.Context, username string) (WrappedDataKey, error) {
+	key := make([]byte, MasterKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return WrappedDataKey{}, fmt.Errorf("could not generate data key: %w", err)
+	}
+
+	wrapped, err := e.wrap(username, uuid.NewString(), key)
+	if err != nil {
+		return WrappedDataKey{}, err
+	}
+
+	// concurrent writes of the account might create a data key at the same time, only one of them is kept
+	stored, err := e.DataKeys.Create(ctx, username, wrapped)
+	if err != nil {
+		return WrappedDataKey{}, fmt.Errorf("could not store data key: %w", err)
+	}
+
+	return stored, nil
+}
+
+func (e *Envelope) dataKeyCipher(username string, wrapped WrappedDataKey) (cipher.AEAD, error) {
+	key, err := e.unwrap(username, wrapped)
+	if err != nil {
+		return nil, err
+	}
+
+	return newGCM(key)
+}
+
+// wrap encrypts the data key with the active master key, bound to the account and ID of the data key.
+func (e *Envelope) wrap(username, id string, key []byte) (WrappedDataKey, error) {
+	aead, err := newGCM(e.Keyring.active.Key)
+	if err != nil {
+		return WrappedDataKey{}, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return WrappedDataKey{}, fmt.Errorf("could not generate nonce: %w", err)
+	}
+
+	return WrappedDataKey{
+		ID:          id,
+		MasterKeyID: e.Keyring.active.ID,
+		Ciphertext:  aead.Seal(nonce, nonce, key, dataKeyAAD(username, id)),
+	}, nil
+}
+
+func (e *Envelope) unwrap(username string, wrapped WrappedDataKey) ([]byte, error) {
+	master, found := e.Keyring.keys[wrapped.MasterKeyID]
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownMasterKey, wrapped.MasterKeyID)
+	}
+
+	aead, err := newGCM(master.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(wrapped.Ciphertext) < aead.NonceSize() {
+		return nil, fmt.Errorf("%w: %s of %s is too short", ErrDataKeyUnwrapFails, wrapped.ID, username)
+	}
+
+	nonce, ciphertext := wrapped.Ciphertext[:aead.NonceSize()], wrapped.Ciphertext[aead.NonceSize():]
+
+	key, err := aead.Open(nil, nonce, ciphertext, dataKeyAAD(username, wrapped.ID))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s of %s: %w", ErrDataKeyUnwrapFails, wrapped.ID, username, err)
+	}
+
+	return key, nil
+}
+
+func dataKeyAAD(username, id string) []byte {
+	return []byte(username + ":" + id)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("could not create cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("could not create cipher: %w", err)
+	}
+
+	return aead, nil
+}
+
+// StartRewrappingDataKeys regularly rewraps the data keys with the active master key until the context is done,
+// so that rotated master keys can be removed from the keyring once all data keys were rewrapped.
+func StartRewrappingDataKeys(
+	ctx context.Context, interval time.Duration, envelope *Envelope, logger *zerolog.Logger,
+) {
+	rewrap := func(ctx context.Context) {
+		rewrapped, err := envelope.RewrapDataKeys(ctx)
+		if err != nil {
+			logger.Warn().Err(err).Int("keys", rewrapped).Msg("rewrapping data keys failed")
+
+			return
+		}
+
+		logger.Debug().Int("keys", rewrapped).Str("masterKey", envelope.Keyring.Active()).
+			Msg("rewrapping data keys finished")
+	}
+
+	go util.NewIntervalTickerPinger(interval, rewrap).Start(ctx)
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Encrypted data is split into segments that are sealed on their own, so that modules are encrypted and decrypted
+// while they are streamed. The nonce of every segment is derived from its data, its position and the nonce of the
+// segment before and is stored in front of the sealed segment. The same data is thereby always encrypted to the same
+// ciphertext with a data key, so that encrypted modules are still deduplicated and unchanged writes detected.
+// The position and the nonce of the segment before are authenticated with every segment, so that segments
+// can neither be reordered, dropped, truncated nor mixed with segments of other data.
+const (
+	// encryptionVersion is raised with every change of the layout of encrypted data.
+	encryptionVersion      = 2
+	encryptionHeaderSize   = 1
+	encryptionSegmentSize  = 64 << 10
+	encryptionPositionSize = 5
+)
+
+// nonceKeyLabel separates the key the nonces are derived with from the data key itself.
+const nonceKeyLabel = "octi segment nonce"
+
+var ErrDecryptionFailed = errors.New("module data could not be decrypted")
+
+// nonceKey derives the key the nonces of segments are derived with from the data key.
+func nonceKey(dataKey []byte) []byte {
+	mac := hmac.New(sha256.New, dataKey)
+	mac.Write([]byte(nonceKeyLabel))
+
+	return mac.Sum(nil)
+}
+
+// segmentAAD authenticates the index of the segment, whether it is the last one and the nonce of the segment before.
+func segmentAAD(previous []byte, index uint32, last bool) []byte {
+	aad := make([]byte, len(previous)+encryptionPositionSize)
+	copy(aad, previous)
+	binary.BigEndian.PutUint32(aad[len(previous):], index)
+
+	if last {
+		aad[len(aad)-1] = 1
+	}
+
+	return aad
+}
+
+// segmentReader reads the source in segments of a fixed size and processes every segment into the output.
+// It reads one byte ahead to find out whether a segment is the last one.
+type segmentReader struct {
+	source  io.Reader
+	segment []byte
+	// buffered is the amount of bytes of the next segment already read
+	buffered int
+	index    uint32
+	output   bytes.Buffer
+	done     bool
+	err      error
+	process  func(segment []byte, index uint32, last bool) error
+}
+
+func newSegmentReader(
+	source io.Reader, size int, process func(segment []byte, index uint32, last bool) error,
+) *segmentReader {
+	return &segmentReader{source: source, segment: make([]byte, size+1), process: process}
+}
+
+func (r *segmentReader) Read(p []byte) (int, error) {
+	for r.err == nil && !r.done && r.output.Len() == 0 {
+		r.err = r.next()
+	}
+
+	if r.output.Len() > 0 {
+		return r.output.Read(p) //nolint:wrapcheck
+	}
+
+	if r.err != nil {
+		return 0, r.err
+	}
+
+	return 0, io.EOF
+}
+
+func (r *segmentReader) next() error {
+	n, err := io.ReadFull(r.source, r.segment[r.buffered:])
+	r.buffered += n
+
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("could not read segment: %w", err)
+	}
+
+	size := len(r.segment) - 1
+	last := r.buffered <= size
+
+	if last {
+		r.done = true
+		size = r.buffered
+	}
+
+	if err := r.process(r.segment[:size], r.index, last); err != nil {
+		return err
+	}
+
+	r.index++
+
+	// the byte read ahead starts the next segment
+	if !last {
+		r.segment[0] = r.segment[size]
+		r.buffered = 1
+	}
+
+	return nil
+}
+
+// sealStream encrypts the data segment by segment while it is read.
+func sealStream(aead cipher.AEAD, nonceKey []byte, data io.Reader) io.Reader {
+	var (
+		sealed   *segmentReader
+		previous []byte
+	)
+
+	sealed = newSegmentReader(data, encryptionSegmentSize, func(segment []byte, index uint32, last bool) error {
+		aad := segmentAAD(previous, index, last)
+
+		mac := hmac.New(sha256.New, nonceKey)
+		mac.Write(aad)
+		mac.Write(segment)
+		nonce := mac.Sum(nil)[:aead.NonceSize()]
+
+		sealed.output.Write(nonce)
+		sealed.output.Write(aead.Seal(nil, nonce, segment, aad))
+		previous = nonce
+
+		return nil
+	})
+	sealed.output.WriteByte(encryptionVersion)
+
+	return sealed
+}
+
+// openStream decrypts the data segment by segment while it is read, failing on the first tampered segment.
+func openStream(aead cipher.AEAD, data io.Reader) (io.Reader, error) {
+	header := make([]byte, encryptionHeaderSize)
+	if _, err := io.ReadFull(data, header); err != nil {
+		return nil, fmt.Errorf("%w: could not read header: %w", ErrDecryptionFailed, err)
+	}
+
+	if header[0] != encryptionVersion {
+		return nil, fmt.Errorf("%w: unknown version %d", ErrDecryptionFailed, header[0])
+	}
+
+	var (
+		opened   *segmentReader
+		previous []byte
+	)
+
+	opened = newSegmentReader(data, aead.NonceSize()+encryptionSegmentSize+aead.Overhead(),
+		func(segment []byte, index uint32, last bool) error {
+			if len(segment) < aead.NonceSize() {
+				return fmt.Errorf("%w: segment %d is too short", ErrDecryptionFailed, index)
+			}
+
+			nonce, sealed := segment[:aead.NonceSize()], segment[aead.NonceSize():]
+
+			plain, err := aead.Open(nil, nonce, sealed, segmentAAD(previous, index, last))
+			if err != nil {
+				return fmt.Errorf("%w: segment %d: %w", ErrDecryptionFailed, index, err)
+			}
+
+			opened.output.Write(plain)
+			previous = bytes.Clone(nonce)
+
+			return nil
+		})
+
+	return opened, nil
+}
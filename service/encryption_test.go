@@ -0,0 +1,145 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+	"github.com/jakobmoellerdev/octi-sync-server/service/memory"
+)
+
+func masterKey(t *testing.T, id string) string {
+	t.Helper()
+
+	key := make([]byte, service.MasterKeySize)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatal(err)
+	}
+
+	return id + ":" + base64.StdEncoding.EncodeToString(key)
+}
+
+func TestEnvelope(t *testing.T) {
+	t.Parallel()
+	assertions := assert.New(t)
+	ctx := context.Background()
+
+	oldKey := masterKey(t, "old")
+
+	keyring, err := service.ParseKeyring(oldKey)
+	assertions.NoError(err)
+
+	dataKeys := memory.NewDataKeys()
+	envelope := &service.Envelope{Keyring: keyring, DataKeys: dataKeys}
+	account := service.NewBaseAccount("encryption", time.Now())
+
+	// larger than a segment so that the data is encrypted over several segments
+	data := bytes.Repeat([]byte("secret module data "), 10000)
+
+	encrypted, keyID, err := envelope.Encrypt(ctx, account, bytes.NewReader(data))
+	assertions.NoError(err)
+
+	ciphertext, err := io.ReadAll(encrypted)
+	assertions.NoError(err)
+	assertions.NotContains(string(ciphertext), "secret")
+
+	decrypt := func(envelope *service.Envelope, ciphertext []byte) ([]byte, error) {
+		decrypted, err := envelope.Decrypt(ctx, account, keyID, bytes.NewReader(ciphertext))
+		if err != nil {
+			return nil, err
+		}
+
+		return io.ReadAll(decrypted)
+	}
+
+	decrypted, err := decrypt(envelope, ciphertext)
+	assertions.NoError(err)
+	assertions.Equal(data, decrypted)
+
+	// the same data is encrypted to the same ciphertext, so that encrypted modules are still deduplicated
+	again, _, err := envelope.Encrypt(ctx, account, bytes.NewReader(data))
+	assertions.NoError(err)
+
+	againCiphertext, err := io.ReadAll(again)
+	assertions.NoError(err)
+	assertions.Equal(ciphertext, againCiphertext)
+
+	changed := bytes.Clone(data)
+	changed[0] = '!'
+	other, _, err := envelope.Encrypt(ctx, account, bytes.NewReader(changed))
+	assertions.NoError(err)
+
+	otherCiphertext, err := io.ReadAll(other)
+	assertions.NoError(err)
+	assertions.NotEqual(ciphertext, otherCiphertext)
+
+	// segments of other data sealed with the same data key are rejected
+	mixed := append(bytes.Clone(ciphertext[:len(ciphertext)/2]), otherCiphertext[len(otherCiphertext)/2:]...)
+	_, err = decrypt(envelope, mixed)
+	assertions.ErrorIs(err, service.ErrDecryptionFailed)
+
+	// tampered or truncated data is never returned
+	tampered := bytes.Clone(ciphertext)
+	tampered[len(tampered)/2] ^= 1
+	_, err = decrypt(envelope, tampered)
+	assertions.ErrorIs(err, service.ErrDecryptionFailed)
+
+	_, err = decrypt(envelope, ciphertext[:len(ciphertext)-100])
+	assertions.ErrorIs(err, service.ErrDecryptionFailed)
+
+	// data encrypted in the layout of earlier versions is rejected instead of misread
+	previous := bytes.Clone(ciphertext)
+	previous[0] = 1
+	_, err = decrypt(envelope, previous)
+	assertions.ErrorIs(err, service.ErrDecryptionFailed)
+
+	// other accounts cannot decrypt the data
+	_, err = envelope.Decrypt(ctx, service.NewBaseAccount("other", time.Now()), keyID, bytes.NewReader(ciphertext))
+	assertions.ErrorIs(err, service.ErrNoDataKey)
+
+	// after rotating the master key, data keys are rewrapped without re-encrypting the data
+	newKey := masterKey(t, "new")
+
+	onlyNew, err := service.ParseKeyring(newKey)
+	assertions.NoError(err)
+
+	_, err = decrypt(&service.Envelope{Keyring: onlyNew, DataKeys: dataKeys}, ciphertext)
+	assertions.ErrorIs(err, service.ErrUnknownMasterKey)
+
+	rotated, err := service.ParseKeyring(newKey + "\n" + oldKey)
+	assertions.NoError(err)
+	assertions.Equal("new", rotated.Active())
+
+	rewrapped, err := (&service.Envelope{Keyring: rotated, DataKeys: dataKeys}).RewrapDataKeys(ctx)
+	assertions.NoError(err)
+	assertions.Equal(1, rewrapped)
+
+	decrypted, err = decrypt(&service.Envelope{Keyring: onlyNew, DataKeys: dataKeys}, ciphertext)
+	assertions.NoError(err)
+	assertions.Equal(data, decrypted)
+}
+
+func TestParseKeyring(t *testing.T) {
+	t.Parallel()
+	assertions := assert.New(t)
+
+	keyring, err := service.ParseKeyring("# rotated 2024-06\n" + masterKey(t, "a") + ", " + masterKey(t, "b"))
+	assertions.NoError(err)
+	assertions.Equal("a", keyring.Active())
+
+	for _, invalid := range []string{
+		"", "# no keys", "a", ":" + base64.StdEncoding.EncodeToString(make([]byte, service.MasterKeySize)),
+		"a:" + base64.StdEncoding.EncodeToString(make([]byte, 16)), "a:not-base64",
+		masterKey(t, "a") + "," + masterKey(t, "a"),
+	} {
+		_, err := service.ParseKeyring(invalid)
+		assertions.ErrorIs(err, service.ErrInvalidMasterKey, invalid)
+	}
+}
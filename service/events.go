@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+// EventBuffer is the amount of events buffered for a subscriber that does not keep up with its account.
+const EventBuffer = 16
+
+type EventType string
+
+//goland:noinspection ALL
+const (
+	EventModuleChanged EventType = "module-changed"
+	EventDeviceAdded   EventType = "device-added"
+	EventDeviceRemoved EventType = "device-removed"
+	EventShareRedeemed EventType = "share-redeemed"
+)
+
+// Event notifies the devices of an account about a change in it.
+type Event struct {
+	Type EventType
+	// Device is the device the event originated from or, for device events, the added or removed device
+	Device DeviceID
+	// Module, Operation and Cursor are only present for EventModuleChanged, Cursor points into the ChangeFeed
+	Module    string
+	Operation ChangeOperation
+	Cursor    string
+	At        time.Time
+}
+
+//go:generate mockgen -source events.go -package mock -destination mock/events.go Events
+type Events interface {
+	// Publish delivers the event to all current subscribers of the account, regardless of the replica they are on.
+	Publish(ctx context.Context, account Account, event Event) error
+	// Subscribe delivers all events published for the account after it returned.
+	// The channel is closed once the context is done.
+	Subscribe(ctx context.Context, account Account) (<-chan Event, error)
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"path"
+	"time"
+)
+
+// ExpiryPolicy decides how long modules are kept after they were written. Modules expire together with their
+// metadata, history and usage, every write of a module starts its expiry anew.
+type ExpiryPolicy struct {
+	// Default is the time modules are kept after their last write, they never expire if 0
+	Default time.Duration `yaml:"default"`
+	// Max caps the time clients request modules to be kept, requests are not capped if 0
+	Max time.Duration `yaml:"max"`
+	// Patterns override Default for modules whose name matches the pattern, e.g. clipboard-*,
+	// the longest matching pattern wins. Modules matching a pattern of 0 never expire.
+	Patterns map[string]time.Duration `yaml:"patterns"`
+}
+
+// ExpiresIn returns how long the module is kept after a write, 0 if it never expires. The time requested by the
+// client takes precedence over the defaults of the operator, but is capped by Max. Nothing is requested with 0.
+func (p ExpiryPolicy) ExpiresIn(module string, requested time.Duration) time.Duration {
+	if requested <= 0 {
+		return p.defaultOf(module)
+	}
+
+	if p.Max > 0 && requested > p.Max {
+		return p.Max
+	}
+
+	return requested
+}
+
+// ExpiresAt returns when the module written now expires, the zero time if it never expires.
+// Expiry is tracked in seconds, so that backends with a resolution of seconds expire modules at the same time.
+func (p ExpiryPolicy) ExpiresAt(module string, requested time.Duration, now time.Time) time.Time {
+	expiresIn := p.ExpiresIn(module, requested)
+	if expiresIn <= 0 {
+		return time.Time{}
+	}
+
+	return now.Add(expiresIn).Truncate(time.Second)
+}
+
+func (p ExpiryPolicy) defaultOf(module string) time.Duration {
+	if expiresIn, found := p.Patterns[module]; found {
+		return expiresIn
+	}
+
+	expiresIn, best := p.Default, ""
+
+	for pattern, patternExpiresIn := range p.Patterns {
+		if matches, _ := path.Match(pattern, module); matches && len(pattern) > len(best) {
+			expiresIn, best = patternExpiresIn, pattern
+		}
+	}
+
+	return expiresIn
+}
+
+// Expired reports whether something expiring at the time has expired, the zero time never expires.
+func Expired(expiresAt, now time.Time) bool {
+	return !expiresAt.IsZero() && !now.Before(expiresAt)
+}
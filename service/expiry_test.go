@@ -0,0 +1,53 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+func TestExpiryPolicy(t *testing.T) {
+	t.Parallel()
+	assertions := assert.New(t)
+
+	policy := service.ExpiryPolicy{
+		Default: 30 * 24 * time.Hour,
+		Max:     7 * 24 * time.Hour,
+		Patterns: map[string]time.Duration{
+			"clipboard-*":       time.Hour,
+			"clipboard-pinned*": 0,
+			"sms":               24 * time.Hour,
+		},
+	}
+
+	for _, tc := range []struct {
+		module    string
+		requested time.Duration
+		expected  time.Duration
+	}{
+		{"settings", 0, 30 * 24 * time.Hour},
+		{"clipboard-1", 0, time.Hour},
+		// the longest matching pattern wins
+		{"clipboard-pinned-1", 0, 0},
+		{"sms", 0, 24 * time.Hour},
+		{"sms-1", 0, 30 * 24 * time.Hour},
+		{"settings", time.Minute, time.Minute},
+		{"clipboard-1", 2 * time.Hour, 2 * time.Hour},
+		// requests are capped, but the defaults of the operator are not
+		{"settings", 365 * 24 * time.Hour, 7 * 24 * time.Hour},
+		{"clipboard-pinned-1", 365 * 24 * time.Hour, 7 * 24 * time.Hour},
+	} {
+		assertions.Equal(tc.expected, policy.ExpiresIn(tc.module, tc.requested), tc.module)
+	}
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 500, time.UTC)
+	assertions.Equal(now.Add(time.Hour).Truncate(time.Second), policy.ExpiresAt("clipboard-1", 0, now))
+	assertions.True(policy.ExpiresAt("clipboard-pinned-1", 0, now).IsZero())
+
+	assertions.False(service.Expired(time.Time{}, now))
+	assertions.False(service.Expired(now.Add(time.Second), now))
+	assertions.True(service.Expired(now, now))
+}
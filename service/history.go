@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// DefaultHistoryVersions is the amount of versions kept per module if not configured otherwise.
+const DefaultHistoryVersions = 10
+
+var ErrVersionNotFound = errors.New("module version not found")
+
+//go:generate mockgen -source history.go -package mock -destination mock/history.go History
+type History interface {
+	// Record keeps the content the module is stored with as the version of the metadata
+	// and drops versions that are no longer retained. The content is shared with the stored module,
+	// so the module has to be locked until the version is recorded.
+	Record(ctx context.Context, meta Metadata) error
+	// Versions returns the metadata of all retained versions of a module, newest first.
+	Versions(ctx context.Context, id MetadataID) ([]Metadata, error)
+	// Get returns the content of a retained version of a module.
+	Get(ctx context.Context, id MetadataID, version int64) (Module, Metadata, error)
+	// DeleteByPrefix drops the versions of all modules whose id starts with the prefix.
+	DeleteByPrefix(ctx context.Context, prefix string) error
+	// Delete drops all versions of the module.
+	Delete(ctx context.Context, id MetadataID) error
+}
+
+// HistoryRetention decides which versions of a module are kept.
+type HistoryRetention struct {
+	// Versions is the maximum amount of versions kept per module, DefaultHistoryVersions is used if 0 or less
+	Versions int `yaml:"versions"`
+	// Period drops versions that were replaced longer ago, versions are kept regardless of age if 0
+	Period time.Duration `yaml:"period"`
+}
+
+// Retained filters the versions that are kept from the given versions, which have to be sorted newest first.
+// The newest version is always kept as it is the current content of the module.
+func (r HistoryRetention) Retained(versions []Metadata, now time.Time) (kept, dropped []Metadata) {
+	limit := r.Versions
+	if limit <= 0 {
+		limit = DefaultHistoryVersions
+	}
+
+	for i, version := range versions {
+		// a version is replaced when its successor was written
+		replacedLongAgo := i > 0 && r.Period > 0 &&
+			now.Sub(time.Time(versions[i-1].GetModifiedAt())) > r.Period
+
+		if i == 0 || (i < limit && !replacedLongAgo) {
+			kept = append(kept, version)
+		} else {
+			dropped = append(dropped, version)
+		}
+	}
+
+	return kept, dropped
+}
@@ -0,0 +1,36 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+func TestHistoryRetention_Retained(t *testing.T) {
+	t.Parallel()
+	assertions := assert.New(t)
+
+	now := time.Now()
+	versions := []service.Metadata{
+		service.NewVersionedMetadata("m", now.Add(-48*time.Hour), "", 4),
+		service.NewVersionedMetadata("m", now.Add(-49*time.Hour), "", 3),
+		service.NewVersionedMetadata("m", now.Add(-72*time.Hour), "", 2),
+		service.NewVersionedMetadata("m", now.Add(-96*time.Hour), "", 1),
+	}
+
+	kept, dropped := service.HistoryRetention{Versions: 3}.Retained(versions, now)
+	assertions.Equal(versions[:3], kept)
+	assertions.Equal(versions[3:], dropped)
+
+	// version 3 was replaced by version 4 48 hours ago, version 2 by version 3 49 hours ago
+	kept, dropped = service.HistoryRetention{Period: 48*time.Hour + time.Minute}.Retained(versions, now)
+	assertions.Equal(versions[:2], kept)
+	assertions.Equal(versions[2:], dropped)
+
+	kept, dropped = service.HistoryRetention{Versions: 1, Period: time.Nanosecond}.Retained(versions[:1], now)
+	assertions.Equal(versions[:1], kept)
+	assertions.Empty(dropped)
+}
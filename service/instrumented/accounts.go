@@ -0,0 +1,70 @@
+package instrumented
+
+import (
+	"context"
+
+	"github.com/jakobmoellerdev/octi-sync-server/metrics"
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+// Accounts records latency and errors of all account operations.
+type Accounts struct {
+	service.Accounts
+	*metrics.Metrics
+}
+
+func (a *Accounts) Find(ctx context.Context, username string) (service.Account, error) {
+	done := a.ObserveOperation("Accounts", "Find")
+	account, err := a.Accounts.Find(ctx, username)
+
+	done(err)
+
+	return account, err //nolint:wrapcheck
+}
+
+func (a *Accounts) Create(ctx context.Context, username string) (service.Account, error) {
+	done := a.ObserveOperation("Accounts", "Create")
+	account, err := a.Accounts.Create(ctx, username)
+
+	done(err)
+
+	return account, err //nolint:wrapcheck
+}
+
+// Sharing records latency and errors of all sharing operations as well as redeemed share codes.
+type Sharing struct {
+	service.Sharing
+	*metrics.Metrics
+}
+
+func (s *Sharing) Share(ctx context.Context, account service.Account) (service.ShareCode, error) {
+	done := s.ObserveOperation("Sharing", "Share")
+	code, err := s.Sharing.Share(ctx, account)
+
+	done(err)
+
+	return code, err //nolint:wrapcheck
+}
+
+func (s *Sharing) Shared(ctx context.Context, shareCode service.ShareCode) (service.Account, error) {
+	done := s.ObserveOperation("Sharing", "Shared")
+	account, err := s.Sharing.Shared(ctx, shareCode)
+
+	done(err)
+
+	return account, err //nolint:wrapcheck
+}
+
+// Revoke counts a share redemption on success, as share codes are revoked once they were used to register.
+func (s *Sharing) Revoke(ctx context.Context, shareCode service.ShareCode) error {
+	done := s.ObserveOperation("Sharing", "Revoke")
+	err := s.Sharing.Revoke(ctx, shareCode)
+
+	if err == nil {
+		s.ShareRedeemed()
+	}
+
+	done(err)
+
+	return err //nolint:wrapcheck
+}
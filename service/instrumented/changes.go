@@ -0,0 +1,34 @@
+package instrumented
+
+import (
+	"context"
+
+	"github.com/jakobmoellerdev/octi-sync-server/metrics"
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+// ChangeFeed records latency and errors of all change feed operations.
+type ChangeFeed struct {
+	service.ChangeFeed
+	*metrics.Metrics
+}
+
+func (c *ChangeFeed) Append(ctx context.Context, account service.Account, change service.Change) (string, error) {
+	done := c.ObserveOperation("ChangeFeed", "Append")
+	cursor, err := c.ChangeFeed.Append(ctx, account, change)
+
+	done(err)
+
+	return cursor, err //nolint:wrapcheck
+}
+
+func (c *ChangeFeed) Since(
+	ctx context.Context, account service.Account, cursor string, limit int,
+) ([]service.Change, error) {
+	done := c.ObserveOperation("ChangeFeed", "Since")
+	changes, err := c.ChangeFeed.Since(ctx, account, cursor, limit)
+
+	done(err)
+
+	return changes, err //nolint:wrapcheck
+}
@@ -0,0 +1,52 @@
+package instrumented
+
+import (
+	"context"
+
+	"github.com/jakobmoellerdev/octi-sync-server/metrics"
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+// DataKeys records latency and errors of all data key operations.
+type DataKeys struct {
+	service.DataKeys
+	*metrics.Metrics
+}
+
+func (k *DataKeys) Get(ctx context.Context, username string) (service.WrappedDataKey, error) {
+	done := k.ObserveOperation("DataKeys", "Get")
+	key, err := k.DataKeys.Get(ctx, username)
+
+	done(err)
+
+	return key, err //nolint:wrapcheck
+}
+
+func (k *DataKeys) Create(
+	ctx context.Context, username string, key service.WrappedDataKey,
+) (service.WrappedDataKey, error) {
+	done := k.ObserveOperation("DataKeys", "Create")
+	stored, err := k.DataKeys.Create(ctx, username, key)
+
+	done(err)
+
+	return stored, err //nolint:wrapcheck
+}
+
+func (k *DataKeys) Set(ctx context.Context, username string, key service.WrappedDataKey) error {
+	done := k.ObserveOperation("DataKeys", "Set")
+	err := k.DataKeys.Set(ctx, username, key)
+
+	done(err)
+
+	return err //nolint:wrapcheck
+}
+
+func (k *DataKeys) List(ctx context.Context) ([]string, error) {
+	done := k.ObserveOperation("DataKeys", "List")
+	usernames, err := k.DataKeys.List(ctx)
+
+	done(err)
+
+	return usernames, err //nolint:wrapcheck
+}
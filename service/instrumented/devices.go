@@ -0,0 +1,92 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"github.com/jakobmoellerdev/octi-sync-server/metrics"
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+// Devices records latency and errors of all device operations as well as device registrations.
+type Devices struct {
+	service.Devices
+	*metrics.Metrics
+}
+
+func (d *Devices) AddDevice(
+	ctx context.Context, account service.Account, id service.DeviceID, password string, info service.DeviceInfo,
+) (service.Device, error) {
+	done := d.ObserveOperation("Devices", "AddDevice")
+	device, err := d.Devices.AddDevice(ctx, account, id, password, info)
+
+	if err == nil {
+		d.Registered()
+	}
+
+	done(err)
+
+	return device, err //nolint:wrapcheck
+}
+
+func (d *Devices) GetDevices(
+	ctx context.Context, account service.Account,
+) (map[service.DeviceID]service.Device, error) {
+	done := d.ObserveOperation("Devices", "GetDevices")
+	devices, err := d.Devices.GetDevices(ctx, account)
+
+	done(err)
+
+	return devices, err //nolint:wrapcheck
+}
+
+func (d *Devices) GetDevice(
+	ctx context.Context, account service.Account, id service.DeviceID,
+) (service.Device, error) {
+	done := d.ObserveOperation("Devices", "GetDevice")
+	device, err := d.Devices.GetDevice(ctx, account, id)
+
+	done(err)
+
+	return device, err //nolint:wrapcheck
+}
+
+func (d *Devices) DeleteDevice(ctx context.Context, account service.Account, id service.DeviceID) error {
+	done := d.ObserveOperation("Devices", "DeleteDevice")
+	err := d.Devices.DeleteDevice(ctx, account, id)
+
+	done(err)
+
+	return err //nolint:wrapcheck
+}
+
+func (d *Devices) RenameDevice(
+	ctx context.Context, account service.Account, id service.DeviceID, name string,
+) (service.Device, error) {
+	done := d.ObserveOperation("Devices", "RenameDevice")
+	device, err := d.Devices.RenameDevice(ctx, account, id, name)
+
+	done(err)
+
+	return device, err //nolint:wrapcheck
+}
+
+func (d *Devices) MarkSeen(ctx context.Context, account service.Account, id service.DeviceID, at time.Time) error {
+	done := d.ObserveOperation("Devices", "MarkSeen")
+	err := d.Devices.MarkSeen(ctx, account, id, at)
+
+	done(err)
+
+	return err //nolint:wrapcheck
+}
+
+func (d *Devices) ApproveDevice(
+	ctx context.Context, account service.Account, id service.DeviceID,
+) (service.Device, error) {
+	done := d.ObserveOperation("Devices", "ApproveDevice")
+	device, err := d.Devices.ApproveDevice(ctx, account, id)
+
+	done(err)
+
+	return device, err //nolint:wrapcheck
+}
@@ -0,0 +1,32 @@
+package instrumented
+
+import (
+	"context"
+
+	"github.com/jakobmoellerdev/octi-sync-server/metrics"
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+// Events records latency and errors of publishing events and setting up subscriptions.
+type Events struct {
+	service.Events
+	*metrics.Metrics
+}
+
+func (e *Events) Publish(ctx context.Context, account service.Account, event service.Event) error {
+	done := e.ObserveOperation("Events", "Publish")
+	err := e.Events.Publish(ctx, account, event)
+
+	done(err)
+
+	return err //nolint:wrapcheck
+}
+
+func (e *Events) Subscribe(ctx context.Context, account service.Account) (<-chan service.Event, error) {
+	done := e.ObserveOperation("Events", "Subscribe")
+	events, err := e.Events.Subscribe(ctx, account)
+
+	done(err)
+
+	return events, err //nolint:wrapcheck
+}
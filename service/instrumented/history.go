@@ -0,0 +1,61 @@
+package instrumented
+
+import (
+	"context"
+
+	"github.com/jakobmoellerdev/octi-sync-server/metrics"
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+// History records latency and errors of all module history operations.
+type History struct {
+	service.History
+	*metrics.Metrics
+}
+
+func (h *History) Record(ctx context.Context, meta service.Metadata) error {
+	done := h.ObserveOperation("History", "Record")
+	err := h.History.Record(ctx, meta)
+
+	done(err)
+
+	return err //nolint:wrapcheck
+}
+
+func (h *History) Versions(ctx context.Context, id service.MetadataID) ([]service.Metadata, error) {
+	done := h.ObserveOperation("History", "Versions")
+	versions, err := h.History.Versions(ctx, id)
+
+	done(err)
+
+	return versions, err //nolint:wrapcheck
+}
+
+func (h *History) Get(
+	ctx context.Context, id service.MetadataID, version int64,
+) (service.Module, service.Metadata, error) {
+	done := h.ObserveOperation("History", "Get")
+	module, metadata, err := h.History.Get(ctx, id, version)
+
+	done(err)
+
+	return module, metadata, err //nolint:wrapcheck
+}
+
+func (h *History) Delete(ctx context.Context, id service.MetadataID) error {
+	done := h.ObserveOperation("History", "Delete")
+	err := h.History.Delete(ctx, id)
+
+	done(err)
+
+	return err //nolint:wrapcheck
+}
+
+func (h *History) DeleteByPrefix(ctx context.Context, prefix string) error {
+	done := h.ObserveOperation("History", "DeleteByPrefix")
+	err := h.History.DeleteByPrefix(ctx, prefix)
+
+	done(err)
+
+	return err //nolint:wrapcheck
+}
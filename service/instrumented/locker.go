@@ -0,0 +1,23 @@
+package instrumented
+
+import (
+	"context"
+
+	"github.com/jakobmoellerdev/octi-sync-server/metrics"
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+// Locker records how long it takes to acquire locks and how often acquiring them fails.
+type Locker struct {
+	service.Locker
+	*metrics.Metrics
+}
+
+func (l *Locker) Lock(ctx context.Context, key string) (service.Unlock, error) {
+	done := l.ObserveOperation("Locker", "Lock")
+	unlock, err := l.Locker.Lock(ctx, key)
+
+	done(err)
+
+	return unlock, err //nolint:wrapcheck
+}
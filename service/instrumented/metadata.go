@@ -0,0 +1,52 @@
+package instrumented
+
+import (
+	"context"
+
+	"github.com/jakobmoellerdev/octi-sync-server/metrics"
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+// MetadataProvider records latency and errors of all metadata operations.
+type MetadataProvider struct {
+	service.MetadataProvider
+	*metrics.Metrics
+}
+
+func (m *MetadataProvider) Get(ctx context.Context, id service.MetadataID) (service.Metadata, error) {
+	done := m.ObserveOperation("MetadataProvider", "Get")
+	metadata, err := m.MetadataProvider.Get(ctx, id)
+
+	done(err)
+
+	return metadata, err //nolint:wrapcheck
+}
+
+func (m *MetadataProvider) Set(ctx context.Context, meta service.Metadata) error {
+	done := m.ObserveOperation("MetadataProvider", "Set")
+	err := m.MetadataProvider.Set(ctx, meta)
+
+	done(err)
+
+	return err //nolint:wrapcheck
+}
+
+func (m *MetadataProvider) GetMany(ctx context.Context, ids []service.MetadataID) ([]service.Metadata, error) {
+	done := m.ObserveOperation("MetadataProvider", "GetMany")
+	metadata, err := m.MetadataProvider.GetMany(ctx, ids)
+
+	done(err)
+
+	return metadata, err //nolint:wrapcheck
+}
+
+func (m *MetadataProvider) List(
+	ctx context.Context, opts service.ListOptions,
+) ([]service.Metadata, string, error) {
+	done := m.ObserveOperation("MetadataProvider", "List")
+	metadata, next, err := m.MetadataProvider.List(ctx, opts)
+
+	done(err)
+
+	return metadata, next, err //nolint:wrapcheck
+}
@@ -0,0 +1,160 @@
+package instrumented
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/jakobmoellerdev/octi-sync-server/metrics"
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+// Modules records latency, errors and transferred bytes of all module operations.
+type Modules struct {
+	service.Modules
+	*metrics.Metrics
+}
+
+func (m *Modules) Set(ctx context.Context, name string, module service.Module, expiresAt time.Time) error {
+	done := m.ObserveOperation("Modules", "Set")
+	written := &countingModule{Module: module}
+	err := m.Modules.Set(ctx, name, written, expiresAt)
+
+	if err == nil {
+		m.ModuleBytes(metrics.ModuleBytesWritten, written.count)
+	}
+
+	done(err)
+
+	return err //nolint:wrapcheck
+}
+
+func (m *Modules) Get(ctx context.Context, name string) (service.Module, error) {
+	done := m.ObserveOperation("Modules", "Get")
+	module, err := m.Modules.Get(ctx, name)
+
+	done(err)
+
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return &countingModule{Module: module, onEOF: func(count int) {
+		m.ModuleBytes(metrics.ModuleBytesRead, count)
+	}}, nil
+}
+
+func (m *Modules) GetRange(ctx context.Context, name string, offset, length int64) (service.Module, error) {
+	done := m.ObserveOperation("Modules", "GetRange")
+	module, err := m.Modules.GetRange(ctx, name, offset, length)
+
+	done(err)
+
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return &countingModule{Module: module, onEOF: func(count int) {
+		m.ModuleBytes(metrics.ModuleBytesRead, count)
+	}}, nil
+}
+
+func (m *Modules) SetMany(
+	ctx context.Context, modules map[string]service.Module, expiresAt map[string]time.Time,
+) error {
+	done := m.ObserveOperation("Modules", "SetMany")
+	counted := make(map[string]service.Module, len(modules))
+	written := make([]*countingModule, 0, len(modules))
+
+	for name, module := range modules {
+		module := &countingModule{Module: module}
+		counted[name] = module
+		written = append(written, module)
+	}
+
+	err := m.Modules.SetMany(ctx, counted, expiresAt)
+
+	if err == nil {
+		for _, module := range written {
+			m.ModuleBytes(metrics.ModuleBytesWritten, module.count)
+		}
+	}
+
+	done(err)
+
+	return err //nolint:wrapcheck
+}
+
+func (m *Modules) GetMany(ctx context.Context, names []string) ([]service.Module, error) {
+	done := m.ObserveOperation("Modules", "GetMany")
+	modules, err := m.Modules.GetMany(ctx, names)
+
+	done(err)
+
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	for i, module := range modules {
+		modules[i] = &countingModule{Module: module, onEOF: func(count int) {
+			m.ModuleBytes(metrics.ModuleBytesRead, count)
+		}}
+	}
+
+	return modules, nil
+}
+
+func (m *Modules) DeleteByPattern(ctx context.Context, pattern string) error {
+	done := m.ObserveOperation("Modules", "DeleteByPattern")
+	err := m.Modules.DeleteByPattern(ctx, pattern)
+
+	done(err)
+
+	return err //nolint:wrapcheck
+}
+
+func (m *Modules) Delete(ctx context.Context, name string) error {
+	done := m.ObserveOperation("Modules", "Delete")
+	err := m.Modules.Delete(ctx, name)
+
+	done(err)
+
+	return err //nolint:wrapcheck
+}
+
+func (m *Modules) List(ctx context.Context, opts service.ListOptions) ([]service.ModuleInfo, string, error) {
+	done := m.ObserveOperation("Modules", "List")
+	infos, next, err := m.Modules.List(ctx, opts)
+
+	done(err)
+
+	return infos, next, err //nolint:wrapcheck
+}
+
+// countingModule counts the bytes read from the underlying module.
+type countingModule struct {
+	service.Module
+	reader io.Reader
+	count  int
+	onEOF  func(count int)
+}
+
+func (m *countingModule) Raw() io.Reader {
+	if m.reader == nil {
+		m.reader = m.Module.Raw()
+	}
+
+	return m
+}
+
+func (m *countingModule) Read(p []byte) (int, error) {
+	n, err := m.reader.Read(p)
+	m.count += n
+
+	if err == io.EOF && m.onEOF != nil {
+		m.onEOF(m.count)
+		m.onEOF = nil
+	}
+
+	return n, err //nolint:wrapcheck
+}
@@ -0,0 +1,43 @@
+package instrumented_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jakobmoellerdev/octi-sync-server/metrics"
+	"github.com/jakobmoellerdev/octi-sync-server/service/instrumented"
+	"github.com/jakobmoellerdev/octi-sync-server/service/memory"
+)
+
+func TestModules_CountsBytes(t *testing.T) {
+	t.Parallel()
+	assertions := assert.New(t)
+
+	recorder := metrics.New()
+	modules := &instrumented.Modules{Modules: memory.NewModules(), Metrics: recorder}
+	ctx := context.Background()
+
+	assertions.NoError(modules.Set(ctx, "test", memory.ModuleFromBytes([]byte("0123456789")), time.Time{}))
+
+	module, err := modules.Get(ctx, "test")
+	assertions.NoError(err)
+
+	data, err := io.ReadAll(module.Raw())
+	assertions.NoError(err)
+	assertions.Equal("0123456789", string(data))
+
+	rec := httptest.NewRecorder()
+	recorder.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, metrics.Path, nil))
+	body := rec.Body.String()
+
+	assertions.Contains(body, `octi_module_bytes_total{direction="written"} 10`)
+	assertions.Contains(body, `octi_module_bytes_total{direction="read"} 10`)
+	assertions.Contains(body, `octi_backend_operation_duration_seconds_count{method="Set",service="Modules"} 1`)
+	assertions.Contains(body, `octi_backend_operation_duration_seconds_count{method="Get",service="Modules"} 1`)
+}
@@ -0,0 +1,46 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"github.com/jakobmoellerdev/octi-sync-server/metrics"
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+// Presence records latency and errors of all device presence operations.
+type Presence struct {
+	service.Presence
+	*metrics.Metrics
+}
+
+func (p *Presence) Connected(
+	ctx context.Context, account service.Account, device service.DeviceID, connection string, until time.Time,
+) error {
+	done := p.ObserveOperation("Presence", "Connected")
+	err := p.Presence.Connected(ctx, account, device, connection, until)
+
+	done(err)
+
+	return err //nolint:wrapcheck
+}
+
+func (p *Presence) Disconnected(
+	ctx context.Context, account service.Account, device service.DeviceID, connection string,
+) error {
+	done := p.ObserveOperation("Presence", "Disconnected")
+	err := p.Presence.Disconnected(ctx, account, device, connection)
+
+	done(err)
+
+	return err //nolint:wrapcheck
+}
+
+func (p *Presence) Online(ctx context.Context, account service.Account) (map[service.DeviceID]bool, error) {
+	done := p.ObserveOperation("Presence", "Online")
+	online, err := p.Presence.Online(ctx, account)
+
+	done(err)
+
+	return online, err //nolint:wrapcheck
+}
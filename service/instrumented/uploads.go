@@ -0,0 +1,63 @@
+package instrumented
+
+import (
+	"context"
+
+	"github.com/jakobmoellerdev/octi-sync-server/metrics"
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+// Uploads records latency and errors of all resumable upload operations.
+type Uploads struct {
+	service.Uploads
+	*metrics.Metrics
+}
+
+func (u *Uploads) Create(
+	ctx context.Context, account service.Account, upload service.Upload,
+) (service.Upload, error) {
+	done := u.ObserveOperation("Uploads", "Create")
+	upload, err := u.Uploads.Create(ctx, account, upload)
+
+	done(err)
+
+	return upload, err //nolint:wrapcheck
+}
+
+func (u *Uploads) Get(ctx context.Context, account service.Account, id string) (service.Upload, error) {
+	done := u.ObserveOperation("Uploads", "Get")
+	upload, err := u.Uploads.Get(ctx, account, id)
+
+	done(err)
+
+	return upload, err //nolint:wrapcheck
+}
+
+func (u *Uploads) Append(
+	ctx context.Context, account service.Account, id string, offset int64, chunk []byte,
+) (service.Upload, error) {
+	done := u.ObserveOperation("Uploads", "Append")
+	upload, err := u.Uploads.Append(ctx, account, id, offset, chunk)
+
+	done(err)
+
+	return upload, err //nolint:wrapcheck
+}
+
+func (u *Uploads) Data(ctx context.Context, account service.Account, id string) (service.Module, error) {
+	done := u.ObserveOperation("Uploads", "Data")
+	module, err := u.Uploads.Data(ctx, account, id)
+
+	done(err)
+
+	return module, err //nolint:wrapcheck
+}
+
+func (u *Uploads) Delete(ctx context.Context, account service.Account, id string) error {
+	done := u.ObserveOperation("Uploads", "Delete")
+	err := u.Uploads.Delete(ctx, account, id)
+
+	done(err)
+
+	return err //nolint:wrapcheck
+}
@@ -0,0 +1,44 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"github.com/jakobmoellerdev/octi-sync-server/metrics"
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+// Usage records latency and errors of all account usage operations.
+type Usage struct {
+	service.Usage
+	*metrics.Metrics
+}
+
+func (u *Usage) Record(
+	ctx context.Context, account service.Account, module string, size int64, expiresAt time.Time,
+) error {
+	done := u.ObserveOperation("Usage", "Record")
+	err := u.Usage.Record(ctx, account, module, size, expiresAt)
+
+	done(err)
+
+	return err //nolint:wrapcheck
+}
+
+func (u *Usage) Remove(ctx context.Context, account service.Account, modules ...string) error {
+	done := u.ObserveOperation("Usage", "Remove")
+	err := u.Usage.Remove(ctx, account, modules...)
+
+	done(err)
+
+	return err //nolint:wrapcheck
+}
+
+func (u *Usage) Get(ctx context.Context, account service.Account) (service.AccountUsage, error) {
+	done := u.ObserveOperation("Usage", "Get")
+	usage, err := u.Usage.Get(ctx, account)
+
+	done(err)
+
+	return usage, err //nolint:wrapcheck
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultListLimit is used for listings that do not request a specific page size.
+const DefaultListLimit = 100
+
+var ErrInvalidCursor = errors.New("list cursor is invalid")
+
+// ListOptions filters and paginates listings of identifiers.
+type ListOptions struct {
+	// Prefix only lists identifiers starting with it
+	Prefix string
+	// Cursor continues a previous listing, it is returned as next cursor of the previous page
+	Cursor string
+	// Limit is the maximum amount of items on a page, DefaultListLimit is used if 0 or less
+	Limit int
+}
+
+// ModuleInfo describes a stored module without its data.
+type ModuleInfo struct {
+	ID   string
+	Size int64
+	// ExpiresAt is zero if the module does not expire
+	ExpiresAt time.Time
+}
+
+// Paginate sorts the given identifiers and returns the page described by the options.
+// The next cursor is empty if there are no more pages.
+// Identifiers not matching the prefix are expected to be filtered by the backend already.
+func Paginate(ids []string, opts ListOptions) ([]string, string, error) {
+	after, err := decodeCursor(opts.Cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = DefaultListLimit
+	}
+
+	sort.Strings(ids)
+
+	start := sort.SearchStrings(ids, after)
+	if start < len(ids) && ids[start] == after {
+		start++
+	}
+
+	page := ids[start:min(start+limit, len(ids))]
+
+	next := ""
+	if start+limit < len(ids) {
+		next = encodeCursor(page[len(page)-1])
+	}
+
+	return page, next, nil
+}
+
+// TrimPrefix removes the prefix of all identifiers that have it and drops the others.
+func TrimPrefix(ids []string, prefix string) []string {
+	trimmed := make([]string, 0, len(ids))
+
+	for _, id := range ids {
+		if strings.HasPrefix(id, prefix) {
+			trimmed = append(trimmed, strings.TrimPrefix(id, prefix))
+		}
+	}
+
+	return trimmed
+}
+
+func encodeCursor(id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(id))
+}
+
+func decodeCursor(cursor string) (string, error) {
+	id, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	return string(id), nil
+}
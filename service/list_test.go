@@ -0,0 +1,45 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+func TestPaginate(t *testing.T) {
+	t.Parallel()
+	assertions := assert.New(t)
+
+	ids := []string{"c", "a", "e", "b", "d"}
+
+	page, next, err := service.Paginate(ids, service.ListOptions{Limit: 2})
+	assertions.NoError(err)
+	assertions.Equal([]string{"a", "b"}, page)
+	assertions.NotEmpty(next)
+
+	page, next, err = service.Paginate(ids, service.ListOptions{Limit: 2, Cursor: next})
+	assertions.NoError(err)
+	assertions.Equal([]string{"c", "d"}, page)
+	assertions.NotEmpty(next)
+
+	page, next, err = service.Paginate(ids, service.ListOptions{Limit: 2, Cursor: next})
+	assertions.NoError(err)
+	assertions.Equal([]string{"e"}, page)
+	assertions.Empty(next)
+
+	page, next, err = service.Paginate(ids, service.ListOptions{})
+	assertions.NoError(err)
+	assertions.Len(page, len(ids))
+	assertions.Empty(next)
+
+	_, _, err = service.Paginate(ids, service.ListOptions{Cursor: "not base64!"})
+	assertions.ErrorIs(err, service.ErrInvalidCursor)
+}
+
+func TestTrimPrefix(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"a", "b"}, service.TrimPrefix([]string{"x:a", "y:c", "x:b"}, "x:"))
+}
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+	"errors"
+)
+
+//go:generate mockgen -source locker.go -package mock -destination mock/locker.go Locker
+type Locker interface {
+	// Lock blocks until the lock for the key is held or the context is done.
+	// The returned Unlock has to be called to release the lock again.
+	Lock(ctx context.Context, key string) (Unlock, error)
+}
+
+// Unlock releases a lock acquired through a Locker.
+type Unlock func(ctx context.Context) error
+
+var ErrLockNotAcquired = errors.New("lock could not be acquired")
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/rs/zerolog"
 
 	"github.com/jakobmoellerdev/octi-sync-server/service"
 )
@@ -25,7 +26,7 @@ type Accounts struct {
 	shares   map[string][]string
 }
 
-func (m *Accounts) Create(_ context.Context, username string) (service.Account, error) {
+func (m *Accounts) Create(ctx context.Context, username string) (service.Account, error) {
 	m.sync.Lock()
 	defer m.sync.Unlock()
 
@@ -35,6 +36,8 @@ func (m *Accounts) Create(_ context.Context, username string) (service.Account,
 	createdAt, _ := account.CreatedAt().MarshalBinary()
 	m.accounts[username] = createdAt
 
+	zerolog.Ctx(ctx).Info().Str("account", username).Msg("account created")
+
 	return account, nil
 }
 
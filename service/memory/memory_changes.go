@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+func NewChangeFeed(retain int) *ChangeFeed {
+	if retain <= 0 {
+		retain = service.DefaultRetainedChanges
+	}
+
+	return &ChangeFeed{sync.RWMutex{}, retain, 0, make(map[string][]service.Change)}
+}
+
+// ChangeFeed numbers changes with a sequence shared by all accounts.
+type ChangeFeed struct {
+	sync     sync.RWMutex
+	retain   int
+	sequence uint64
+	changes  map[string][]service.Change
+}
+
+func (m *ChangeFeed) Append(_ context.Context, account service.Account, change service.Change) (string, error) {
+	m.sync.Lock()
+	defer m.sync.Unlock()
+
+	m.sequence++
+	change.Cursor = strconv.FormatUint(m.sequence, 10)
+
+	changes := append(m.changes[account.Username()], change)
+	if len(changes) > m.retain {
+		changes = changes[len(changes)-m.retain:]
+	}
+
+	m.changes[account.Username()] = changes
+
+	return change.Cursor, nil
+}
+
+func (m *ChangeFeed) Since(
+	_ context.Context, account service.Account, cursor string, limit int,
+) ([]service.Change, error) {
+	var after uint64
+
+	if cursor != "" {
+		var err error
+		if after, err = strconv.ParseUint(cursor, 10, 64); err != nil {
+			return nil, fmt.Errorf("%w: %w", service.ErrInvalidCursor, err)
+		}
+	}
+
+	if limit <= 0 {
+		limit = service.DefaultListLimit
+	}
+
+	m.sync.RLock()
+	defer m.sync.RUnlock()
+
+	retained := m.changes[account.Username()]
+
+	// cursors were created from the sequence and always parse
+	if cursor != "" && len(retained) > 0 {
+		if oldest, _ := strconv.ParseUint(retained[0].Cursor, 10, 64); after < oldest {
+			return nil, fmt.Errorf("%w: %s is older than %s", service.ErrCursorExpired, cursor, retained[0].Cursor)
+		}
+	}
+
+	changes := make([]service.Change, 0, limit)
+
+	for _, change := range retained {
+		if len(changes) == limit {
+			break
+		}
+
+		if sequence, _ := strconv.ParseUint(change.Cursor, 10, 64); sequence > after {
+			changes = append(changes, change)
+		}
+	}
+
+	return changes, nil
+}
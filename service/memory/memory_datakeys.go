@@ -0,0 +1,70 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+func NewDataKeys() *DataKeys {
+	return &DataKeys{sync.RWMutex{}, make(map[string]service.WrappedDataKey)}
+}
+
+// DataKeys keeps the wrapped data key of every account.
+type DataKeys struct {
+	sync sync.RWMutex
+	keys map[string]service.WrappedDataKey
+}
+
+func (m *DataKeys) Get(_ context.Context, username string) (service.WrappedDataKey, error) {
+	m.sync.RLock()
+	defer m.sync.RUnlock()
+
+	key, found := m.keys[username]
+	if !found {
+		return service.WrappedDataKey{}, fmt.Errorf("%w: %s", service.ErrNoDataKey, username)
+	}
+
+	return key, nil
+}
+
+func (m *DataKeys) Create(
+	_ context.Context, username string, key service.WrappedDataKey,
+) (service.WrappedDataKey, error) {
+	m.sync.Lock()
+	defer m.sync.Unlock()
+
+	if stored, found := m.keys[username]; found {
+		return stored, nil
+	}
+
+	m.keys[username] = key
+
+	return key, nil
+}
+
+func (m *DataKeys) Set(_ context.Context, username string, key service.WrappedDataKey) error {
+	m.sync.Lock()
+	defer m.sync.Unlock()
+
+	m.keys[username] = key
+
+	return nil
+}
+
+func (m *DataKeys) List(_ context.Context) ([]string, error) {
+	m.sync.RLock()
+	defer m.sync.RUnlock()
+
+	usernames := make([]string, 0, len(m.keys))
+	for username := range m.keys {
+		usernames = append(usernames, username)
+	}
+
+	sort.Strings(usernames)
+
+	return usernames, nil
+}
@@ -5,17 +5,20 @@ import (
 	"crypto/sha256"
 	"fmt"
 	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
 
 	"github.com/jakobmoellerdev/octi-sync-server/service"
 )
 
 func NewDevices() *Devices {
-	return &Devices{sync.RWMutex{}, make(map[string]map[service.DeviceID]service.Device)}
+	return &Devices{sync.RWMutex{}, make(map[string]map[service.DeviceID]*service.BaseDevice)}
 }
 
 type Devices struct {
 	sync    sync.RWMutex
-	devices map[string]map[service.DeviceID]service.Device
+	devices map[string]map[service.DeviceID]*service.BaseDevice
 }
 
 func (r *Devices) hashPassword(password string) string {
@@ -23,16 +26,29 @@ func (r *Devices) hashPassword(password string) string {
 }
 
 func (r *Devices) AddDevice(
-	_ context.Context, account service.Account, id service.DeviceID, password string,
+	ctx context.Context, account service.Account, id service.DeviceID, password string, info service.DeviceInfo,
 ) (service.Device, error) {
 	r.sync.Lock()
 	defer r.sync.Unlock()
 
 	if r.devices[account.Username()] == nil {
-		r.devices[account.Username()] = map[service.DeviceID]service.Device{}
+		r.devices[account.Username()] = map[service.DeviceID]*service.BaseDevice{}
+	}
+
+	registeredAt, lastSeenAt, msg := time.Now(), time.Time{}, "device registered"
+
+	// re-registrations keep the original registration time and all info that was not resupplied
+	if existing := r.devices[account.Username()][id]; existing != nil {
+		info = info.Merge(service.InfoOf(existing))
+		registeredAt, lastSeenAt, msg = existing.RegisteredAt(), existing.LastSeenAt(), "device re-registered"
 	}
 
-	r.devices[account.Username()][id] = service.NewBaseDevice(id, r.hashPassword(password))
+	r.devices[account.Username()][id] = service.NewBaseDeviceWithInfo(
+		id, r.hashPassword(password), info, registeredAt, lastSeenAt,
+	)
+
+	zerolog.Ctx(ctx).Info().Str("account", account.Username()).Str("device", id.String()).
+		Str("status", string(info.Status)).Msg(msg)
 
 	return r.devices[account.Username()][id], nil
 }
@@ -43,9 +59,12 @@ func (r *Devices) GetDevices(
 	r.sync.RLock()
 	defer r.sync.RUnlock()
 
-	deviceIDs := r.devices[account.Username()]
+	devices := make(map[service.DeviceID]service.Device, len(r.devices[account.Username()]))
+	for id, device := range r.devices[account.Username()] {
+		devices[id] = device
+	}
 
-	return deviceIDs, nil
+	return devices, nil
 }
 
 func (r *Devices) GetDevice(_ context.Context, account service.Account, id service.DeviceID) (service.Device, error) {
@@ -61,11 +80,73 @@ func (r *Devices) GetDevice(_ context.Context, account service.Account, id servi
 	return device, nil
 }
 
-func (r *Devices) DeleteDevice(_ context.Context, account service.Account, id service.DeviceID) error {
+func (r *Devices) RenameDevice(
+	_ context.Context, account service.Account, id service.DeviceID, name string,
+) (service.Device, error) {
+	r.sync.Lock()
+	defer r.sync.Unlock()
+
+	device := r.devices[account.Username()][id]
+	if device == nil {
+		return nil, service.ErrDeviceNotFound
+	}
+
+	info := service.InfoOf(device)
+	info.Name = name
+
+	r.devices[account.Username()][id] = service.NewBaseDeviceWithInfo(
+		id, device.HashedPass(), info, device.RegisteredAt(), device.LastSeenAt(),
+	)
+
+	return r.devices[account.Username()][id], nil
+}
+
+func (r *Devices) MarkSeen(_ context.Context, account service.Account, id service.DeviceID, at time.Time) error {
 	r.sync.Lock()
 	defer r.sync.Unlock()
 
-	r.devices[account.Username()][id] = nil
+	device := r.devices[account.Username()][id]
+	if device == nil {
+		return service.ErrDeviceNotFound
+	}
+
+	r.devices[account.Username()][id] = service.NewBaseDeviceWithInfo(
+		id, device.HashedPass(), service.InfoOf(device), device.RegisteredAt(), at,
+	)
+
+	return nil
+}
+
+func (r *Devices) ApproveDevice(
+	ctx context.Context, account service.Account, id service.DeviceID,
+) (service.Device, error) {
+	r.sync.Lock()
+	defer r.sync.Unlock()
+
+	device := r.devices[account.Username()][id]
+	if device == nil {
+		return nil, service.ErrDeviceNotFound
+	}
+
+	info := service.InfoOf(device)
+	info.Status = service.DeviceStatusApproved
+
+	r.devices[account.Username()][id] = service.NewBaseDeviceWithInfo(
+		id, device.HashedPass(), info, device.RegisteredAt(), device.LastSeenAt(),
+	)
+
+	zerolog.Ctx(ctx).Info().Str("approved-device", id.String()).Msg("device approved")
+
+	return r.devices[account.Username()][id], nil
+}
+
+func (r *Devices) DeleteDevice(ctx context.Context, account service.Account, id service.DeviceID) error {
+	r.sync.Lock()
+	defer r.sync.Unlock()
+
+	delete(r.devices[account.Username()], id)
+
+	zerolog.Ctx(ctx).Info().Str("deleted-device", id.String()).Msg("device deleted")
 
 	return nil
 }
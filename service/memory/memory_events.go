@@ -0,0 +1,64 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+func NewEvents() *Events {
+	return &Events{sync.RWMutex{}, make(map[string]map[chan service.Event]struct{})}
+}
+
+// Events is an in-process broker, events for subscribers with full buffers are dropped.
+type Events struct {
+	sync        sync.RWMutex
+	subscribers map[string]map[chan service.Event]struct{}
+}
+
+func (m *Events) Publish(_ context.Context, account service.Account, event service.Event) error {
+	m.sync.RLock()
+	defer m.sync.RUnlock()
+
+	for subscriber := range m.subscribers[account.Username()] {
+		select {
+		case subscriber <- event:
+		default:
+		}
+	}
+
+	return nil
+}
+
+func (m *Events) Subscribe(ctx context.Context, account service.Account) (<-chan service.Event, error) {
+	m.sync.Lock()
+	defer m.sync.Unlock()
+
+	subscriber := make(chan service.Event, service.EventBuffer)
+
+	subscribers := m.subscribers[account.Username()]
+	if subscribers == nil {
+		subscribers = make(map[chan service.Event]struct{})
+		m.subscribers[account.Username()] = subscribers
+	}
+
+	subscribers[subscriber] = struct{}{}
+
+	go func() {
+		<-ctx.Done()
+
+		m.sync.Lock()
+		defer m.sync.Unlock()
+
+		delete(subscribers, subscriber)
+
+		if len(subscribers) == 0 {
+			delete(m.subscribers, account.Username())
+		}
+
+		close(subscriber)
+	}()
+
+	return subscriber, nil
+}
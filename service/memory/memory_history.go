@@ -0,0 +1,112 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+func NewHistory(modules *Modules, retention service.HistoryRetention) *History {
+	return &History{sync.RWMutex{}, modules, retention, make(map[service.MetadataID][]historyEntry)}
+}
+
+type historyEntry struct {
+	metadata service.BaseMetadata
+	data     []byte
+}
+
+// History keeps the versions of every module newest first, they expire together with the newest version.
+// Versions share the data of the modules they were recorded from.
+type History struct {
+	sync      sync.RWMutex
+	modules   *Modules
+	retention service.HistoryRetention
+	versions  map[service.MetadataID][]historyEntry
+}
+
+func (m *History) Record(_ context.Context, meta service.Metadata) error {
+	data := m.modules.stored(string(meta.GetID()))
+
+	m.sync.Lock()
+	defer m.sync.Unlock()
+
+	entries := append([]historyEntry{{service.MetadataOf(meta), data}}, m.entries(meta.GetID())...)
+
+	metadata := make([]service.Metadata, len(entries))
+	for i := range entries {
+		metadata[i] = &entries[i].metadata
+	}
+
+	kept, _ := m.retention.Retained(metadata, time.Now())
+	m.versions[meta.GetID()] = entries[:len(kept)]
+
+	return nil
+}
+
+func (m *History) Versions(_ context.Context, id service.MetadataID) ([]service.Metadata, error) {
+	m.sync.RLock()
+	defer m.sync.RUnlock()
+
+	entries := m.entries(id)
+	versions := make([]service.Metadata, len(entries))
+
+	for i, entry := range entries {
+		metadata := entry.metadata
+		versions[i] = &metadata
+	}
+
+	return versions, nil
+}
+
+func (m *History) Get(
+	_ context.Context, id service.MetadataID, version int64,
+) (service.Module, service.Metadata, error) {
+	m.sync.RLock()
+	defer m.sync.RUnlock()
+
+	for _, entry := range m.entries(id) {
+		if entry.metadata.Version == version {
+			metadata := entry.metadata
+
+			return ModuleFromBytes(entry.data), &metadata, nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("%w: %s version %d", service.ErrVersionNotFound, id, version)
+}
+
+// entries returns the versions of the module unless they expired.
+func (m *History) entries(id service.MetadataID) []historyEntry {
+	entries := m.versions[id]
+	if len(entries) == 0 || service.Expired(entries[0].metadata.GetExpiresAt(), time.Now()) {
+		return nil
+	}
+
+	return entries
+}
+
+func (m *History) Delete(_ context.Context, id service.MetadataID) error {
+	m.sync.Lock()
+	defer m.sync.Unlock()
+
+	delete(m.versions, id)
+
+	return nil
+}
+
+func (m *History) DeleteByPrefix(_ context.Context, prefix string) error {
+	m.sync.Lock()
+	defer m.sync.Unlock()
+
+	for id := range m.versions {
+		if strings.HasPrefix(string(id), prefix) {
+			delete(m.versions, id)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+func NewLocker() *Locker {
+	return &Locker{sync.Mutex{}, make(map[string]chan struct{})}
+}
+
+// Locker holds locks within the process, every held lock has a channel that is closed on release.
+type Locker struct {
+	sync  sync.Mutex
+	locks map[string]chan struct{}
+}
+
+func (m *Locker) Lock(ctx context.Context, key string) (service.Unlock, error) {
+	for {
+		m.sync.Lock()
+		released, held := m.locks[key]
+
+		if !held {
+			released = make(chan struct{})
+			m.locks[key] = released
+			m.sync.Unlock()
+
+			return func(context.Context) error {
+				m.sync.Lock()
+				defer m.sync.Unlock()
+
+				delete(m.locks, key)
+				close(released)
+
+				return nil
+			}, nil
+		}
+
+		m.sync.Unlock()
+
+		select {
+		case <-released:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %s: %w", service.ErrLockNotAcquired, key, ctx.Err())
+		}
+	}
+}
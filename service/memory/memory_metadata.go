@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+func NewMetadataProvider() *MetadataProvider {
+	return &MetadataProvider{sync.RWMutex{}, make(map[service.MetadataID]service.BaseMetadata)}
+}
+
+type MetadataProvider struct {
+	sync     sync.RWMutex
+	metadata map[service.MetadataID]service.BaseMetadata
+}
+
+func (m *MetadataProvider) Get(_ context.Context, id service.MetadataID) (service.Metadata, error) {
+	m.sync.RLock()
+	defer m.sync.RUnlock()
+
+	meta, found := m.metadata[id]
+	if !found || service.Expired(meta.GetExpiresAt(), time.Now()) {
+		return nil, service.ErrNoMetadata
+	}
+
+	return &meta, nil
+}
+
+func (m *MetadataProvider) Set(_ context.Context, meta service.Metadata) error {
+	m.sync.Lock()
+	defer m.sync.Unlock()
+
+	m.metadata[meta.GetID()] = service.MetadataOf(meta)
+
+	return nil
+}
+
+func (m *MetadataProvider) GetMany(_ context.Context, ids []service.MetadataID) ([]service.Metadata, error) {
+	m.sync.RLock()
+	defer m.sync.RUnlock()
+
+	metadata := make([]service.Metadata, len(ids))
+	now := time.Now()
+
+	for i, id := range ids {
+		if meta, found := m.metadata[id]; found && !service.Expired(meta.GetExpiresAt(), now) {
+			metadata[i] = &meta
+		}
+	}
+
+	return metadata, nil
+}
+
+func (m *MetadataProvider) List(_ context.Context, opts service.ListOptions) ([]service.Metadata, string, error) {
+	m.sync.RLock()
+	defer m.sync.RUnlock()
+
+	ids := make([]string, 0, len(m.metadata))
+	now := time.Now()
+
+	for id, meta := range m.metadata {
+		if strings.HasPrefix(string(id), opts.Prefix) && !service.Expired(meta.GetExpiresAt(), now) {
+			ids = append(ids, string(id))
+		}
+	}
+
+	page, next, err := service.Paginate(ids, opts)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not paginate metadata: %w", err)
+	}
+
+	metadata := make([]service.Metadata, len(page))
+
+	for i, id := range page {
+		meta := m.metadata[service.MetadataID(id)]
+		metadata[i] = &meta
+	}
+
+	return metadata, next, nil
+}
+
+func (m *MetadataProvider) HealthCheck() service.HealthCheck {
+	return func(_ context.Context) (string, bool) {
+		return "memory-metadata-provider", true
+	}
+}
@@ -5,27 +5,41 @@ import (
 	"fmt"
 	"io"
 	"regexp"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/jakobmoellerdev/octi-sync-server/service"
 )
 
 func NewModules() *Modules {
-	return &Modules{sync.RWMutex{}, make(map[string][]byte)}
+	return &Modules{sync.RWMutex{}, make(map[string]string), make(map[string]time.Time), make(map[string]*blob)}
 }
 
+type blob struct {
+	data       []byte
+	references int
+}
+
+// Modules keeps the data of modules content addressed like the redis backend,
+// blobs are dropped as soon as the last module referencing them is overwritten, deleted or expired.
 type Modules struct {
-	sync sync.RWMutex
-	data map[string][]byte
+	sync    sync.RWMutex
+	modules map[string]string
+	// expiresAt holds the expiry of the modules that expire
+	expiresAt map[string]time.Time
+	blobs     map[string]*blob
 }
 
 func (m *Modules) DeleteByPattern(_ context.Context, pattern string) error {
 	m.sync.Lock()
 	defer m.sync.Unlock()
 
-	for key := range m.data {
+	for key := range m.modules {
 		if matched, err := regexp.Match(pattern, []byte(key)); matched {
-			m.data[key] = nil
+			m.release(key)
+			delete(m.modules, key)
+			delete(m.expiresAt, key)
 		} else if err != nil {
 			return fmt.Errorf("error while parsing regex pattern %s: %w", pattern, err)
 		}
@@ -34,16 +48,28 @@ func (m *Modules) DeleteByPattern(_ context.Context, pattern string) error {
 	return nil
 }
 
-func (m *Modules) Set(_ context.Context, name string, module service.Module) error {
+func (m *Modules) Delete(_ context.Context, name string) error {
 	m.sync.Lock()
 	defer m.sync.Unlock()
 
+	m.release(name)
+	delete(m.modules, name)
+	delete(m.expiresAt, name)
+
+	return nil
+}
+
+func (m *Modules) Set(_ context.Context, name string, module service.Module, expiresAt time.Time) error {
 	moduleData, err := io.ReadAll(module.Raw())
 	if err != nil {
 		return fmt.Errorf("error while reading module raw input for writing: %w", err)
 	}
 
-	m.data[name] = moduleData
+	m.sync.Lock()
+	defer m.sync.Unlock()
+
+	m.dropExpired(time.Now())
+	m.store(name, moduleData, expiresAt)
 
 	return nil
 }
@@ -52,7 +78,148 @@ func (m *Modules) Get(_ context.Context, name string) (service.Module, error) {
 	m.sync.RLock()
 	defer m.sync.RUnlock()
 
-	return ModuleFromBytes(m.data[name]), nil
+	return ModuleFromBytes(m.data(name)), nil
+}
+
+func (m *Modules) GetRange(_ context.Context, name string, offset, length int64) (service.Module, error) {
+	m.sync.RLock()
+	defer m.sync.RUnlock()
+
+	data := m.data(name)
+	start := min(offset, int64(len(data)))
+	end := min(start+length, int64(len(data)))
+
+	return ModuleFromBytes(data[start:end]), nil
+}
+
+func (m *Modules) SetMany(
+	_ context.Context, modules map[string]service.Module, expiresAt map[string]time.Time,
+) error {
+	data := make(map[string][]byte, len(modules))
+
+	for name, module := range modules {
+		moduleData, err := io.ReadAll(module.Raw())
+		if err != nil {
+			return fmt.Errorf("error while reading module raw input of %s for writing: %w", name, err)
+		}
+
+		data[name] = moduleData
+	}
+
+	m.sync.Lock()
+	defer m.sync.Unlock()
+
+	m.dropExpired(time.Now())
+
+	for name, moduleData := range data {
+		m.store(name, moduleData, expiresAt[name])
+	}
+
+	return nil
+}
+
+func (m *Modules) GetMany(_ context.Context, names []string) ([]service.Module, error) {
+	m.sync.RLock()
+	defer m.sync.RUnlock()
+
+	modules := make([]service.Module, len(names))
+	for i, name := range names {
+		modules[i] = ModuleFromBytes(m.data(name))
+	}
+
+	return modules, nil
+}
+
+func (m *Modules) store(name string, data []byte, expiresAt time.Time) {
+	delete(m.expiresAt, name)
+
+	if !expiresAt.IsZero() {
+		m.expiresAt[name] = expiresAt
+	}
+
+	digest := service.Digest(data)
+	if previous, exists := m.modules[name]; exists && previous == digest {
+		return
+	}
+
+	m.release(name)
+
+	if _, exists := m.blobs[digest]; !exists {
+		m.blobs[digest] = &blob{data: data}
+	}
+
+	m.blobs[digest].references++
+	m.modules[name] = digest
+}
+
+// dropExpired drops the modules that expired, modules are treated as missing from the moment they expire.
+func (m *Modules) dropExpired(now time.Time) {
+	for name, expiresAt := range m.expiresAt {
+		if service.Expired(expiresAt, now) {
+			m.release(name)
+			delete(m.modules, name)
+			delete(m.expiresAt, name)
+		}
+	}
+}
+
+func (m *Modules) release(name string) {
+	digest, exists := m.modules[name]
+	if !exists {
+		return
+	}
+
+	if m.blobs[digest].references--; m.blobs[digest].references == 0 {
+		delete(m.blobs, digest)
+	}
+}
+
+// stored returns the data the module is stored with, which is never modified and can be shared.
+func (m *Modules) stored(name string) []byte {
+	m.sync.RLock()
+	defer m.sync.RUnlock()
+
+	return m.data(name)
+}
+
+func (m *Modules) data(name string) []byte {
+	if !m.exists(name, time.Now()) {
+		return nil
+	}
+
+	return m.blobs[m.modules[name]].data
+}
+
+func (m *Modules) exists(name string, now time.Time) bool {
+	_, exists := m.modules[name]
+
+	return exists && !service.Expired(m.expiresAt[name], now)
+}
+
+func (m *Modules) List(_ context.Context, opts service.ListOptions) ([]service.ModuleInfo, string, error) {
+	m.sync.RLock()
+	defer m.sync.RUnlock()
+
+	names := make([]string, 0, len(m.modules))
+	now := time.Now()
+
+	for name := range m.modules {
+		if strings.HasPrefix(name, opts.Prefix) && m.exists(name, now) {
+			names = append(names, name)
+		}
+	}
+
+	page, next, err := service.Paginate(names, opts)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not paginate modules: %w", err)
+	}
+
+	infos := make([]service.ModuleInfo, len(page))
+	for i, name := range page {
+		infos[i] = service.ModuleInfo{ID: name, Size: int64(len(m.data(name))), ExpiresAt: m.expiresAt[name]}
+	}
+
+	return infos, next, nil
 }
 
 func (m *Modules) HealthCheck() service.HealthCheck {
@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+func NewPresence() *Presence {
+	return &Presence{sync.RWMutex{}, make(map[string]map[presenceConnection]time.Time)}
+}
+
+type presenceConnection struct {
+	device service.DeviceID
+	id     string
+}
+
+type Presence struct {
+	sync        sync.RWMutex
+	connections map[string]map[presenceConnection]time.Time
+}
+
+func (m *Presence) Connected(
+	_ context.Context, account service.Account, device service.DeviceID, connection string, until time.Time,
+) error {
+	m.sync.Lock()
+	defer m.sync.Unlock()
+
+	connections := m.connections[account.Username()]
+	if connections == nil {
+		connections = make(map[presenceConnection]time.Time)
+		m.connections[account.Username()] = connections
+	}
+
+	connections[presenceConnection{device, connection}] = until
+
+	return nil
+}
+
+func (m *Presence) Disconnected(
+	_ context.Context, account service.Account, device service.DeviceID, connection string,
+) error {
+	m.sync.Lock()
+	defer m.sync.Unlock()
+
+	delete(m.connections[account.Username()], presenceConnection{device, connection})
+
+	return nil
+}
+
+func (m *Presence) Online(_ context.Context, account service.Account) (map[service.DeviceID]bool, error) {
+	m.sync.RLock()
+	defer m.sync.RUnlock()
+
+	now := time.Now()
+	online := make(map[service.DeviceID]bool)
+
+	for connection, until := range m.connections[account.Username()] {
+		if until.After(now) {
+			online[connection.device] = true
+		}
+	}
+
+	return online, nil
+}
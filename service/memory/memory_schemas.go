@@ -0,0 +1,189 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+// SchemaFileExtension is the extension of the files schemas are loaded from.
+const SchemaFileExtension = ".json"
+
+func NewSchemas() *Schemas {
+	return &Schemas{sync.RWMutex{}, jsonschema.NewCompiler(), nil}
+}
+
+// LoadSchemas registers every schema file of the directory for the modules matching its file name
+// without extension, e.g. settings.json validates the module settings and sms-*.json all modules prefixed sms-.
+// Schemas can reference each other relative to the directory.
+func LoadSchemas(dir string) (*Schemas, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*"+SchemaFileExtension))
+	if err != nil {
+		return nil, fmt.Errorf("could not list schemas in %s: %w", dir, err)
+	}
+
+	schemas := NewSchemas()
+
+	for _, file := range files {
+		document, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("could not read schema %s: %w", file, err)
+		}
+
+		url, err := filepath.Abs(file)
+		if err != nil {
+			return nil, fmt.Errorf("could not resolve schema %s: %w", file, err)
+		}
+
+		name := strings.TrimSuffix(filepath.Base(file), SchemaFileExtension)
+		if err := schemas.register(url, service.Schema{Name: name, Pattern: name, Document: document}); err != nil {
+			return nil, err
+		}
+	}
+
+	return schemas, nil
+}
+
+type compiledSchema struct {
+	service.Schema
+	compiled *jsonschema.Schema
+}
+
+// Schemas keeps the compiled schemas sorted by name.
+type Schemas struct {
+	sync     sync.RWMutex
+	compiler *jsonschema.Compiler
+	schemas  []compiledSchema
+}
+
+// Register compiles the schema and validates modules matching its pattern against it from now on.
+func (m *Schemas) Register(schema service.Schema) error {
+	return m.register(schema.Name+SchemaFileExtension, schema)
+}
+
+func (m *Schemas) register(url string, schema service.Schema) error {
+	if _, err := path.Match(schema.Pattern, ""); err != nil {
+		return fmt.Errorf("invalid module pattern %q of schema %s: %w", schema.Pattern, schema.Name, err)
+	}
+
+	m.sync.Lock()
+	defer m.sync.Unlock()
+
+	if err := m.compiler.AddResource(url, bytes.NewReader(schema.Document)); err != nil {
+		return fmt.Errorf("could not parse schema %s: %w", schema.Name, err)
+	}
+
+	compiled, err := m.compiler.Compile(url)
+	if err != nil {
+		return fmt.Errorf("could not compile schema %s: %w", schema.Name, err)
+	}
+
+	m.schemas = append(m.schemas, compiledSchema{schema, compiled})
+	sort.Slice(m.schemas, func(i, j int) bool { return m.schemas[i].Name < m.schemas[j].Name })
+
+	return nil
+}
+
+func (m *Schemas) List(_ context.Context) ([]service.Schema, error) {
+	m.sync.RLock()
+	defer m.sync.RUnlock()
+
+	schemas := make([]service.Schema, len(m.schemas))
+	for i := range m.schemas {
+		schemas[i] = m.schemas[i].Schema
+	}
+
+	return schemas, nil
+}
+
+func (m *Schemas) Match(_ context.Context, module string) (service.Schema, bool, error) {
+	schema, found := m.match(module)
+
+	return schema.Schema, found, nil
+}
+
+func (m *Schemas) Validate(_ context.Context, module string, data []byte) error {
+	schema, found := m.match(module)
+	if !found {
+		return nil
+	}
+
+	var instance interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	err := decoder.Decode(&instance)
+	if err == nil && decoder.Decode(&struct{}{}) != io.EOF {
+		err = errors.New("unexpected data after the JSON value")
+	}
+
+	if err != nil {
+		return &service.SchemaValidationError{Schema: schema.Name, Violations: []service.SchemaViolation{{
+			Message: fmt.Sprintf("module data is not valid JSON: %s", err),
+		}}}
+	}
+
+	var validationError *jsonschema.ValidationError
+	if err := schema.compiled.Validate(instance); errors.As(err, &validationError) {
+		return &service.SchemaValidationError{Schema: schema.Name, Violations: violations(validationError)}
+	} else if err != nil {
+		return fmt.Errorf("could not validate %s against schema %s: %w", module, schema.Name, err)
+	}
+
+	return nil
+}
+
+// match returns the schema with the pattern equal to the module name or else the longest pattern matching it.
+func (m *Schemas) match(module string) (compiledSchema, bool) {
+	m.sync.RLock()
+	defer m.sync.RUnlock()
+
+	var (
+		best  compiledSchema
+		found bool
+	)
+
+	for _, schema := range m.schemas {
+		if schema.Pattern == module {
+			return schema, true
+		}
+
+		if matches, _ := path.Match(schema.Pattern, module); matches && len(schema.Pattern) > len(best.Pattern) {
+			best, found = schema, true
+		}
+	}
+
+	return best, found
+}
+
+// violations flattens the validation error into the errors that are not caused by others.
+func violations(err *jsonschema.ValidationError) []service.SchemaViolation {
+	if len(err.Causes) == 0 {
+		return []service.SchemaViolation{{
+			InstanceLocation: err.InstanceLocation,
+			KeywordLocation:  err.KeywordLocation,
+			Message:          err.Message,
+		}}
+	}
+
+	var flattened []service.SchemaViolation
+	for _, cause := range err.Causes {
+		flattened = append(flattened, violations(cause)...)
+	}
+
+	return flattened
+}
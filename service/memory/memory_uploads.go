@@ -0,0 +1,116 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+func NewUploads(expiration time.Duration) *Uploads {
+	if expiration <= 0 {
+		expiration = service.DefaultUploadExpiration
+	}
+
+	return &Uploads{sync.RWMutex{}, expiration, make(map[string]map[string]*stagedUpload)}
+}
+
+type stagedUpload struct {
+	upload service.Upload
+	data   []byte
+}
+
+type Uploads struct {
+	sync       sync.RWMutex
+	expiration time.Duration
+	uploads    map[string]map[string]*stagedUpload
+}
+
+func (m *Uploads) Create(_ context.Context, account service.Account, upload service.Upload) (service.Upload, error) {
+	m.sync.Lock()
+	defer m.sync.Unlock()
+
+	upload.ID = uuid.NewString()
+	upload.Offset = 0
+	upload.ExpiresAt = time.Now().Add(m.expiration)
+
+	uploads := m.uploads[account.Username()]
+	if uploads == nil {
+		uploads = make(map[string]*stagedUpload)
+		m.uploads[account.Username()] = uploads
+	}
+
+	uploads[upload.ID] = &stagedUpload{upload: upload}
+
+	return upload, nil
+}
+
+func (m *Uploads) Get(_ context.Context, account service.Account, id string) (service.Upload, error) {
+	m.sync.RLock()
+	defer m.sync.RUnlock()
+
+	staged, err := m.staged(account, id)
+	if err != nil {
+		return service.Upload{}, err
+	}
+
+	return staged.upload, nil
+}
+
+func (m *Uploads) Append(
+	_ context.Context, account service.Account, id string, offset int64, chunk []byte,
+) (service.Upload, error) {
+	m.sync.Lock()
+	defer m.sync.Unlock()
+
+	staged, err := m.staged(account, id)
+	if err != nil {
+		return service.Upload{}, err
+	}
+
+	if offset != staged.upload.Offset {
+		return staged.upload, service.ErrUploadOffsetMismatch
+	}
+
+	if offset+int64(len(chunk)) > staged.upload.Length {
+		return staged.upload, service.ErrUploadTooLarge
+	}
+
+	staged.data = append(staged.data, chunk...)
+	staged.upload.Offset = int64(len(staged.data))
+
+	return staged.upload, nil
+}
+
+func (m *Uploads) Data(_ context.Context, account service.Account, id string) (service.Module, error) {
+	m.sync.RLock()
+	defer m.sync.RUnlock()
+
+	staged, err := m.staged(account, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return ModuleFromBytes(staged.data), nil
+}
+
+func (m *Uploads) Delete(_ context.Context, account service.Account, id string) error {
+	m.sync.Lock()
+	defer m.sync.Unlock()
+
+	delete(m.uploads[account.Username()], id)
+
+	return nil
+}
+
+func (m *Uploads) staged(account service.Account, id string) (*stagedUpload, error) {
+	staged, found := m.uploads[account.Username()][id]
+	if !found || time.Now().After(staged.upload.ExpiresAt) {
+		return nil, service.ErrUploadNotFound
+	}
+
+	return staged, nil
+}
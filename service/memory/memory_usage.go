@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+func NewUsage() *Usage {
+	return &Usage{sync.RWMutex{}, make(map[string]map[string]moduleUsage)}
+}
+
+type moduleUsage struct {
+	size      int64
+	expiresAt time.Time
+}
+
+// Usage keeps the size of every module per account, modules are no longer counted once they expired.
+type Usage struct {
+	sync    sync.RWMutex
+	modules map[string]map[string]moduleUsage
+}
+
+func (m *Usage) Record(
+	_ context.Context, account service.Account, module string, size int64, expiresAt time.Time,
+) error {
+	m.sync.Lock()
+	defer m.sync.Unlock()
+
+	modules := m.modules[account.Username()]
+	if modules == nil {
+		modules = make(map[string]moduleUsage)
+		m.modules[account.Username()] = modules
+	}
+
+	modules[module] = moduleUsage{size, expiresAt}
+
+	return nil
+}
+
+func (m *Usage) Remove(_ context.Context, account service.Account, modules ...string) error {
+	m.sync.Lock()
+	defer m.sync.Unlock()
+
+	for _, module := range modules {
+		delete(m.modules[account.Username()], module)
+	}
+
+	return nil
+}
+
+func (m *Usage) Get(_ context.Context, account service.Account) (service.AccountUsage, error) {
+	m.sync.RLock()
+	defer m.sync.RUnlock()
+
+	var usage service.AccountUsage
+
+	now := time.Now()
+
+	for _, module := range m.modules[account.Username()] {
+		if service.Expired(module.expiresAt, now) {
+			continue
+		}
+
+		usage.Modules++
+		usage.Bytes += module.size
+	}
+
+	return usage, nil
+}
@@ -14,18 +14,58 @@ type ModifiedAt time.Time
 type MetadataProvider interface {
 	Get(ctx context.Context, id MetadataID) (Metadata, error)
 	Set(ctx context.Context, meta Metadata) error
+	// GetMany reads the metadata of the ids in a single round trip to the backend, in the order of the ids.
+	// Metadata that does not exist is returned as nil instead of failing with ErrNoMetadata like in Get.
+	GetMany(ctx context.Context, ids []MetadataID) ([]Metadata, error)
+	// List returns the metadata whose id starts with the prefix of the options, sorted by id.
+	List(ctx context.Context, opts ListOptions) ([]Metadata, string, error)
 }
 
 type Metadata interface {
 	GetID() MetadataID
 	GetModifiedAt() ModifiedAt
+	// GetHash is the hex encoded SHA-256 of the content, empty for metadata written before hashes were recorded.
+	GetHash() string
+	// GetVersion is increased with every write of the content, starting at 1.
+	GetVersion() int64
+	// GetSize is the size of the content in bytes.
+	GetSize() int64
+	// GetType decides how writes are combined with the content.
+	GetType() ModuleType
+	// GetEncoding is the codec the content is compressed with at rest.
+	GetEncoding() ModuleEncoding
+	// GetKeyID is the data key the content is encrypted with at rest, empty if it is not encrypted.
+	GetKeyID() string
+	// GetExpiresAt is when the content expires together with its metadata, the zero time if it never expires.
+	GetExpiresAt() time.Time
+	// GetContentType is the media type the content was written with, empty if the client did not declare one.
+	GetContentType() string
+	// GetContentEncoding is the encoding the client applied to the content, which is stored as is.
+	// It is empty for content that is not encoded, unlike GetEncoding it is never undone by the server.
+	GetContentEncoding() string
+	// GetFilename is the name of the file the content was written from, empty if the client did not name one.
+	GetFilename() string
+	// GetDeletedAt is when the content was deleted, the zero time if it was not. The metadata of deleted content
+	// is kept as tombstone, so that clients can tell deleted content apart from content that never existed.
+	GetDeletedAt() time.Time
 }
 
 var ErrNoMetadata = errors.New("no metadata found")
 
 type BaseMetadata struct {
-	ID         MetadataID `json:"id"         yaml:"id"`
-	ModifiedAt time.Time  `json:"modifiedAt" yaml:"modifiedAt"`
+	ID              MetadataID     `json:"id"                        yaml:"id"`
+	ModifiedAt      time.Time      `json:"modifiedAt"                yaml:"modifiedAt"`
+	Hash            string         `json:"hash,omitempty"            yaml:"hash,omitempty"`
+	Version         int64          `json:"version,omitempty"         yaml:"version,omitempty"`
+	Size            int64          `json:"size,omitempty"            yaml:"size,omitempty"`
+	Type            ModuleType     `json:"type,omitempty"            yaml:"type,omitempty"`
+	Encoding        ModuleEncoding `json:"encoding,omitempty"        yaml:"encoding,omitempty"`
+	KeyID           string         `json:"keyId,omitempty"           yaml:"keyId,omitempty"`
+	ExpiresAt       *time.Time     `json:"expiresAt,omitempty"       yaml:"expiresAt,omitempty"`
+	ContentType     string         `json:"contentType,omitempty"     yaml:"contentType,omitempty"`
+	ContentEncoding string         `json:"contentEncoding,omitempty" yaml:"contentEncoding,omitempty"`
+	Filename        string         `json:"filename,omitempty"        yaml:"filename,omitempty"`
+	DeletedAt       *time.Time     `json:"deletedAt,omitempty"       yaml:"deletedAt,omitempty"`
 }
 
 func (r *BaseMetadata) GetID() MetadataID {
@@ -36,6 +76,131 @@ func (r *BaseMetadata) GetModifiedAt() ModifiedAt {
 	return ModifiedAt(r.ModifiedAt.UTC())
 }
 
+func (r *BaseMetadata) GetHash() string {
+	return r.Hash
+}
+
+func (r *BaseMetadata) GetVersion() int64 {
+	return r.Version
+}
+
+func (r *BaseMetadata) GetSize() int64 {
+	return r.Size
+}
+
+func (r *BaseMetadata) GetType() ModuleType {
+	return r.Type
+}
+
+func (r *BaseMetadata) GetEncoding() ModuleEncoding {
+	return r.Encoding
+}
+
+func (r *BaseMetadata) GetKeyID() string {
+	return r.KeyID
+}
+
+func (r *BaseMetadata) GetExpiresAt() time.Time {
+	if r.ExpiresAt == nil {
+		return time.Time{}
+	}
+
+	return *r.ExpiresAt
+}
+
+func (r *BaseMetadata) GetContentType() string {
+	return r.ContentType
+}
+
+func (r *BaseMetadata) GetContentEncoding() string {
+	return r.ContentEncoding
+}
+
+func (r *BaseMetadata) GetFilename() string {
+	return r.Filename
+}
+
+// SetExpiresAt sets when the content expires, the zero time never expires.
+func (r *BaseMetadata) SetExpiresAt(expiresAt time.Time) {
+	r.ExpiresAt = nil
+
+	if !expiresAt.IsZero() {
+		expiresAt = expiresAt.UTC()
+		r.ExpiresAt = &expiresAt
+	}
+}
+
+func (r *BaseMetadata) GetDeletedAt() time.Time {
+	if r.DeletedAt == nil {
+		return time.Time{}
+	}
+
+	return *r.DeletedAt
+}
+
+// SetDeletedAt marks the content as deleted, the zero time marks it as not deleted.
+func (r *BaseMetadata) SetDeletedAt(deletedAt time.Time) {
+	r.DeletedAt = nil
+
+	if !deletedAt.IsZero() {
+		deletedAt = deletedAt.UTC()
+		r.DeletedAt = &deletedAt
+	}
+}
+
+// MetadataOf copies the metadata, e.g. to persist it.
+func MetadataOf(meta Metadata) BaseMetadata {
+	metadata := BaseMetadata{
+		ID:              meta.GetID(),
+		ModifiedAt:      time.Time(meta.GetModifiedAt()),
+		Hash:            meta.GetHash(),
+		Version:         meta.GetVersion(),
+		Size:            meta.GetSize(),
+		Type:            meta.GetType(),
+		Encoding:        meta.GetEncoding(),
+		KeyID:           meta.GetKeyID(),
+		ContentType:     meta.GetContentType(),
+		ContentEncoding: meta.GetContentEncoding(),
+		Filename:        meta.GetFilename(),
+	}
+	metadata.SetExpiresAt(meta.GetExpiresAt())
+	metadata.SetDeletedAt(meta.GetDeletedAt())
+
+	return metadata
+}
+
+// NewTombstone returns the metadata that replaces the metadata of content deleted at the time,
+// it expires after the retention unless the retention is 0.
+func NewTombstone(current Metadata, id string, deletedAt time.Time, retention time.Duration) *BaseMetadata {
+	tombstone := NewVersionedMetadata(id, deletedAt, "", NextVersion(current))
+	tombstone.SetDeletedAt(deletedAt)
+
+	if retention > 0 {
+		tombstone.SetExpiresAt(deletedAt.Add(retention).Truncate(time.Second))
+	}
+
+	return tombstone
+}
+
+// Deleted reports whether the metadata is the tombstone of deleted content.
+func Deleted(metadata Metadata) bool {
+	return metadata != nil && !metadata.GetDeletedAt().IsZero()
+}
+
 func NewBaseMetadata(id string, modifiedAt time.Time) *BaseMetadata {
-	return &BaseMetadata{MetadataID(id), modifiedAt}
+	return &BaseMetadata{ID: MetadataID(id), ModifiedAt: modifiedAt}
+}
+
+func NewVersionedMetadata(id string, modifiedAt time.Time, hash string, version int64) *BaseMetadata {
+	return &BaseMetadata{ID: MetadataID(id), ModifiedAt: modifiedAt, Hash: hash, Version: version}
+}
+
+// NextVersion returns the version of the content that replaces the content described by the metadata.
+// Metadata may be nil if there is no content yet.
+func NextVersion(current Metadata) int64 {
+	if current == nil {
+		return 1
+	}
+
+	return current.GetVersion() + 1
 }
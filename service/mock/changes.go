@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: changes.go
+//
+// Generated by this command:
+//
+//	mockgen -source changes.go -package mock -destination mock/changes.go ChangeFeed
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	service "github.com/jakobmoellerdev/octi-sync-server/service"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockChangeFeed is a mock of ChangeFeed interface.
+type MockChangeFeed struct {
+	ctrl     *gomock.Controller
+	recorder *MockChangeFeedMockRecorder
+}
+
+// MockChangeFeedMockRecorder is the mock recorder for MockChangeFeed.
+type MockChangeFeedMockRecorder struct {
+	mock *MockChangeFeed
+}
+
+// NewMockChangeFeed creates a new mock instance.
+func NewMockChangeFeed(ctrl *gomock.Controller) *MockChangeFeed {
+	mock := &MockChangeFeed{ctrl: ctrl}
+	mock.recorder = &MockChangeFeedMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChangeFeed) EXPECT() *MockChangeFeedMockRecorder {
+	return m.recorder
+}
+
+// Append mocks base method.
+func (m *MockChangeFeed) Append(ctx context.Context, account service.Account, change service.Change) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Append", ctx, account, change)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockChangeFeedMockRecorder) Append(ctx, account, change any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockChangeFeed)(nil).Append), ctx, account, change)
+}
+
+// Since mocks base method.
+func (m *MockChangeFeed) Since(ctx context.Context, account service.Account, cursor string, limit int) ([]service.Change, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Since", ctx, account, cursor, limit)
+	ret0, _ := ret[0].([]service.Change)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Since indicates an expected call of Since.
+func (mr *MockChangeFeedMockRecorder) Since(ctx, account, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Since", reflect.TypeOf((*MockChangeFeed)(nil).Since), ctx, account, cursor, limit)
+}
@@ -11,6 +11,7 @@ package mock
 
 import (
 	reflect "reflect"
+	time "time"
 
 	service "github.com/jakobmoellerdev/octi-sync-server/service"
 	gomock "go.uber.org/mock/gomock"
@@ -39,6 +40,20 @@ func (m *MockDevice) EXPECT() *MockDeviceMockRecorder {
 	return m.recorder
 }
 
+// ClientVersion mocks base method.
+func (m *MockDevice) ClientVersion() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClientVersion")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ClientVersion indicates an expected call of ClientVersion.
+func (mr *MockDeviceMockRecorder) ClientVersion() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClientVersion", reflect.TypeOf((*MockDevice)(nil).ClientVersion))
+}
+
 // HashedPass mocks base method.
 func (m *MockDevice) HashedPass() string {
 	m.ctrl.T.Helper()
@@ -67,6 +82,90 @@ func (mr *MockDeviceMockRecorder) ID() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ID", reflect.TypeOf((*MockDevice)(nil).ID))
 }
 
+// LastSeenAt mocks base method.
+func (m *MockDevice) LastSeenAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastSeenAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// LastSeenAt indicates an expected call of LastSeenAt.
+func (mr *MockDeviceMockRecorder) LastSeenAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastSeenAt", reflect.TypeOf((*MockDevice)(nil).LastSeenAt))
+}
+
+// Name mocks base method.
+func (m *MockDevice) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockDeviceMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockDevice)(nil).Name))
+}
+
+// Platform mocks base method.
+func (m *MockDevice) Platform() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Platform")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Platform indicates an expected call of Platform.
+func (mr *MockDeviceMockRecorder) Platform() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Platform", reflect.TypeOf((*MockDevice)(nil).Platform))
+}
+
+// RegisteredAt mocks base method.
+func (m *MockDevice) RegisteredAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisteredAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// RegisteredAt indicates an expected call of RegisteredAt.
+func (mr *MockDeviceMockRecorder) RegisteredAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisteredAt", reflect.TypeOf((*MockDevice)(nil).RegisteredAt))
+}
+
+// RemoteIP mocks base method.
+func (m *MockDevice) RemoteIP() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoteIP")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// RemoteIP indicates an expected call of RemoteIP.
+func (mr *MockDeviceMockRecorder) RemoteIP() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoteIP", reflect.TypeOf((*MockDevice)(nil).RemoteIP))
+}
+
+// Status mocks base method.
+func (m *MockDevice) Status() service.DeviceStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status")
+	ret0, _ := ret[0].(service.DeviceStatus)
+	return ret0
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockDeviceMockRecorder) Status() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockDevice)(nil).Status))
+}
+
 // Verify mocks base method.
 func (m *MockDevice) Verify(password string) bool {
 	m.ctrl.T.Helper()
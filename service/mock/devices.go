@@ -12,6 +12,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	service "github.com/jakobmoellerdev/octi-sync-server/service"
 	gomock "go.uber.org/mock/gomock"
@@ -41,18 +42,33 @@ func (m *MockDevices) EXPECT() *MockDevicesMockRecorder {
 }
 
 // AddDevice mocks base method.
-func (m *MockDevices) AddDevice(ctx context.Context, account service.Account, id service.DeviceID, password string) (service.Device, error) {
+func (m *MockDevices) AddDevice(ctx context.Context, account service.Account, id service.DeviceID, password string, info service.DeviceInfo) (service.Device, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddDevice", ctx, account, id, password)
+	ret := m.ctrl.Call(m, "AddDevice", ctx, account, id, password, info)
 	ret0, _ := ret[0].(service.Device)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AddDevice indicates an expected call of AddDevice.
-func (mr *MockDevicesMockRecorder) AddDevice(ctx, account, id, password any) *gomock.Call {
+func (mr *MockDevicesMockRecorder) AddDevice(ctx, account, id, password, info any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDevice", reflect.TypeOf((*MockDevices)(nil).AddDevice), ctx, account, id, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDevice", reflect.TypeOf((*MockDevices)(nil).AddDevice), ctx, account, id, password, info)
+}
+
+// ApproveDevice mocks base method.
+func (m *MockDevices) ApproveDevice(ctx context.Context, account service.Account, id service.DeviceID) (service.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveDevice", ctx, account, id)
+	ret0, _ := ret[0].(service.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveDevice indicates an expected call of ApproveDevice.
+func (mr *MockDevicesMockRecorder) ApproveDevice(ctx, account, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveDevice", reflect.TypeOf((*MockDevices)(nil).ApproveDevice), ctx, account, id)
 }
 
 // DeleteDevice mocks base method.
@@ -112,3 +128,32 @@ func (mr *MockDevicesMockRecorder) HealthCheck() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HealthCheck", reflect.TypeOf((*MockDevices)(nil).HealthCheck))
 }
+
+// MarkSeen mocks base method.
+func (m *MockDevices) MarkSeen(ctx context.Context, account service.Account, id service.DeviceID, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkSeen", ctx, account, id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkSeen indicates an expected call of MarkSeen.
+func (mr *MockDevicesMockRecorder) MarkSeen(ctx, account, id, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSeen", reflect.TypeOf((*MockDevices)(nil).MarkSeen), ctx, account, id, at)
+}
+
+// RenameDevice mocks base method.
+func (m *MockDevices) RenameDevice(ctx context.Context, account service.Account, id service.DeviceID, name string) (service.Device, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameDevice", ctx, account, id, name)
+	ret0, _ := ret[0].(service.Device)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameDevice indicates an expected call of RenameDevice.
+func (mr *MockDevicesMockRecorder) RenameDevice(ctx, account, id, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameDevice", reflect.TypeOf((*MockDevices)(nil).RenameDevice), ctx, account, id, name)
+}
@@ -0,0 +1,155 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: encryption.go
+//
+// Generated by this command:
+//
+//	mockgen -source encryption.go -package mock -destination mock/encryption.go Encryption DataKeys
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	service "github.com/jakobmoellerdev/octi-sync-server/service"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEncryption is a mock of Encryption interface.
+type MockEncryption struct {
+	ctrl     *gomock.Controller
+	recorder *MockEncryptionMockRecorder
+}
+
+// MockEncryptionMockRecorder is the mock recorder for MockEncryption.
+type MockEncryptionMockRecorder struct {
+	mock *MockEncryption
+}
+
+// NewMockEncryption creates a new mock instance.
+func NewMockEncryption(ctrl *gomock.Controller) *MockEncryption {
+	mock := &MockEncryption{ctrl: ctrl}
+	mock.recorder = &MockEncryptionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEncryption) EXPECT() *MockEncryptionMockRecorder {
+	return m.recorder
+}
+
+// Decrypt mocks base method.
+func (m *MockEncryption) Decrypt(ctx context.Context, account service.Account, keyID string, data io.Reader) (io.Reader, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decrypt", ctx, account, keyID, data)
+	ret0, _ := ret[0].(io.Reader)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Decrypt indicates an expected call of Decrypt.
+func (mr *MockEncryptionMockRecorder) Decrypt(ctx, account, keyID, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decrypt", reflect.TypeOf((*MockEncryption)(nil).Decrypt), ctx, account, keyID, data)
+}
+
+// Encrypt mocks base method.
+func (m *MockEncryption) Encrypt(ctx context.Context, account service.Account, data io.Reader) (io.Reader, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Encrypt", ctx, account, data)
+	ret0, _ := ret[0].(io.Reader)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Encrypt indicates an expected call of Encrypt.
+func (mr *MockEncryptionMockRecorder) Encrypt(ctx, account, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Encrypt", reflect.TypeOf((*MockEncryption)(nil).Encrypt), ctx, account, data)
+}
+
+// MockDataKeys is a mock of DataKeys interface.
+type MockDataKeys struct {
+	ctrl     *gomock.Controller
+	recorder *MockDataKeysMockRecorder
+}
+
+// MockDataKeysMockRecorder is the mock recorder for MockDataKeys.
+type MockDataKeysMockRecorder struct {
+	mock *MockDataKeys
+}
+
+// NewMockDataKeys creates a new mock instance.
+func NewMockDataKeys(ctrl *gomock.Controller) *MockDataKeys {
+	mock := &MockDataKeys{ctrl: ctrl}
+	mock.recorder = &MockDataKeysMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDataKeys) EXPECT() *MockDataKeysMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockDataKeys) Create(ctx context.Context, username string, key service.WrappedDataKey) (service.WrappedDataKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, username, key)
+	ret0, _ := ret[0].(service.WrappedDataKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDataKeysMockRecorder) Create(ctx, username, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDataKeys)(nil).Create), ctx, username, key)
+}
+
+// Get mocks base method.
+func (m *MockDataKeys) Get(ctx context.Context, username string) (service.WrappedDataKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, username)
+	ret0, _ := ret[0].(service.WrappedDataKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockDataKeysMockRecorder) Get(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDataKeys)(nil).Get), ctx, username)
+}
+
+// List mocks base method.
+func (m *MockDataKeys) List(ctx context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockDataKeysMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDataKeys)(nil).List), ctx)
+}
+
+// Set mocks base method.
+func (m *MockDataKeys) Set(ctx context.Context, username string, key service.WrappedDataKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, username, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockDataKeysMockRecorder) Set(ctx, username, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockDataKeys)(nil).Set), ctx, username, key)
+}
@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: events.go
+//
+// Generated by this command:
+//
+//	mockgen -source events.go -package mock -destination mock/events.go Events
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	service "github.com/jakobmoellerdev/octi-sync-server/service"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEvents is a mock of Events interface.
+type MockEvents struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventsMockRecorder
+}
+
+// MockEventsMockRecorder is the mock recorder for MockEvents.
+type MockEventsMockRecorder struct {
+	mock *MockEvents
+}
+
+// NewMockEvents creates a new mock instance.
+func NewMockEvents(ctrl *gomock.Controller) *MockEvents {
+	mock := &MockEvents{ctrl: ctrl}
+	mock.recorder = &MockEventsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEvents) EXPECT() *MockEventsMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockEvents) Publish(ctx context.Context, account service.Account, event service.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, account, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockEventsMockRecorder) Publish(ctx, account, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockEvents)(nil).Publish), ctx, account, event)
+}
+
+// Subscribe mocks base method.
+func (m *MockEvents) Subscribe(ctx context.Context, account service.Account) (<-chan service.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", ctx, account)
+	ret0, _ := ret[0].(<-chan service.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockEventsMockRecorder) Subscribe(ctx, account any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockEvents)(nil).Subscribe), ctx, account)
+}
@@ -12,6 +12,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	service "github.com/jakobmoellerdev/octi-sync-server/service"
 	gomock "go.uber.org/mock/gomock"
@@ -55,6 +56,37 @@ func (mr *MockMetadataProviderMockRecorder) Get(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockMetadataProvider)(nil).Get), ctx, id)
 }
 
+// GetMany mocks base method.
+func (m *MockMetadataProvider) GetMany(ctx context.Context, ids []service.MetadataID) ([]service.Metadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMany", ctx, ids)
+	ret0, _ := ret[0].([]service.Metadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMany indicates an expected call of GetMany.
+func (mr *MockMetadataProviderMockRecorder) GetMany(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMany", reflect.TypeOf((*MockMetadataProvider)(nil).GetMany), ctx, ids)
+}
+
+// List mocks base method.
+func (m *MockMetadataProvider) List(ctx context.Context, opts service.ListOptions) ([]service.Metadata, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, opts)
+	ret0, _ := ret[0].([]service.Metadata)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockMetadataProviderMockRecorder) List(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockMetadataProvider)(nil).List), ctx, opts)
+}
+
 // Set mocks base method.
 func (m *MockMetadataProvider) Set(ctx context.Context, meta service.Metadata) error {
 	m.ctrl.T.Helper()
@@ -92,6 +124,104 @@ func (m *MockMetadata) EXPECT() *MockMetadataMockRecorder {
 	return m.recorder
 }
 
+// GetContentEncoding mocks base method.
+func (m *MockMetadata) GetContentEncoding() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContentEncoding")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetContentEncoding indicates an expected call of GetContentEncoding.
+func (mr *MockMetadataMockRecorder) GetContentEncoding() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContentEncoding", reflect.TypeOf((*MockMetadata)(nil).GetContentEncoding))
+}
+
+// GetContentType mocks base method.
+func (m *MockMetadata) GetContentType() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContentType")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetContentType indicates an expected call of GetContentType.
+func (mr *MockMetadataMockRecorder) GetContentType() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContentType", reflect.TypeOf((*MockMetadata)(nil).GetContentType))
+}
+
+// GetDeletedAt mocks base method.
+func (m *MockMetadata) GetDeletedAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// GetDeletedAt indicates an expected call of GetDeletedAt.
+func (mr *MockMetadataMockRecorder) GetDeletedAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedAt", reflect.TypeOf((*MockMetadata)(nil).GetDeletedAt))
+}
+
+// GetEncoding mocks base method.
+func (m *MockMetadata) GetEncoding() service.ModuleEncoding {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEncoding")
+	ret0, _ := ret[0].(service.ModuleEncoding)
+	return ret0
+}
+
+// GetEncoding indicates an expected call of GetEncoding.
+func (mr *MockMetadataMockRecorder) GetEncoding() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEncoding", reflect.TypeOf((*MockMetadata)(nil).GetEncoding))
+}
+
+// GetExpiresAt mocks base method.
+func (m *MockMetadata) GetExpiresAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiresAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// GetExpiresAt indicates an expected call of GetExpiresAt.
+func (mr *MockMetadataMockRecorder) GetExpiresAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiresAt", reflect.TypeOf((*MockMetadata)(nil).GetExpiresAt))
+}
+
+// GetFilename mocks base method.
+func (m *MockMetadata) GetFilename() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilename")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetFilename indicates an expected call of GetFilename.
+func (mr *MockMetadataMockRecorder) GetFilename() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilename", reflect.TypeOf((*MockMetadata)(nil).GetFilename))
+}
+
+// GetHash mocks base method.
+func (m *MockMetadata) GetHash() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHash")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetHash indicates an expected call of GetHash.
+func (mr *MockMetadataMockRecorder) GetHash() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHash", reflect.TypeOf((*MockMetadata)(nil).GetHash))
+}
+
 // GetID mocks base method.
 func (m *MockMetadata) GetID() service.MetadataID {
 	m.ctrl.T.Helper()
@@ -106,6 +236,20 @@ func (mr *MockMetadataMockRecorder) GetID() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetID", reflect.TypeOf((*MockMetadata)(nil).GetID))
 }
 
+// GetKeyID mocks base method.
+func (m *MockMetadata) GetKeyID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKeyID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetKeyID indicates an expected call of GetKeyID.
+func (mr *MockMetadataMockRecorder) GetKeyID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeyID", reflect.TypeOf((*MockMetadata)(nil).GetKeyID))
+}
+
 // GetModifiedAt mocks base method.
 func (m *MockMetadata) GetModifiedAt() service.ModifiedAt {
 	m.ctrl.T.Helper()
@@ -119,3 +263,45 @@ func (mr *MockMetadataMockRecorder) GetModifiedAt() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModifiedAt", reflect.TypeOf((*MockMetadata)(nil).GetModifiedAt))
 }
+
+// GetSize mocks base method.
+func (m *MockMetadata) GetSize() int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSize")
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// GetSize indicates an expected call of GetSize.
+func (mr *MockMetadataMockRecorder) GetSize() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSize", reflect.TypeOf((*MockMetadata)(nil).GetSize))
+}
+
+// GetType mocks base method.
+func (m *MockMetadata) GetType() service.ModuleType {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetType")
+	ret0, _ := ret[0].(service.ModuleType)
+	return ret0
+}
+
+// GetType indicates an expected call of GetType.
+func (mr *MockMetadataMockRecorder) GetType() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetType", reflect.TypeOf((*MockMetadata)(nil).GetType))
+}
+
+// GetVersion mocks base method.
+func (m *MockMetadata) GetVersion() int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVersion")
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// GetVersion indicates an expected call of GetVersion.
+func (mr *MockMetadataMockRecorder) GetVersion() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersion", reflect.TypeOf((*MockMetadata)(nil).GetVersion))
+}
@@ -12,6 +12,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	service "github.com/jakobmoellerdev/octi-sync-server/service"
 	gomock "go.uber.org/mock/gomock"
@@ -40,6 +41,20 @@ func (m *MockModules) EXPECT() *MockModulesMockRecorder {
 	return m.recorder
 }
 
+// Delete mocks base method.
+func (m *MockModules) Delete(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockModulesMockRecorder) Delete(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockModules)(nil).Delete), ctx, name)
+}
+
 // DeleteByPattern mocks base method.
 func (m *MockModules) DeleteByPattern(ctx context.Context, pattern string) error {
 	m.ctrl.T.Helper()
@@ -69,6 +84,36 @@ func (mr *MockModulesMockRecorder) Get(ctx, name any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockModules)(nil).Get), ctx, name)
 }
 
+// GetMany mocks base method.
+func (m *MockModules) GetMany(ctx context.Context, names []string) ([]service.Module, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMany", ctx, names)
+	ret0, _ := ret[0].([]service.Module)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMany indicates an expected call of GetMany.
+func (mr *MockModulesMockRecorder) GetMany(ctx, names any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMany", reflect.TypeOf((*MockModules)(nil).GetMany), ctx, names)
+}
+
+// GetRange mocks base method.
+func (m *MockModules) GetRange(ctx context.Context, name string, offset, length int64) (service.Module, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRange", ctx, name, offset, length)
+	ret0, _ := ret[0].(service.Module)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRange indicates an expected call of GetRange.
+func (mr *MockModulesMockRecorder) GetRange(ctx, name, offset, length any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRange", reflect.TypeOf((*MockModules)(nil).GetRange), ctx, name, offset, length)
+}
+
 // HealthCheck mocks base method.
 func (m *MockModules) HealthCheck() service.HealthCheck {
 	m.ctrl.T.Helper()
@@ -83,16 +128,46 @@ func (mr *MockModulesMockRecorder) HealthCheck() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HealthCheck", reflect.TypeOf((*MockModules)(nil).HealthCheck))
 }
 
+// List mocks base method.
+func (m *MockModules) List(ctx context.Context, opts service.ListOptions) ([]service.ModuleInfo, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, opts)
+	ret0, _ := ret[0].([]service.ModuleInfo)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockModulesMockRecorder) List(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockModules)(nil).List), ctx, opts)
+}
+
 // Set mocks base method.
-func (m *MockModules) Set(ctx context.Context, name string, module service.Module) error {
+func (m *MockModules) Set(ctx context.Context, name string, module service.Module, expiresAt time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Set", ctx, name, module)
+	ret := m.ctrl.Call(m, "Set", ctx, name, module, expiresAt)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Set indicates an expected call of Set.
-func (mr *MockModulesMockRecorder) Set(ctx, name, module any) *gomock.Call {
+func (mr *MockModulesMockRecorder) Set(ctx, name, module, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockModules)(nil).Set), ctx, name, module, expiresAt)
+}
+
+// SetMany mocks base method.
+func (m *MockModules) SetMany(ctx context.Context, modules map[string]service.Module, expiresAt map[string]time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMany", ctx, modules, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMany indicates an expected call of SetMany.
+func (mr *MockModulesMockRecorder) SetMany(ctx, modules, expiresAt any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockModules)(nil).Set), ctx, name, module)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMany", reflect.TypeOf((*MockModules)(nil).SetMany), ctx, modules, expiresAt)
 }
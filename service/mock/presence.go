@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: presence.go
+//
+// Generated by this command:
+//
+//	mockgen -source presence.go -package mock -destination mock/presence.go Presence
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	service "github.com/jakobmoellerdev/octi-sync-server/service"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPresence is a mock of Presence interface.
+type MockPresence struct {
+	ctrl     *gomock.Controller
+	recorder *MockPresenceMockRecorder
+}
+
+// MockPresenceMockRecorder is the mock recorder for MockPresence.
+type MockPresenceMockRecorder struct {
+	mock *MockPresence
+}
+
+// NewMockPresence creates a new mock instance.
+func NewMockPresence(ctrl *gomock.Controller) *MockPresence {
+	mock := &MockPresence{ctrl: ctrl}
+	mock.recorder = &MockPresenceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPresence) EXPECT() *MockPresenceMockRecorder {
+	return m.recorder
+}
+
+// Connected mocks base method.
+func (m *MockPresence) Connected(ctx context.Context, account service.Account, device service.DeviceID, connection string, until time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Connected", ctx, account, device, connection, until)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Connected indicates an expected call of Connected.
+func (mr *MockPresenceMockRecorder) Connected(ctx, account, device, connection, until any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Connected", reflect.TypeOf((*MockPresence)(nil).Connected), ctx, account, device, connection, until)
+}
+
+// Disconnected mocks base method.
+func (m *MockPresence) Disconnected(ctx context.Context, account service.Account, device service.DeviceID, connection string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Disconnected", ctx, account, device, connection)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Disconnected indicates an expected call of Disconnected.
+func (mr *MockPresenceMockRecorder) Disconnected(ctx, account, device, connection any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disconnected", reflect.TypeOf((*MockPresence)(nil).Disconnected), ctx, account, device, connection)
+}
+
+// Online mocks base method.
+func (m *MockPresence) Online(ctx context.Context, account service.Account) (map[service.DeviceID]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Online", ctx, account)
+	ret0, _ := ret[0].(map[service.DeviceID]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Online indicates an expected call of Online.
+func (mr *MockPresenceMockRecorder) Online(ctx, account any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Online", reflect.TypeOf((*MockPresence)(nil).Online), ctx, account)
+}
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: schemas.go
+//
+// Generated by this command:
+//
+//	mockgen -source schemas.go -package mock -destination mock/schemas.go Schemas
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	service "github.com/jakobmoellerdev/octi-sync-server/service"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSchemas is a mock of Schemas interface.
+type MockSchemas struct {
+	ctrl     *gomock.Controller
+	recorder *MockSchemasMockRecorder
+}
+
+// MockSchemasMockRecorder is the mock recorder for MockSchemas.
+type MockSchemasMockRecorder struct {
+	mock *MockSchemas
+}
+
+// NewMockSchemas creates a new mock instance.
+func NewMockSchemas(ctrl *gomock.Controller) *MockSchemas {
+	mock := &MockSchemas{ctrl: ctrl}
+	mock.recorder = &MockSchemasMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSchemas) EXPECT() *MockSchemasMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockSchemas) List(ctx context.Context) ([]service.Schema, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]service.Schema)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockSchemasMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSchemas)(nil).List), ctx)
+}
+
+// Match mocks base method.
+func (m *MockSchemas) Match(ctx context.Context, module string) (service.Schema, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Match", ctx, module)
+	ret0, _ := ret[0].(service.Schema)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Match indicates an expected call of Match.
+func (mr *MockSchemasMockRecorder) Match(ctx, module any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Match", reflect.TypeOf((*MockSchemas)(nil).Match), ctx, module)
+}
+
+// Validate mocks base method.
+func (m *MockSchemas) Validate(ctx context.Context, module string, data []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", ctx, module, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockSchemasMockRecorder) Validate(ctx, module, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockSchemas)(nil).Validate), ctx, module, data)
+}
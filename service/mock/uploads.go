@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: uploads.go
+//
+// Generated by this command:
+//
+//	mockgen -source uploads.go -package mock -destination mock/uploads.go Uploads
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	service "github.com/jakobmoellerdev/octi-sync-server/service"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUploads is a mock of Uploads interface.
+type MockUploads struct {
+	ctrl     *gomock.Controller
+	recorder *MockUploadsMockRecorder
+}
+
+// MockUploadsMockRecorder is the mock recorder for MockUploads.
+type MockUploadsMockRecorder struct {
+	mock *MockUploads
+}
+
+// NewMockUploads creates a new mock instance.
+func NewMockUploads(ctrl *gomock.Controller) *MockUploads {
+	mock := &MockUploads{ctrl: ctrl}
+	mock.recorder = &MockUploadsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUploads) EXPECT() *MockUploadsMockRecorder {
+	return m.recorder
+}
+
+// Append mocks base method.
+func (m *MockUploads) Append(ctx context.Context, account service.Account, id string, offset int64, chunk []byte) (service.Upload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Append", ctx, account, id, offset, chunk)
+	ret0, _ := ret[0].(service.Upload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockUploadsMockRecorder) Append(ctx, account, id, offset, chunk any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockUploads)(nil).Append), ctx, account, id, offset, chunk)
+}
+
+// Create mocks base method.
+func (m *MockUploads) Create(ctx context.Context, account service.Account, upload service.Upload) (service.Upload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, account, upload)
+	ret0, _ := ret[0].(service.Upload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUploadsMockRecorder) Create(ctx, account, upload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUploads)(nil).Create), ctx, account, upload)
+}
+
+// Data mocks base method.
+func (m *MockUploads) Data(ctx context.Context, account service.Account, id string) (service.Module, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Data", ctx, account, id)
+	ret0, _ := ret[0].(service.Module)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Data indicates an expected call of Data.
+func (mr *MockUploadsMockRecorder) Data(ctx, account, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Data", reflect.TypeOf((*MockUploads)(nil).Data), ctx, account, id)
+}
+
+// Delete mocks base method.
+func (m *MockUploads) Delete(ctx context.Context, account service.Account, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, account, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockUploadsMockRecorder) Delete(ctx, account, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUploads)(nil).Delete), ctx, account, id)
+}
+
+// Get mocks base method.
+func (m *MockUploads) Get(ctx context.Context, account service.Account, id string) (service.Upload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, account, id)
+	ret0, _ := ret[0].(service.Upload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockUploadsMockRecorder) Get(ctx, account, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockUploads)(nil).Get), ctx, account, id)
+}
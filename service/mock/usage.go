@@ -0,0 +1,90 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usage.go
+//
+// Generated by this command:
+//
+//	mockgen -source usage.go -package mock -destination mock/usage.go Usage
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	service "github.com/jakobmoellerdev/octi-sync-server/service"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUsage is a mock of Usage interface.
+type MockUsage struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsageMockRecorder
+}
+
+// MockUsageMockRecorder is the mock recorder for MockUsage.
+type MockUsageMockRecorder struct {
+	mock *MockUsage
+}
+
+// NewMockUsage creates a new mock instance.
+func NewMockUsage(ctrl *gomock.Controller) *MockUsage {
+	mock := &MockUsage{ctrl: ctrl}
+	mock.recorder = &MockUsageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUsage) EXPECT() *MockUsageMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockUsage) Get(ctx context.Context, account service.Account) (service.AccountUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, account)
+	ret0, _ := ret[0].(service.AccountUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockUsageMockRecorder) Get(ctx, account any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockUsage)(nil).Get), ctx, account)
+}
+
+// Record mocks base method.
+func (m *MockUsage) Record(ctx context.Context, account service.Account, module string, size int64, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, account, module, size, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockUsageMockRecorder) Record(ctx, account, module, size, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockUsage)(nil).Record), ctx, account, module, size, expiresAt)
+}
+
+// Remove mocks base method.
+func (m *MockUsage) Remove(ctx context.Context, account service.Account, modules ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, account}
+	for _, a := range modules {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Remove", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockUsageMockRecorder) Remove(ctx, account any, modules ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, account}, modules...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockUsage)(nil).Remove), varargs...)
+}
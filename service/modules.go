@@ -3,14 +3,29 @@ package service
 import (
 	"context"
 	"errors"
+	"time"
 )
 
 //go:generate mockgen -source modules.go -package mock -destination mock/modules.go Modules
 type Modules interface {
-	Set(ctx context.Context, name string, module Module) error
+	// Set writes the module, which is dropped once expiresAt passed unless it is the zero time.
+	Set(ctx context.Context, name string, module Module, expiresAt time.Time) error
 	Get(ctx context.Context, name string) (Module, error)
+	// GetRange reads length bytes of the data of the module starting at offset, without reading the rest of it.
+	// The range is cut at the end of the data, modules that were never written are returned empty.
+	GetRange(ctx context.Context, name string, offset, length int64) (Module, error)
+	// SetMany writes all modules in a single round trip to the backend.
+	// Every module expires at its time in expiresAt, modules without one never expire.
+	SetMany(ctx context.Context, modules map[string]Module, expiresAt map[string]time.Time) error
+	// GetMany reads the modules in a single round trip to the backend, in the order of the names.
+	// Modules that were never written are returned empty, like in Get.
+	GetMany(ctx context.Context, names []string) ([]Module, error)
 	HealthCheck() HealthCheck
 	DeleteByPattern(ctx context.Context, pattern string) error
+	// Delete drops the module, deleting a module that does not exist is not an error.
+	Delete(ctx context.Context, name string) error
+	// List returns the modules whose name starts with the prefix of the options, sorted by name.
+	List(ctx context.Context, opts ListOptions) ([]ModuleInfo, string, error)
 }
 
 var (
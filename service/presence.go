@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockgen -source presence.go -package mock -destination mock/presence.go Presence
+type Presence interface {
+	// Connected marks a connection of the device as online until the given time.
+	// Connections have to be renewed before they expire to keep the device online.
+	Connected(ctx context.Context, account Account, device DeviceID, connection string, until time.Time) error
+	// Disconnected marks a connection of the device as closed, the device stays online if it has other connections.
+	Disconnected(ctx context.Context, account Account, device DeviceID, connection string) error
+	// Online returns all devices of the account that have at least one connection that did not expire.
+	Online(ctx context.Context, account Account) (map[DeviceID]bool, error)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
 
 	"github.com/jakobmoellerdev/octi-sync-server/service"
 )
@@ -35,6 +36,8 @@ func (r *Accounts) Create(ctx context.Context, username string) (service.Account
 		return nil, fmt.Errorf("error while setting user in account key space: %w", err)
 	}
 
+	zerolog.Ctx(ctx).Info().Str("account", username).Msg("account created")
+
 	return account, nil
 }
 
@@ -83,6 +86,8 @@ func (r *Accounts) Share(ctx context.Context, account service.Account) (service.
 		return "", fmt.Errorf("error while pushing shareCode: %w", err)
 	}
 
+	zerolog.Ctx(ctx).Debug().Msg("share code created")
+
 	return shareCode, nil
 }
 
@@ -104,5 +109,7 @@ func (r *Accounts) Revoke(ctx context.Context, shareCode service.ShareCode) erro
 		return fmt.Errorf("error while revoking share code: %w", err)
 	}
 
+	zerolog.Ctx(ctx).Debug().Msg("share code revoked")
+
 	return nil
 }
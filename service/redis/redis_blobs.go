@@ -0,0 +1,221 @@
+package redis
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+	"github.com/jakobmoellerdev/octi-sync-server/service/util"
+)
+
+const (
+	BlobKeySpace = "octi:blobs"
+
+	// BlobReferencePrefix marks module values that reference the blobs of their data chunks by their digests,
+	// separated by BlobReferenceSeparator. Values without it contain the module data itself,
+	// as they were written before modules were stored content addressed.
+	BlobReferencePrefix    = "sha256:"
+	BlobReferenceSeparator = ","
+
+	// DefaultBlobGracePeriod protects the references of writes in flight from being collected as garbage.
+	DefaultBlobGracePeriod = time.Minute
+)
+
+// releaseBlobScript removes the module from the references of the blob and drops the blob
+// once nothing references it anymore. It returns the amount of dropped blobs.
+var releaseBlobScript = redis.NewScript(`
+redis.call("ZREM", KEYS[2], ARGV[1])
+if redis.call("ZCARD", KEYS[2]) == 0 then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// blobKey stores the data of a blob. The digest is a hash tag so that the blob and its references
+// share a cluster slot and can be released atomically.
+func blobKey(digest string) string {
+	return fmt.Sprintf("%s:{%s}", BlobKeySpace, digest)
+}
+
+// blobReferencesKey is a sorted set of the modules referencing the blob, scored by when they started to.
+func blobReferencesKey(digest string) string {
+	return blobKey(digest) + ":refs"
+}
+
+func blobReference(digests []string) string {
+	return BlobReferencePrefix + strings.Join(digests, BlobReferenceSeparator)
+}
+
+// referencedBlobs returns the digests of the blobs referenced by a module value in the order of the data chunks.
+func referencedBlobs(value string) ([]string, bool) {
+	list, isReference := strings.CutPrefix(value, BlobReferencePrefix)
+	if !isReference {
+		return nil, false
+	}
+
+	digests := strings.Split(list, BlobReferenceSeparator)
+
+	for _, digest := range digests {
+		if len(digest) != hex.EncodedLen(sha256.Size) {
+			return nil, false
+		}
+
+		if _, err := hex.DecodeString(digest); err != nil {
+			return nil, false
+		}
+	}
+
+	return digests, true
+}
+
+// uniqueDigests returns every digest once, as chunks with the same data share their blob.
+func uniqueDigests(digests []string) []string {
+	unique := slices.Clone(digests)
+	slices.Sort(unique)
+
+	return slices.Compact(unique)
+}
+
+func releaseBlob(ctx context.Context, client redis.Scripter, digest, name string) (int, error) {
+	dropped, err := releaseBlobScript.Run(
+		ctx, client, []string{blobKey(digest), blobReferencesKey(digest)}, name,
+	).Int()
+	if err != nil {
+		return 0, fmt.Errorf("could not release blob %s of %s: %w", digest, name, err)
+	}
+
+	return dropped, nil
+}
+
+// chunkSizes returns the size of the blob of every chunk.
+func chunkSizes(ctx context.Context, client redis.Cmdable, digests []string) ([]int64, error) {
+	cmds := make([]*redis.IntCmd, len(digests))
+
+	if _, err := client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, digest := range digests {
+			cmds[i] = pipe.StrLen(ctx, blobKey(digest))
+		}
+
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("could not read blob sizes: %w", err)
+	}
+
+	sizes := make([]int64, len(digests))
+	for i, cmd := range cmds {
+		sizes[i] = cmd.Val()
+	}
+
+	return sizes, nil
+}
+
+// blobReader streams the blobs of the chunks one after another.
+func blobReader(ctx context.Context, client redis.Cmdable, digests []string) io.Reader {
+	remaining := digests
+
+	return &chunkReader{next: func() ([]byte, error) {
+		if len(remaining) == 0 {
+			return nil, io.EOF
+		}
+
+		digest := remaining[0]
+		remaining = remaining[1:]
+
+		chunk, err := client.Get(ctx, blobKey(digest)).Bytes()
+		if err != nil {
+			return nil, fmt.Errorf("could not read blob %s: %w", digest, err)
+		}
+
+		return chunk, nil
+	}}
+}
+
+// CollectGarbage releases references of modules and history versions that expired or were overwritten
+// without releasing their blob and drops the blobs that are no longer referenced afterwards.
+func (r *Modules) CollectGarbage(ctx context.Context) (int, error) {
+	gracePeriod := r.GracePeriod
+	if gracePeriod <= 0 {
+		gracePeriod = DefaultBlobGracePeriod
+	}
+
+	keys, err := scanPrefix(ctx, r.Client, BlobKeySpace+":")
+	if err != nil {
+		return 0, err
+	}
+
+	verifiedUntil := strconv.FormatInt(time.Now().Add(-gracePeriod).UnixMilli(), 10)
+	collected := 0
+
+	for _, key := range keys {
+		if strings.HasSuffix(key, ":refs") {
+			continue
+		}
+
+		digest := strings.Trim(strings.TrimPrefix(key, BlobKeySpace+":"), "{}")
+
+		// younger references might belong to writes that did not point their module at the blob yet
+		names, err := r.Client.ZRangeByScore(ctx, blobReferencesKey(digest), &redis.ZRangeBy{
+			Min: "-inf", Max: verifiedUntil,
+		}).Result()
+		if err != nil {
+			return collected, fmt.Errorf("could not read references of blob %s: %w", digest, err)
+		}
+
+		values, err := r.values(ctx, names)
+		if err != nil {
+			return collected, err
+		}
+
+		stale := make([]string, 0, len(names))
+
+		for i, value := range values {
+			if referenced, _ := referencedBlobs(value); !slices.Contains(referenced, digest) {
+				stale = append(stale, names[i])
+			}
+		}
+
+		// a blob without any references is dropped by releasing nothing
+		if len(names) == 0 {
+			stale = append(stale, "")
+		}
+
+		for _, name := range stale {
+			dropped, err := releaseBlob(ctx, r.Client, digest, name)
+			if err != nil {
+				return collected, err
+			}
+
+			collected += dropped
+		}
+	}
+
+	return collected, nil
+}
+
+// StartCollectingGarbage regularly drops blobs that are no longer referenced until the context is done.
+func StartCollectingGarbage(
+	ctx context.Context, interval time.Duration, collector service.GarbageCollector, logger *zerolog.Logger,
+) {
+	collect := func(ctx context.Context) {
+		collected, err := collector.CollectGarbage(ctx)
+		if err != nil {
+			logger.Warn().Err(err).Msg("blob garbage collection failed")
+
+			return
+		}
+
+		logger.Debug().Int("blobs", collected).Msg("blob garbage collection finished")
+	}
+
+	go util.NewIntervalTickerPinger(interval, collect).Start(ctx)
+}
@@ -0,0 +1,34 @@
+package redis_test
+
+import (
+	"context"
+	"testing"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+	"github.com/jakobmoellerdev/octi-sync-server/service/redis"
+	"github.com/jakobmoellerdev/octi-sync-server/service/redis/mock"
+)
+
+func TestModules_CollectGarbage(t *testing.T) {
+	t.Parallel()
+	assertions := assert.New(t)
+	ctrl := gomock.NewController(t)
+	clientMock := mock.NewMockUniversalClient(ctrl)
+	ctx := context.Background()
+	blobKey := redis.BlobKeySpace + ":{" + service.Digest([]byte("unreferenced")) + "}"
+
+	clientMock.EXPECT().Scan(ctx, uint64(0), redis.BlobKeySpace+":*", int64(redis.ScanCount)).
+		Return(goredis.NewScanCmdResult([]string{blobKey, blobKey + ":refs"}, 0, nil))
+	clientMock.EXPECT().ZRangeByScore(ctx, blobKey+":refs", gomock.Any()).
+		Return(goredis.NewStringSliceResult(nil, nil))
+	clientMock.EXPECT().EvalSha(ctx, gomock.Any(), []string{blobKey, blobKey + ":refs"}, gomock.Any()).
+		Return(goredis.NewCmdResult(int64(1), nil))
+
+	collected, err := (&redis.Modules{Client: clientMock}).CollectGarbage(ctx)
+	assertions.NoError(err)
+	assertions.Equal(1, collected)
+}
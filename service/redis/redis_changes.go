@@ -0,0 +1,168 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+const ChangeKeySpace = "octi:changes"
+
+const (
+	changeModuleField     = "module"
+	changeDeviceField     = "device"
+	changeOperationField  = "operation"
+	changeModifiedAtField = "modifiedAt"
+)
+
+var (
+	ErrInvalidChange = errors.New("change in feed could not be parsed")
+
+	streamIDPattern = regexp.MustCompile(`^\d+-\d+$`)
+)
+
+// ChangeFeed keeps the changes of each account in a stream, so that the stream entry ids serve as cursors.
+// Streams are trimmed approximately to Retain entries.
+type ChangeFeed struct {
+	Client redis.Cmdable
+	Retain int64
+}
+
+func (r *ChangeFeed) changeKey(account service.Account) string {
+	return fmt.Sprintf("%s:%s", ChangeKeySpace, account.Username())
+}
+
+func (r *ChangeFeed) Append(ctx context.Context, account service.Account, change service.Change) (string, error) {
+	retain := r.Retain
+	if retain <= 0 {
+		retain = service.DefaultRetainedChanges
+	}
+
+	cursor, err := r.Client.XAdd(ctx, &redis.XAddArgs{
+		Stream: r.changeKey(account),
+		MaxLen: retain,
+		Approx: true,
+		Values: []any{
+			changeModuleField, change.Module,
+			changeDeviceField, change.Device.String(),
+			changeOperationField, string(change.Operation),
+			changeModifiedAtField, change.ModifiedAt.Format(time.RFC3339Nano),
+		},
+	}).Result()
+	if err != nil {
+		return "", fmt.Errorf("appending change of %s failed: %w", change.Module, err)
+	}
+
+	return cursor, nil
+}
+
+func (r *ChangeFeed) Since(
+	ctx context.Context, account service.Account, cursor string, limit int,
+) ([]service.Change, error) {
+	start := "-"
+
+	if cursor != "" {
+		if !streamIDPattern.MatchString(cursor) {
+			return nil, fmt.Errorf("%w: %s is not a stream id", service.ErrInvalidCursor, cursor)
+		}
+		// exclusive ranges skip the change the client has already seen
+		start = "(" + cursor
+	}
+
+	if limit <= 0 {
+		limit = service.DefaultListLimit
+	}
+
+	if cursor != "" {
+		if err := r.checkRetained(ctx, account, cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	messages, err := r.Client.XRangeN(ctx, r.changeKey(account), start, "+", int64(limit)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("reading changes of %s failed: %w", account.Username(), err)
+	}
+
+	changes := make([]service.Change, 0, len(messages))
+
+	for _, message := range messages {
+		change, err := r.decodeChange(message)
+		if err != nil {
+			return nil, err
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// checkRetained fails with service.ErrCursorExpired if the cursor is older than the oldest retained change,
+// as the stream might have been trimmed past changes the client has not seen yet.
+func (r *ChangeFeed) checkRetained(ctx context.Context, account service.Account, cursor string) error {
+	oldest, err := r.Client.XRangeN(ctx, r.changeKey(account), "-", "+", 1).Result()
+	if err != nil {
+		return fmt.Errorf("reading oldest change of %s failed: %w", account.Username(), err)
+	}
+
+	if len(oldest) > 0 && compareStreamIDs(cursor, oldest[0].ID) < 0 {
+		return fmt.Errorf("%w: %s is older than %s", service.ErrCursorExpired, cursor, oldest[0].ID)
+	}
+
+	return nil
+}
+
+// compareStreamIDs compares stream entry ids of the form milliseconds-sequence, which both have to match
+// streamIDPattern, like strings.Compare compares strings.
+func compareStreamIDs(a, b string) int {
+	aTime, aSequence, _ := strings.Cut(a, "-")
+	bTime, bSequence, _ := strings.Cut(b, "-")
+
+	for _, pair := range [][2]string{{aTime, bTime}, {aSequence, bSequence}} {
+		first, _ := strconv.ParseUint(pair[0], 10, 64)
+		second, _ := strconv.ParseUint(pair[1], 10, 64)
+
+		switch {
+		case first < second:
+			return -1
+		case first > second:
+			return 1
+		}
+	}
+
+	return 0
+}
+
+func (r *ChangeFeed) decodeChange(message redis.XMessage) (service.Change, error) {
+	change := service.Change{Cursor: message.ID}
+
+	module, _ := message.Values[changeModuleField].(string)
+	device, _ := message.Values[changeDeviceField].(string)
+	operation, _ := message.Values[changeOperationField].(string)
+	modifiedAt, _ := message.Values[changeModifiedAtField].(string)
+
+	deviceID, err := uuid.Parse(device)
+	if err != nil {
+		return change, fmt.Errorf("%w: device of %s: %w", ErrInvalidChange, message.ID, err)
+	}
+
+	if change.ModifiedAt, err = time.Parse(time.RFC3339Nano, modifiedAt); err != nil {
+		return change, fmt.Errorf("%w: modification time of %s: %w", ErrInvalidChange, message.ID, err)
+	}
+
+	change.Module = module
+	change.Device = service.DeviceID(deviceID)
+	change.Operation = service.ChangeOperation(operation)
+
+	return change, nil
+}
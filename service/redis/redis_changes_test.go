@@ -0,0 +1,65 @@
+package redis_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+	"github.com/jakobmoellerdev/octi-sync-server/service/redis"
+	"github.com/jakobmoellerdev/octi-sync-server/service/redis/mock"
+)
+
+func TestChangeFeed_Since(t *testing.T) {
+	t.Parallel()
+	assertions := assert.New(t)
+	ctrl := gomock.NewController(t)
+	clientMock := mock.NewMockUniversalClient(ctrl)
+	ctx := context.Background()
+	account := service.NewBaseAccount("changes", time.Now())
+	device := uuid.Must(uuid.NewRandom())
+	modifiedAt := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	messages := goredis.NewXMessageSliceCmd(ctx)
+	messages.SetVal([]goredis.XMessage{{
+		ID: "1719835200000-1",
+		Values: map[string]any{
+			"module":     "settings",
+			"device":     device.String(),
+			"operation":  "updated",
+			"modifiedAt": modifiedAt.Format(time.RFC3339Nano),
+		},
+	}})
+
+	oldest := goredis.NewXMessageSliceCmd(ctx)
+	oldest.SetVal([]goredis.XMessage{{ID: "1719835200000-0"}})
+
+	clientMock.EXPECT().XRangeN(ctx, redis.ChangeKeySpace+":changes", "-", "+", int64(1)).
+		Return(oldest).Times(2)
+	clientMock.EXPECT().XRangeN(ctx, redis.ChangeKeySpace+":changes", "(1719835200000-0", "+", int64(10)).
+		Return(messages)
+
+	feed := &redis.ChangeFeed{Client: clientMock}
+
+	changes, err := feed.Since(ctx, account, "1719835200000-0", 10)
+	assertions.NoError(err)
+	assertions.Equal([]service.Change{{
+		Cursor:     "1719835200000-1",
+		Module:     "settings",
+		Device:     service.DeviceID(device),
+		Operation:  service.ChangeOperationUpdated,
+		ModifiedAt: modifiedAt,
+	}}, changes)
+
+	_, err = feed.Since(ctx, account, "not-a-stream-id", 10)
+	assertions.ErrorIs(err, service.ErrInvalidCursor)
+
+	// changes after cursors older than the oldest retained change might have been trimmed
+	_, err = feed.Since(ctx, account, "1719835199999-7", 10)
+	assertions.ErrorIs(err, service.ErrCursorExpired)
+}
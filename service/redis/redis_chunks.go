@@ -0,0 +1,138 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultChunkSize bounds the data that is held in memory per module while it is streamed from or to redis.
+const DefaultChunkSize = 256 << 10
+
+// chunkReader streams data that is read from redis chunk by chunk, only the current chunk is kept in memory.
+type chunkReader struct {
+	// next returns the next chunk of the data or io.EOF after the last one
+	next    func() ([]byte, error)
+	current []byte
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.current) == 0 {
+		chunk, err := r.next()
+		if err != nil {
+			return 0, err
+		}
+
+		r.current = chunk
+	}
+
+	n := copy(p, r.current)
+	r.current = r.current[n:]
+
+	return n, nil
+}
+
+// rangeReader streams the string stored at the key with GETRANGE.
+func rangeReader(ctx context.Context, client redis.Cmdable, key string, size int64, chunkSize int) io.Reader {
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+
+	var offset int64
+
+	return &chunkReader{next: func() ([]byte, error) {
+		if offset >= size {
+			return nil, io.EOF
+		}
+
+		end := min(offset+int64(chunkSize), size)
+
+		chunk, err := client.GetRange(ctx, key, offset, end-1).Bytes()
+		if err != nil {
+			return nil, fmt.Errorf("could not read %s at %d: %w", key, offset, err)
+		}
+
+		if len(chunk) == 0 {
+			return nil, fmt.Errorf("could not read %s at %d: %w", key, offset, io.ErrUnexpectedEOF)
+		}
+
+		offset += int64(len(chunk))
+
+		return chunk, nil
+	}}
+}
+
+// forEachChunk reads the data in chunks of at most chunkSize bytes and passes every chunk to the callback.
+// The chunk is only valid until the callback returns, the callback is not called for empty data.
+func forEachChunk(data io.Reader, chunkSize int, callback func(chunk []byte) error) error {
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+
+	buffer := make([]byte, chunkSize)
+
+	for {
+		// unlike io.ReadFull, this keeps an io.ErrUnexpectedEOF of an aborted stream apart from its regular end
+		var (
+			n   int
+			err error
+		)
+
+		for n < len(buffer) && err == nil {
+			var read int
+			read, err = data.Read(buffer[n:])
+			n += read
+		}
+
+		if n > 0 {
+			if err := callback(buffer[:n]); err != nil {
+				return err
+			}
+		}
+
+		switch {
+		case errors.Is(err, io.EOF):
+			return nil
+		case err != nil:
+			return fmt.Errorf("could not read data: %w", err)
+		}
+	}
+}
+
+// appendChunks replaces the string stored at the key with the data, appending it chunk by chunk.
+// It returns the amount of bytes written, the key does not exist afterwards if the data is empty.
+func appendChunks(
+	ctx context.Context, client redis.Cmdable, key string, data io.Reader, chunkSize int, expiration time.Duration,
+) (int64, error) {
+	if err := client.Del(ctx, key).Err(); err != nil {
+		return 0, fmt.Errorf("could not reset %s: %w", key, err)
+	}
+
+	var written int64
+
+	if err := forEachChunk(data, chunkSize, func(chunk []byte) error {
+		if _, err := client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Append(ctx, key, string(chunk))
+
+			if expiration > 0 {
+				pipe.Expire(ctx, key, expiration)
+			}
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("could not append to %s: %w", key, err)
+		}
+
+		written += int64(len(chunk))
+
+		return nil
+	}); err != nil {
+		return written, err
+	}
+
+	return written, nil
+}
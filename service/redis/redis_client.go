@@ -19,7 +19,7 @@ const (
 )
 
 type (
-	Clients        map[string]goredis.Cmdable
+	Clients        map[string]goredis.UniversalClient
 	ClientMutators map[string]ClientMutator
 	ClientMutator  func(client goredis.UniversalClient) goredis.UniversalClient
 )
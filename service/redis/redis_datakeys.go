@@ -0,0 +1,89 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	json "github.com/json-iterator/go"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+const DataKeyKeySpace = "octi:datakeys"
+
+// DataKeys keeps the wrapped data key of every account in its own key.
+type DataKeys struct {
+	Client redis.Cmdable
+}
+
+func (r *DataKeys) dataKeyKey(username string) string {
+	return fmt.Sprintf("%s:%s", DataKeyKeySpace, username)
+}
+
+func (r *DataKeys) Get(ctx context.Context, username string) (service.WrappedDataKey, error) {
+	data, err := r.Client.Get(ctx, r.dataKeyKey(username)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return service.WrappedDataKey{}, fmt.Errorf("%w: %s", service.ErrNoDataKey, username)
+	}
+
+	if err != nil {
+		return service.WrappedDataKey{}, fmt.Errorf("reading data key of %s failed: %w", username, err)
+	}
+
+	var key service.WrappedDataKey
+	if err := json.Unmarshal(data, &key); err != nil {
+		return service.WrappedDataKey{}, fmt.Errorf("unmarshalling data key of %s failed: %w", username, err)
+	}
+
+	return key, nil
+}
+
+func (r *DataKeys) Create(
+	ctx context.Context, username string, key service.WrappedDataKey,
+) (service.WrappedDataKey, error) {
+	data, err := json.Marshal(key)
+	if err != nil {
+		return service.WrappedDataKey{}, fmt.Errorf("marshalling data key of %s failed: %w", username, err)
+	}
+
+	created, err := r.Client.SetNX(ctx, r.dataKeyKey(username), data, 0).Result()
+	if err != nil {
+		return service.WrappedDataKey{}, fmt.Errorf("persisting data key of %s failed: %w", username, err)
+	}
+
+	if !created {
+		return r.Get(ctx, username)
+	}
+
+	return key, nil
+}
+
+func (r *DataKeys) Set(ctx context.Context, username string, key service.WrappedDataKey) error {
+	data, err := json.Marshal(key)
+	if err != nil {
+		return fmt.Errorf("marshalling data key of %s failed: %w", username, err)
+	}
+
+	if err := r.Client.Set(ctx, r.dataKeyKey(username), data, 0).Err(); err != nil {
+		return fmt.Errorf("persisting data key of %s failed: %w", username, err)
+	}
+
+	return nil
+}
+
+func (r *DataKeys) List(ctx context.Context) ([]string, error) {
+	keys, err := scanPrefix(ctx, r.Client, DataKeyKeySpace+":")
+	if err != nil {
+		return nil, err
+	}
+
+	usernames := make([]string, len(keys))
+	for i, key := range keys {
+		usernames[i] = strings.TrimPrefix(key, DataKeyKeySpace+":")
+	}
+
+	return usernames, nil
+}
@@ -4,37 +4,201 @@ import (
 	"context"
 	"crypto/sha256"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	json "github.com/json-iterator/go"
 	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
 
 	"github.com/jakobmoellerdev/octi-sync-server/service"
 )
 
-const DeviceKeySpace = "octi:devices"
+const (
+	DeviceKeySpace         = "octi:devices"
+	DeviceLastSeenKeySpace = "octi:device-activity"
+)
+
+// deviceUpdateAttempts bounds how often an update is retried while the device is changed concurrently.
+const deviceUpdateAttempts = 5
+
+// replaceRecordScript replaces the record of a device only if it still is the record the update was based on,
+// so that updates never resurrect deleted devices or overwrite concurrent changes.
+// It returns 0 if the device no longer exists, 1 if the record changed meanwhile and 2 once it was replaced.
+var replaceRecordScript = redis.NewScript(`
+local current = redis.call("HGET", KEYS[1], ARGV[1])
+if not current then
+	return 0
+end
+if current ~= ARGV[2] then
+	return 1
+end
+redis.call("HSET", KEYS[1], ARGV[1], ARGV[3])
+return 2
+`)
 
 type Devices struct {
 	Client redis.Cmdable
 }
 
+// deviceRecord is the persisted form of a device in the device key space.
+// Devices registered before records were introduced only carry the hashed password as plain value.
+type deviceRecord struct {
+	HashedPass string `json:"hashedPass"`
+	service.DeviceInfo
+	RegisteredAt time.Time `json:"registeredAt"`
+}
+
 func (r *Devices) deviceKeyForAccount(acc service.Account) string {
 	return fmt.Sprintf("%s:%s", DeviceKeySpace, acc.Username())
 }
 
+func (r *Devices) lastSeenKeyForAccount(acc service.Account) string {
+	return fmt.Sprintf("%s:%s", DeviceLastSeenKeySpace, acc.Username())
+}
+
 func (r *Devices) hashPassword(password string) string {
 	return fmt.Sprintf("%x", sha256.Sum256([]byte(password)))
 }
 
+func (r *Devices) decodeRecord(raw string) (deviceRecord, error) {
+	if !strings.HasPrefix(raw, "{") {
+		return deviceRecord{HashedPass: raw}, nil
+	}
+
+	var record deviceRecord
+	if err := json.Unmarshal([]byte(raw), &record); err != nil {
+		return record, fmt.Errorf("device record could not be parsed: %w", err)
+	}
+
+	return record, nil
+}
+
+func (r *Devices) decodeLastSeen(ctx context.Context, raw string) time.Time {
+	var lastSeen time.Time
+	if raw != "" {
+		// an unparseable timestamp is treated as never seen
+		if err := lastSeen.UnmarshalText([]byte(raw)); err != nil {
+			zerolog.Ctx(ctx).Warn().Err(err).Msg("ignoring unparseable device activity")
+		}
+	}
+
+	return lastSeen
+}
+
+func (r *Devices) toDevice(id service.DeviceID, record deviceRecord, lastSeen time.Time) service.Device {
+	return service.NewBaseDeviceWithInfo(id, record.HashedPass, record.DeviceInfo, record.RegisteredAt, lastSeen)
+}
+
+func (r *Devices) getRecord(
+	ctx context.Context, account service.Account, id service.DeviceID,
+) (deviceRecord, error) {
+	res, err := r.Client.HGet(ctx, r.deviceKeyForAccount(account), id.String()).Result()
+
+	if err == redis.Nil {
+		return deviceRecord{}, service.ErrDeviceNotFound
+	}
+
+	if err != nil {
+		return deviceRecord{}, fmt.Errorf("could not find devices by id: %w", err)
+	}
+
+	return r.decodeRecord(res)
+}
+
+func (r *Devices) setRecord(
+	ctx context.Context, account service.Account, id service.DeviceID, record deviceRecord,
+) error {
+	data, err := json.Marshal(&record)
+	if err != nil {
+		return fmt.Errorf("could not marshal device record: %w", err)
+	}
+
+	if err := r.Client.HSet(ctx, r.deviceKeyForAccount(account), id.String(), data).Err(); err != nil {
+		return fmt.Errorf("could not push device record: %w", err)
+	}
+
+	return nil
+}
+
+// updateRecord applies update to the record of an existing device as a compare-and-set,
+// retrying with the latest record as long as the device is changed concurrently.
+func (r *Devices) updateRecord(
+	ctx context.Context, account service.Account, id service.DeviceID, update func(*deviceRecord),
+) error {
+	key := r.deviceKeyForAccount(account)
+
+	for attempt := 0; attempt < deviceUpdateAttempts; attempt++ {
+		raw, err := r.Client.HGet(ctx, key, id.String()).Result()
+		if err == redis.Nil {
+			return service.ErrDeviceNotFound
+		}
+
+		if err != nil {
+			return fmt.Errorf("could not find devices by id: %w", err)
+		}
+
+		record, err := r.decodeRecord(raw)
+		if err != nil {
+			return err
+		}
+
+		update(&record)
+
+		data, err := json.Marshal(&record)
+		if err != nil {
+			return fmt.Errorf("could not marshal device record: %w", err)
+		}
+
+		result, err := replaceRecordScript.Run(ctx, r.Client, []string{key}, id.String(), raw, data).Int()
+		if err != nil {
+			return fmt.Errorf("could not push device record: %w", err)
+		}
+
+		switch result {
+		case 0:
+			return service.ErrDeviceNotFound
+		case 2:
+			return nil
+		}
+	}
+
+	return fmt.Errorf("device record changed concurrently %d times", deviceUpdateAttempts)
+}
+
 func (r *Devices) AddDevice(
-	ctx context.Context, account service.Account, id service.DeviceID, password string,
+	ctx context.Context, account service.Account, id service.DeviceID, password string, info service.DeviceInfo,
 ) (service.Device, error) {
-	hashed := r.hashPassword(password)
+	record := deviceRecord{
+		HashedPass:   r.hashPassword(password),
+		DeviceInfo:   info,
+		RegisteredAt: time.Now(),
+	}
+
+	msg := "device registered"
 
-	if err := r.Client.HSet(ctx, r.deviceKeyForAccount(account), id.String(), hashed).Err(); err != nil {
+	// re-registrations keep the original registration time and all info that was not resupplied
+	if existing, err := r.getRecord(ctx, account, id); err == nil {
+		record.DeviceInfo = info.Merge(existing.DeviceInfo)
+
+		if !existing.RegisteredAt.IsZero() {
+			record.RegisteredAt = existing.RegisteredAt
+		}
+
+		msg = "device re-registered"
+	}
+
+	if err := r.setRecord(ctx, account, id, record); err != nil {
 		return nil, fmt.Errorf("could not push device id for registration: %w", err)
 	}
 
-	return service.NewBaseDevice(id, hashed), nil
+	zerolog.Ctx(ctx).Info().Str("account", account.Username()).Str("device", id.String()).
+		Str("status", string(record.Status)).Msg(msg)
+
+	lastSeen, _ := r.Client.HGet(ctx, r.lastSeenKeyForAccount(account), id.String()).Result()
+
+	return r.toDevice(id, record, r.decodeLastSeen(ctx, lastSeen)), nil
 }
 
 func (r *Devices) GetDevices(
@@ -46,33 +210,80 @@ func (r *Devices) GetDevices(
 		return nil, fmt.Errorf("could not find devices by account: %w", err)
 	}
 
+	lastSeen, err := r.Client.HGetAll(ctx, r.lastSeenKeyForAccount(account)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("could not find device activity by account: %w", err)
+	}
+
 	devices := make(map[service.DeviceID]service.Device, len(res))
 
-	for id, pass := range res {
+	for id, raw := range res {
 		deviceUUID, err := uuid.Parse(id)
 		if err != nil {
 			return devices, fmt.Errorf("device id could not be parsed: %w", err)
 		}
 
+		record, err := r.decodeRecord(raw)
+		if err != nil {
+			return devices, err
+		}
+
 		deviceID := service.DeviceID(deviceUUID)
-		devices[deviceID] = service.NewBaseDevice(deviceID, pass)
+		devices[deviceID] = r.toDevice(deviceID, record, r.decodeLastSeen(ctx, lastSeen[id]))
 	}
 
 	return devices, nil
 }
 
 func (r *Devices) GetDevice(ctx context.Context, account service.Account, id service.DeviceID) (service.Device, error) {
-	res, err := r.Client.HGet(ctx, r.deviceKeyForAccount(account), id.String()).Result()
+	record, err := r.getRecord(ctx, account, id)
+	if err != nil {
+		return nil, err
+	}
 
-	if err == redis.Nil {
-		return nil, service.ErrDeviceNotFound
+	lastSeen, err := r.Client.HGet(ctx, r.lastSeenKeyForAccount(account), id.String()).Result()
+	if err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("could not find device activity by id: %w", err)
 	}
 
-	if err != nil {
-		return nil, fmt.Errorf("could not find devices by id: %w", err)
+	return r.toDevice(id, record, r.decodeLastSeen(ctx, lastSeen)), nil
+}
+
+func (r *Devices) RenameDevice(
+	ctx context.Context, account service.Account, id service.DeviceID, name string,
+) (service.Device, error) {
+	if err := r.updateRecord(ctx, account, id, func(record *deviceRecord) {
+		record.Name = name
+	}); err != nil {
+		return nil, fmt.Errorf("could not rename device: %w", err)
+	}
+
+	return r.GetDevice(ctx, account, id)
+}
+
+func (r *Devices) ApproveDevice(
+	ctx context.Context, account service.Account, id service.DeviceID,
+) (service.Device, error) {
+	if err := r.updateRecord(ctx, account, id, func(record *deviceRecord) {
+		record.Status = service.DeviceStatusApproved
+	}); err != nil {
+		return nil, fmt.Errorf("could not approve device: %w", err)
+	}
+
+	zerolog.Ctx(ctx).Info().Str("approved-device", id.String()).Msg("device approved")
+
+	return r.GetDevice(ctx, account, id)
+}
+
+func (r *Devices) MarkSeen(ctx context.Context, account service.Account, id service.DeviceID, at time.Time) error {
+	// cannot err out as the time is always valid
+	lastSeen, _ := at.UTC().MarshalText()
+
+	if err := r.Client.HSet(ctx, r.lastSeenKeyForAccount(account), id.String(), lastSeen).Err(); err != nil {
+		return fmt.Errorf("could not record device activity: %w", err)
 	}
 
-	return service.NewBaseDevice(id, res), nil
+	return nil
 }
 
 func (r *Devices) DeleteDevice(
@@ -84,6 +295,12 @@ func (r *Devices) DeleteDevice(
 		return fmt.Errorf("deletion of device failed: %w", err)
 	}
 
+	if err := r.Client.HDel(ctx, r.lastSeenKeyForAccount(account), id.String()).Err(); err != nil {
+		return fmt.Errorf("deletion of device activity failed: %w", err)
+	}
+
+	zerolog.Ctx(ctx).Info().Str("deleted-device", id.String()).Msg("device deleted")
+
 	return nil
 }
 
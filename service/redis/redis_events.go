@@ -0,0 +1,154 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	json "github.com/json-iterator/go"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+const EventChannelSpace = "octi:events"
+
+// Events publishes events through one Pub/Sub channel per account. Every replica holds a single
+// subscription to all of these channels and fans out the events to its local subscribers.
+type Events struct {
+	Client redis.UniversalClient
+
+	sync        sync.RWMutex
+	pubSub      *redis.PubSub
+	subscribers map[string]map[chan service.Event]struct{}
+}
+
+// eventMessage is the published form of a service.Event.
+type eventMessage struct {
+	Type      service.EventType       `json:"type"`
+	Device    uuid.UUID               `json:"device"`
+	Module    string                  `json:"module,omitempty"`
+	Operation service.ChangeOperation `json:"operation,omitempty"`
+	Cursor    string                  `json:"cursor,omitempty"`
+	At        time.Time               `json:"at"`
+}
+
+func (r *Events) eventChannel(account service.Account) string {
+	return fmt.Sprintf("%s:%s", EventChannelSpace, account.Username())
+}
+
+func (r *Events) Publish(ctx context.Context, account service.Account, event service.Event) error {
+	payload, err := json.Marshal(eventMessage{
+		Type:      event.Type,
+		Device:    event.Device.UUID(),
+		Module:    event.Module,
+		Operation: event.Operation,
+		Cursor:    event.Cursor,
+		At:        event.At,
+	})
+	if err != nil {
+		return fmt.Errorf("marshalling %s event failed: %w", event.Type, err)
+	}
+
+	if err := r.Client.Publish(ctx, r.eventChannel(account), payload).Err(); err != nil {
+		return fmt.Errorf("publishing %s event failed: %w", event.Type, err)
+	}
+
+	return nil
+}
+
+// Subscribe registers a local subscriber for the events of the account. All subscribers of a replica share a
+// single pattern subscription, events are fanned out to them in memory and dropped for subscribers with full buffers.
+func (r *Events) Subscribe(ctx context.Context, account service.Account) (<-chan service.Event, error) {
+	r.sync.Lock()
+	defer r.sync.Unlock()
+
+	if r.pubSub == nil {
+		if err := r.listen(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	channel := r.eventChannel(account)
+	subscriber := make(chan service.Event, service.EventBuffer)
+
+	subscribers := r.subscribers[channel]
+	if subscribers == nil {
+		subscribers = make(map[chan service.Event]struct{})
+		r.subscribers[channel] = subscribers
+	}
+
+	subscribers[subscriber] = struct{}{}
+
+	go func() {
+		<-ctx.Done()
+
+		r.sync.Lock()
+		defer r.sync.Unlock()
+
+		delete(subscribers, subscriber)
+
+		if len(subscribers) == 0 {
+			delete(r.subscribers, channel)
+		}
+
+		close(subscriber)
+	}()
+
+	return subscriber, nil
+}
+
+// listen opens the pattern subscription shared by all subscribers of the replica. It lives as long as the replica,
+// the client takes care of reconnecting and resubscribing.
+func (r *Events) listen(ctx context.Context) error {
+	pubSub := r.Client.PSubscribe(context.Background(), EventChannelSpace+":*")
+
+	// the subscription has to be confirmed, otherwise events published right after returning could be missed
+	if _, err := pubSub.Receive(ctx); err != nil {
+		_ = pubSub.Close()
+
+		return fmt.Errorf("subscribing to events failed: %w", err)
+	}
+
+	r.pubSub = pubSub
+	r.subscribers = make(map[string]map[chan service.Event]struct{})
+
+	go r.dispatch(zerolog.Ctx(ctx), pubSub.Channel())
+
+	return nil
+}
+
+func (r *Events) dispatch(logger *zerolog.Logger, messages <-chan *redis.Message) {
+	for message := range messages {
+		var event eventMessage
+		if err := json.Unmarshal([]byte(message.Payload), &event); err != nil {
+			logger.Warn().Err(err).Str("channel", message.Channel).Msg("could not parse event")
+
+			continue
+		}
+
+		r.fanOut(message.Channel, service.Event{
+			Type:      event.Type,
+			Device:    service.DeviceID(event.Device),
+			Module:    event.Module,
+			Operation: event.Operation,
+			Cursor:    event.Cursor,
+			At:        event.At,
+		})
+	}
+}
+
+func (r *Events) fanOut(channel string, event service.Event) {
+	r.sync.RLock()
+	defer r.sync.RUnlock()
+
+	for subscriber := range r.subscribers[channel] {
+		select {
+		case subscriber <- event:
+		default:
+		}
+	}
+}
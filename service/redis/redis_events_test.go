@@ -0,0 +1,42 @@
+package redis_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+	"github.com/jakobmoellerdev/octi-sync-server/service/redis"
+	"github.com/jakobmoellerdev/octi-sync-server/service/redis/mock"
+)
+
+func TestEvents_Publish(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	clientMock := mock.NewMockUniversalClient(ctrl)
+	ctx := context.Background()
+	account := service.NewBaseAccount("events", time.Now())
+	device := uuid.MustParse("5c8b4a3e-1f0e-4b55-9a53-7e0d1c6b2a10")
+	at := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	clientMock.EXPECT().Publish(ctx, redis.EventChannelSpace+":events", []byte(
+		`{"type":"module-changed","device":"5c8b4a3e-1f0e-4b55-9a53-7e0d1c6b2a10","module":"settings",`+
+			`"operation":"updated","cursor":"1-0","at":"2024-07-01T12:00:00Z"}`,
+	)).Return(goredis.NewIntResult(1, nil))
+
+	events := &redis.Events{Client: clientMock}
+
+	assert.NoError(t, events.Publish(ctx, account, service.Event{
+		Type:      service.EventModuleChanged,
+		Device:    service.DeviceID(device),
+		Module:    "settings",
+		Operation: service.ChangeOperationUpdated,
+		Cursor:    "1-0",
+		At:        at,
+	}))
+}
@@ -0,0 +1,383 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	json "github.com/json-iterator/go"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+	"github.com/jakobmoellerdev/octi-sync-server/service/util"
+)
+
+const (
+	HistoryKeySpace = "octi:history"
+
+	// historyDataField holds the data of versions recorded before their data was streamed into keys of their own.
+	historyDataField     = "data:"
+	historyMetadataField = "metadata:"
+	historyBlobsField    = "blobs:"
+)
+
+// History keeps the metadata of all versions of a module in a single hash with one field per version.
+// Every version references the blobs the module was stored in when the version was written in a key of its own
+// next to the hash, so that versions share their data with the module and with each other like modules do.
+// Versions of modules stored before blobs were introduced have their data streamed into a key of their own instead.
+// The history of a module expires together with the module, or else Expiration after its last write.
+type History struct {
+	Client     redis.Cmdable
+	Retention  service.HistoryRetention
+	Expiration time.Duration
+	// ChunkSize is the maximum amount of data held in memory per version while streaming, DefaultChunkSize if 0
+	ChunkSize int
+}
+
+func (r *History) historyKey(id service.MetadataID) string {
+	return fmt.Sprintf("%s:%s", HistoryKeySpace, id)
+}
+
+func (r *History) dataKey(id service.MetadataID, version string) string {
+	return fmt.Sprintf("%s:%s%s", r.historyKey(id), historyDataField, version)
+}
+
+// blobsKey holds the blob references of the version, the blobs list it among their references by this name.
+func (r *History) blobsKey(id service.MetadataID, version string) string {
+	return fmt.Sprintf("%s:%s%s", r.historyKey(id), historyBlobsField, version)
+}
+
+// Record references the data of the version before adding its metadata,
+// so that versions are only listed once their data is complete.
+func (r *History) Record(ctx context.Context, meta service.Metadata) error {
+	key := r.historyKey(meta.GetID())
+	version := strconv.FormatInt(meta.GetVersion(), 10)
+
+	metadata, err := json.Marshal(service.MetadataOf(meta))
+	if err != nil {
+		return fmt.Errorf("marshalling history metadata of %s failed: %w", key, err)
+	}
+
+	if err := r.recordData(ctx, meta, version); err != nil {
+		return fmt.Errorf("persisting data of version %s of %s failed: %w", version, key, err)
+	}
+
+	if _, err := r.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, historyMetadataField+version, metadata)
+		r.expire(ctx, pipe, key, meta)
+
+		return nil
+	}); err != nil {
+		return fmt.Errorf("persisting version %s of %s failed: %w", version, key, err)
+	}
+
+	versions, err := r.Versions(ctx, meta.GetID())
+	if err != nil {
+		return err
+	}
+
+	kept, dropped := r.Retention.Retained(versions, time.Now())
+
+	if err := r.refresh(ctx, meta, kept); err != nil {
+		return err
+	}
+
+	if len(dropped) == 0 {
+		return nil
+	}
+
+	return r.drop(ctx, meta.GetID(), dropped)
+}
+
+// recordData points the version at the blobs the module is stored in, which is kept under its id.
+// The data of modules that do not reference blobs is streamed into the data key of the version instead.
+func (r *History) recordData(ctx context.Context, meta service.Metadata, version string) error {
+	value, err := r.Client.Get(ctx, string(meta.GetID())).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return fmt.Errorf("could not read blob references of %s: %w", meta.GetID(), err)
+	}
+
+	digests, isReference := referencedBlobs(value)
+	if !isReference {
+		_, err := appendChunks(
+			ctx, r.Client, r.dataKey(meta.GetID(), version), strings.NewReader(value), r.ChunkSize, r.expiration(meta),
+		)
+
+		return err
+	}
+
+	blobsKey := r.blobsKey(meta.GetID(), version)
+
+	// the references are added before the version points at the blobs, so that a concurrent release
+	// cannot drop them, and like for modules chunks with the same data are referenced only once
+	if _, err := r.Client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		referencedAt := float64(time.Now().UnixMilli())
+
+		for _, digest := range uniqueDigests(digests) {
+			pipe.ZAdd(ctx, blobReferencesKey(digest), redis.Z{Score: referencedAt, Member: blobsKey})
+		}
+
+		return nil
+	}); err != nil {
+		return fmt.Errorf("could not reference blobs: %w", err)
+	}
+
+	if _, err := r.Client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, blobsKey, value, 0)
+		r.expire(ctx, pipe, blobsKey, meta)
+
+		return nil
+	}); err != nil {
+		return fmt.Errorf("could not point %s at its blobs: %w", blobsKey, err)
+	}
+
+	return nil
+}
+
+// refresh sets the expiry of the data of the kept versions to the one of the hash of their metadata,
+// which follows the expiry of the recorded version.
+func (r *History) refresh(ctx context.Context, meta service.Metadata, kept []service.Metadata) error {
+	if _, err := r.Client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, version := range kept {
+			v := strconv.FormatInt(version.GetVersion(), 10)
+			r.expire(ctx, pipe, r.blobsKey(meta.GetID(), v), meta)
+			r.expire(ctx, pipe, r.dataKey(meta.GetID(), v), meta)
+		}
+
+		return nil
+	}); err != nil {
+		return fmt.Errorf("refreshing expiry of versions of %s failed: %w", r.historyKey(meta.GetID()), err)
+	}
+
+	return nil
+}
+
+// expire lets the key expire with the module of the version, or else Expiration after the version was recorded.
+func (r *History) expire(ctx context.Context, pipe redis.Pipeliner, key string, meta service.Metadata) {
+	switch {
+	case !meta.GetExpiresAt().IsZero():
+		pipe.ExpireAt(ctx, key, meta.GetExpiresAt())
+	case r.Expiration > 0:
+		pipe.Expire(ctx, key, r.Expiration)
+	default:
+		pipe.Persist(ctx, key)
+	}
+}
+
+// expiration returns the time the data of the version is kept while it is recorded, 0 if it does not expire.
+func (r *History) expiration(meta service.Metadata) time.Duration {
+	if meta.GetExpiresAt().IsZero() {
+		return r.Expiration
+	}
+
+	// data of versions expiring while they are recorded is dropped right away
+	return max(time.Until(meta.GetExpiresAt()), time.Millisecond)
+}
+
+func (r *History) drop(ctx context.Context, id service.MetadataID, dropped []service.Metadata) error {
+	key := r.historyKey(id)
+	fields := make([]string, 0, 2*len(dropped))
+
+	for _, version := range dropped {
+		v := strconv.FormatInt(version.GetVersion(), 10)
+		fields = append(fields, historyDataField+v, historyMetadataField+v)
+	}
+
+	if err := r.Client.HDel(ctx, key, fields...).Err(); err != nil {
+		return fmt.Errorf("dropping versions of %s failed: %w", key, err)
+	}
+
+	var errs []error
+
+	// data keys are deleted one by one as they may belong to different cluster slots
+	for _, version := range dropped {
+		v := strconv.FormatInt(version.GetVersion(), 10)
+
+		if err := r.release(ctx, r.blobsKey(id, v)); err != nil {
+			errs = append(errs, err)
+		}
+
+		dataKey := r.dataKey(id, v)
+		if err := r.Client.Del(ctx, dataKey).Err(); err != nil {
+			errs = append(errs, fmt.Errorf("dropping %s failed: %w", dataKey, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return util.MultiError(errs)
+	}
+
+	return nil
+}
+
+// release deletes the blob references of a version and releases its blobs,
+// blobs that fail to be released are dropped by the garbage collection later on.
+func (r *History) release(ctx context.Context, blobsKey string) error {
+	value, err := r.Client.GetDel(ctx, blobsKey).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return fmt.Errorf("dropping %s failed: %w", blobsKey, err)
+	}
+
+	digests, _ := referencedBlobs(value)
+
+	for _, digest := range uniqueDigests(digests) {
+		if _, err := releaseBlob(ctx, r.Client, digest, blobsKey); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *History) Versions(ctx context.Context, id service.MetadataID) ([]service.Metadata, error) {
+	key := r.historyKey(id)
+
+	fields, err := r.Client.HKeys(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("reading versions of %s failed: %w", key, err)
+	}
+
+	metadataFields := make([]string, 0, len(fields)/2)
+
+	for _, field := range fields {
+		if strings.HasPrefix(field, historyMetadataField) {
+			metadataFields = append(metadataFields, field)
+		}
+	}
+
+	if len(metadataFields) == 0 {
+		return []service.Metadata{}, nil
+	}
+
+	values, err := r.Client.HMGet(ctx, key, metadataFields...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("reading version metadata of %s failed: %w", key, err)
+	}
+
+	versions := make([]service.Metadata, 0, len(values))
+
+	for _, value := range values {
+		raw, found := value.(string)
+		if !found {
+			// dropped between listing and reading
+			continue
+		}
+
+		var metadata service.BaseMetadata
+		if err := json.Unmarshal([]byte(raw), &metadata); err != nil {
+			return nil, fmt.Errorf("unmarshalling version metadata of %s failed: %w", key, err)
+		}
+
+		versions = append(versions, &metadata)
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].GetVersion() > versions[j].GetVersion()
+	})
+
+	return versions, nil
+}
+
+func (r *History) Get(
+	ctx context.Context, id service.MetadataID, version int64,
+) (service.Module, service.Metadata, error) {
+	key := r.historyKey(id)
+	v := strconv.FormatInt(version, 10)
+
+	values, err := r.Client.HMGet(ctx, key, historyDataField+v, historyMetadataField+v).Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading version %s of %s failed: %w", v, key, err)
+	}
+
+	raw, metadataFound := values[1].(string)
+	if !metadataFound {
+		return nil, nil, fmt.Errorf("%w: %s version %s", service.ErrVersionNotFound, id, v)
+	}
+
+	var metadata service.BaseMetadata
+	if err := json.Unmarshal([]byte(raw), &metadata); err != nil {
+		return nil, nil, fmt.Errorf("unmarshalling version metadata of %s failed: %w", key, err)
+	}
+
+	if data, legacy := values[0].(string); legacy {
+		return ModuleFromBytes([]byte(data)), &metadata, nil
+	}
+
+	reference, err := r.Client.Get(ctx, r.blobsKey(id, v)).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, nil, fmt.Errorf("reading blob references of version %s of %s failed: %w", v, key, err)
+	}
+
+	if digests, isReference := referencedBlobs(reference); isReference {
+		sizes, err := chunkSizes(ctx, r.Client, digests)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var size int64
+		for _, chunkSize := range sizes {
+			size += chunkSize
+		}
+
+		return ModuleFromReader(blobReader(ctx, r.Client, digests), int(size)), &metadata, nil
+	}
+
+	// versions recorded before they referenced blobs have their data streamed into a key of their own,
+	// empty versions have no data key
+	dataKey := r.dataKey(id, v)
+
+	size, err := r.Client.StrLen(ctx, dataKey).Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading size of version %s of %s failed: %w", v, key, err)
+	}
+
+	return ModuleFromReader(rangeReader(ctx, r.Client, dataKey, size, r.ChunkSize), int(size)), &metadata, nil
+}
+
+// Delete drops all versions like the retention drops them, so that their blobs are released
+// and their data keys are deleted as well, and deletes the hash afterwards.
+func (r *History) Delete(ctx context.Context, id service.MetadataID) error {
+	versions, err := r.Versions(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if len(versions) > 0 {
+		if err := r.drop(ctx, id, versions); err != nil {
+			return err
+		}
+	}
+
+	if err := r.Client.Del(ctx, r.historyKey(id)).Err(); err != nil {
+		return fmt.Errorf("error while deleting %s: %w", r.historyKey(id), err)
+	}
+
+	return nil
+}
+
+// DeleteByPrefix deletes the keys of all versions, the blobs they referenced are released by the garbage collection.
+func (r *History) DeleteByPrefix(ctx context.Context, prefix string) error {
+	keys, err := scanPrefix(ctx, r.Client, r.historyKey(service.MetadataID(prefix)))
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	// keys are deleted one by one as they may belong to different cluster slots
+	for _, key := range keys {
+		if err := r.Client.Del(ctx, key).Err(); err != nil {
+			errs = append(errs, fmt.Errorf("error while deleting %s: %w", key, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return util.MultiError(errs)
+	}
+
+	return nil
+}
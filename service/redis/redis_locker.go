@@ -0,0 +1,124 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+const (
+	LockKeySpace = "octi:locks"
+
+	// DefaultLockExpiration releases locks of crashed holders eventually.
+	// Locks of live holders are extended before they expire until they are released.
+	DefaultLockExpiration = 30 * time.Second
+	// lockExtensions is how often a held lock is extended within its expiration.
+	lockExtensions = 3
+	// DefaultLockRetryInterval is the time between two attempts to acquire a held lock.
+	DefaultLockRetryInterval = 25 * time.Millisecond
+)
+
+// unlockScript only deletes the lock if it is still held with the token of the caller,
+// so an expired lock that was acquired by someone else in the meantime is left alone.
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// extendScript only extends the lock if it is still held with the token of the caller.
+var extendScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// Locker holds locks across all server instances sharing the redis.
+type Locker struct {
+	Client        redis.Cmdable
+	Expiration    time.Duration
+	RetryInterval time.Duration
+}
+
+func (r *Locker) lockKey(key string) string {
+	return fmt.Sprintf("%s:%s", LockKeySpace, key)
+}
+
+func (r *Locker) Lock(ctx context.Context, key string) (service.Unlock, error) {
+	expiration, retryInterval := r.Expiration, r.RetryInterval
+	if expiration <= 0 {
+		expiration = DefaultLockExpiration
+	}
+
+	if retryInterval <= 0 {
+		retryInterval = DefaultLockRetryInterval
+	}
+
+	token := uuid.NewString()
+	lockKey := r.lockKey(key)
+
+	for {
+		acquired, err := r.Client.SetNX(ctx, lockKey, token, expiration).Result()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", service.ErrLockNotAcquired, key, err)
+		}
+
+		if acquired {
+			released := make(chan struct{})
+
+			go r.extend(context.WithoutCancel(ctx), key, token, expiration, released)
+
+			return func(ctx context.Context) error {
+				close(released)
+
+				if err := unlockScript.Run(ctx, r.Client, []string{lockKey}, token).Err(); err != nil {
+					return fmt.Errorf("could not release lock %s: %w", key, err)
+				}
+
+				return nil
+			}, nil
+		}
+
+		select {
+		case <-time.After(retryInterval):
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %s: %w", service.ErrLockNotAcquired, key, ctx.Err())
+		}
+	}
+}
+
+// extend keeps the lock from expiring while its holder is alive, until it is released or was lost.
+func (r *Locker) extend(ctx context.Context, key, token string, expiration time.Duration, released <-chan struct{}) {
+	lockKey := r.lockKey(key)
+	ticker := time.NewTicker(expiration / lockExtensions)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-released:
+			return
+		case <-ticker.C:
+		}
+
+		extended, err := extendScript.Run(ctx, r.Client, []string{lockKey}, token, expiration.Milliseconds()).Int()
+		if err != nil {
+			zerolog.Ctx(ctx).Warn().Err(err).Str("lock", key).Msg("could not extend lock")
+
+			continue
+		}
+
+		if extended == 0 {
+			zerolog.Ctx(ctx).Warn().Str("lock", key).Msg("lock expired before it was released")
+
+			return
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package redis_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+	"github.com/jakobmoellerdev/octi-sync-server/service/redis"
+	"github.com/jakobmoellerdev/octi-sync-server/service/redis/mock"
+)
+
+func TestLocker_Lock(t *testing.T) {
+	t.Parallel()
+	assertions := assert.New(t)
+	ctrl := gomock.NewController(t)
+	clientMock := mock.NewMockUniversalClient(ctrl)
+	ctx := context.Background()
+	lockKey := redis.LockKeySpace + ":module"
+
+	held := goredis.NewBoolCmd(ctx)
+	held.SetVal(false)
+	acquired := goredis.NewBoolCmd(ctx)
+	acquired.SetVal(true)
+
+	gomock.InOrder(
+		clientMock.EXPECT().SetNX(ctx, lockKey, gomock.Any(), time.Second).Return(held),
+		clientMock.EXPECT().SetNX(ctx, lockKey, gomock.Any(), time.Second).Return(acquired),
+	)
+	clientMock.EXPECT().EvalSha(ctx, gomock.Any(), []string{lockKey}, gomock.Any()).
+		Return(goredis.NewCmdResult(int64(1), nil))
+
+	locker := &redis.Locker{Client: clientMock, Expiration: time.Second, RetryInterval: time.Millisecond}
+
+	unlock, err := locker.Lock(ctx, "module")
+	if assertions.NoError(err) {
+		assertions.NoError(unlock(ctx))
+	}
+}
+
+func TestLocker_LockExtended(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	clientMock := mock.NewMockUniversalClient(ctrl)
+	ctx := context.Background()
+	lockKey := redis.LockKeySpace + ":module"
+
+	acquired := goredis.NewBoolCmd(ctx)
+	acquired.SetVal(true)
+
+	clientMock.EXPECT().SetNX(ctx, lockKey, gomock.Any(), 30*time.Millisecond).Return(acquired)
+	// the lock is extended while it is held and released afterwards
+	clientMock.EXPECT().EvalSha(gomock.Any(), gomock.Any(), []string{lockKey}, gomock.Any()).
+		Return(goredis.NewCmdResult(int64(1), nil)).MinTimes(3)
+
+	locker := &redis.Locker{Client: clientMock, Expiration: 30 * time.Millisecond}
+
+	unlock, err := locker.Lock(ctx, "module")
+	if assert.NoError(t, err) {
+		time.Sleep(100 * time.Millisecond)
+		assert.NoError(t, unlock(ctx))
+	}
+}
+
+func TestLocker_LockTimeout(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	clientMock := mock.NewMockUniversalClient(ctrl)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	held := goredis.NewBoolCmd(ctx)
+	held.SetVal(false)
+
+	clientMock.EXPECT().SetNX(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(held).MinTimes(1)
+
+	locker := &redis.Locker{Client: clientMock, RetryInterval: time.Millisecond}
+
+	_, err := locker.Lock(ctx, "module")
+	assert.ErrorIs(t, err, service.ErrLockNotAcquired)
+}
@@ -7,6 +7,7 @@ import (
 
 	json "github.com/json-iterator/go"
 	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
 
 	"github.com/jakobmoellerdev/octi-sync-server/service"
 )
@@ -32,6 +33,8 @@ func (r *MetadataProvider) Get(ctx context.Context, id service.MetadataID) (serv
 
 	var metaData service.BaseMetadata
 	if err := json.Unmarshal(bytes, &metaData); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Str("metadata", name).Msg("reading metadata failed")
+
 		return nil, fmt.Errorf("unmarshalling meta %s failed: %w", name, service.ErrWritingModuleFailed)
 	}
 
@@ -46,14 +49,100 @@ func (r *MetadataProvider) Set(ctx context.Context, meta service.Metadata) error
 		return fmt.Errorf("marshalling meta %s failed: %w", name, service.ErrWritingModuleFailed)
 	}
 
-	err = r.Client.Set(ctx, name, data, NoExpiry).Err()
+	// metadata expires together with its module, a zero time removes the expiry it had before
+	err = r.Client.SetArgs(ctx, name, data, redis.SetArgs{ExpireAt: meta.GetExpiresAt()}).Err()
 	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Str("metadata", name).Msg("persisting metadata failed")
+
 		return fmt.Errorf("persisting meta %s failed: %w", name, service.ErrWritingModuleFailed)
 	}
 
 	return nil
 }
 
+func (r *MetadataProvider) GetMany(ctx context.Context, ids []service.MetadataID) ([]service.Metadata, error) {
+	metadata := make([]service.Metadata, len(ids))
+	if len(ids) == 0 {
+		return metadata, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = r.metadataKey(id)
+	}
+
+	values, err := r.Client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("could not read metadata: %w", err)
+	}
+
+	for i, value := range values {
+		raw, found := value.(string)
+		if !found {
+			continue
+		}
+
+		var meta service.BaseMetadata
+		if err := json.Unmarshal([]byte(raw), &meta); err != nil {
+			zerolog.Ctx(ctx).Error().Err(err).Str("metadata", keys[i]).Msg("reading metadata failed")
+
+			return nil, fmt.Errorf("unmarshalling meta %s failed: %w", keys[i], service.ErrWritingModuleFailed)
+		}
+
+		metadata[i] = &meta
+	}
+
+	return metadata, nil
+}
+
+func (r *MetadataProvider) List(
+	ctx context.Context, opts service.ListOptions,
+) ([]service.Metadata, string, error) {
+	prefix := r.metadataKey("")
+
+	keys, err := scanPrefix(ctx, r.Client, prefix+opts.Prefix)
+	if err != nil {
+		return nil, "", err
+	}
+
+	page, next, err := service.Paginate(service.TrimPrefix(keys, prefix), opts)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not paginate metadata: %w", err)
+	}
+
+	if len(page) == 0 {
+		return []service.Metadata{}, next, nil
+	}
+
+	for i := range page {
+		page[i] = prefix + page[i]
+	}
+
+	values, err := r.Client.MGet(ctx, page...).Result()
+	if err != nil {
+		return nil, "", fmt.Errorf("could not read metadata: %w", err)
+	}
+
+	metadata := make([]service.Metadata, 0, len(values))
+
+	for i, value := range values {
+		raw, found := value.(string)
+		if !found {
+			// deleted between scanning and reading
+			continue
+		}
+
+		var meta service.BaseMetadata
+		if err := json.Unmarshal([]byte(raw), &meta); err != nil {
+			return nil, "", fmt.Errorf("unmarshalling meta %s failed: %w", page[i], err)
+		}
+
+		metadata = append(metadata, &meta)
+	}
+
+	return metadata, next, nil
+}
+
 func (r *MetadataProvider) HealthCheck() service.HealthCheck {
 	return func(ctx context.Context) (string, bool) {
 		return "redis-metadata-provider", r.Client.Ping(ctx).Err() == nil
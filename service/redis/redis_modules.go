@@ -5,26 +5,35 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"slices"
 	"time"
 
 	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
 
 	"github.com/jakobmoellerdev/octi-sync-server/service"
 	"github.com/jakobmoellerdev/octi-sync-server/service/util"
 )
 
+// Modules stores the data of modules content addressed as blobs, so modules with the same data share blobs.
+// The data is split into chunks of at most ChunkSize bytes with a blob each, so that modules are streamed
+// without holding more than a chunk in memory. Module keys only reference the blobs of their chunks,
+// blobs are released once no module references them anymore. Module keys expire, blobs are released
+// by the garbage collection once the keys referencing them expired.
 type Modules struct {
-	Client     redis.Cmdable
-	Expiration time.Duration
+	Client redis.Cmdable
+	// GracePeriod protects references of writes in flight from garbage collection, DefaultBlobGracePeriod if 0
+	GracePeriod time.Duration
+	// ChunkSize is the maximum size of a blob, DefaultChunkSize if 0
+	ChunkSize int
 }
 
-func (r *Modules) Set(ctx context.Context, name string, module service.Module) error {
-	moduleData, err := io.ReadAll(module.Raw())
-	if err == nil {
-		err = r.Client.Set(ctx, name, moduleData, r.Expiration).Err()
-	}
+func (r *Modules) Set(ctx context.Context, name string, module service.Module, expiresAt time.Time) error {
+	if err := r.store(
+		ctx, map[string]service.Module{name: module}, map[string]time.Time{name: expiresAt},
+	); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Str("module", name).Msg("persisting module failed")
 
-	if err != nil {
 		return fmt.Errorf("persisting %s failed: %w", name, service.ErrWritingModuleFailed)
 	}
 
@@ -32,16 +41,357 @@ func (r *Modules) Set(ctx context.Context, name string, module service.Module) e
 }
 
 func (r *Modules) Get(ctx context.Context, name string) (service.Module, error) {
-	bytes, err := r.Client.Get(ctx, name).Bytes()
-	if errors.Is(err, redis.Nil) {
-		return ModuleFromBytes([]byte{}), nil
+	modules, err := r.load(ctx, []string{name})
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Str("module", name).Msg("reading module failed")
+
+		return nil, fmt.Errorf("reading %s failed: %w", name, service.ErrReadingModule)
 	}
 
+	return modules[0], nil
+}
+
+func (r *Modules) GetRange(ctx context.Context, name string, offset, length int64) (service.Module, error) {
+	module, err := r.loadRange(ctx, name, offset, length)
 	if err != nil {
-		return nil, fmt.Errorf("reading %s failed: %w", name, service.ErrReadingModule)
+		zerolog.Ctx(ctx).Error().Err(err).Str("module", name).Msg("reading module range failed")
+
+		return nil, fmt.Errorf("reading range of %s failed: %w", name, service.ErrReadingModule)
+	}
+
+	return module, nil
+}
+
+func (r *Modules) SetMany(
+	ctx context.Context, modules map[string]service.Module, expiresAt map[string]time.Time,
+) error {
+	if err := r.store(ctx, modules, expiresAt); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Int("modules", len(modules)).Msg("persisting modules failed")
+
+		return fmt.Errorf("persisting %d modules failed: %w", len(modules), service.ErrWritingModuleFailed)
+	}
+
+	return nil
+}
+
+func (r *Modules) GetMany(ctx context.Context, names []string) ([]service.Module, error) {
+	modules, err := r.load(ctx, names)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Int("modules", len(names)).Msg("reading modules failed")
+
+		return nil, fmt.Errorf("reading %d modules failed: %w", len(names), service.ErrReadingModule)
+	}
+
+	return modules, nil
+}
+
+// store streams the data of the modules into blobs and points the modules at them once all blobs are written.
+// Blobs are only transferred for chunks the modules did not reference before,
+// unchanged modules only have their expiry refreshed.
+func (r *Modules) store(
+	ctx context.Context, modules map[string]service.Module, expiresAt map[string]time.Time,
+) error {
+	names := make([]string, 0, len(modules))
+	for name := range modules {
+		names = append(names, name)
+	}
+
+	previous, err := r.values(ctx, names)
+	if err != nil {
+		return err
+	}
+
+	references := make([]string, len(names))
+	replaced := make(map[string][]string, len(names))
+
+	for i, name := range names {
+		previousDigests, _ := referencedBlobs(previous[i])
+
+		digests, err := r.storeChunks(ctx, name, modules[name].Raw(), previousDigests)
+		if err != nil {
+			return err
+		}
+
+		references[i] = blobReference(digests)
+
+		for _, digest := range previousDigests {
+			if !slices.Contains(digests, digest) && !slices.Contains(replaced[name], digest) {
+				replaced[name] = append(replaced[name], digest)
+			}
+		}
+	}
+
+	if _, err := r.Client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, name := range names {
+			// a zero time removes the expiry the module had before
+			pipe.SetArgs(ctx, name, references[i], redis.SetArgs{ExpireAt: expiresAt[name]})
+		}
+
+		return nil
+	}); err != nil {
+		return fmt.Errorf("could not reference blobs: %w", err)
+	}
+
+	// blobs that fail to be released are dropped by the garbage collection later on
+	for name, digests := range replaced {
+		for _, digest := range digests {
+			if _, err := releaseBlob(ctx, r.Client, digest, name); err != nil {
+				zerolog.Ctx(ctx).Warn().Err(err).Str("module", name).Msg("could not release replaced blob")
+			}
+		}
+	}
+
+	return nil
+}
+
+// storeChunks writes a blob for every chunk of the data that is not among the blobs the module already references
+// and returns the digests of all chunks. Empty data is stored as a single empty chunk.
+func (r *Modules) storeChunks(ctx context.Context, name string, data io.Reader, previous []string) ([]string, error) {
+	var digests []string
+
+	store := func(chunk []byte) error {
+		digest := service.Digest(chunk)
+		digests = append(digests, digest)
+
+		if slices.Contains(previous, digest) {
+			return nil
+		}
+
+		// the reference is added before the blob, so that a concurrent release cannot drop the blob
+		if _, err := r.Client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.ZAdd(ctx, blobReferencesKey(digest), redis.Z{Score: float64(time.Now().UnixMilli()), Member: name})
+			pipe.SetNX(ctx, blobKey(digest), chunk, 0)
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("could not store blob %s of %s: %w", digest, name, err)
+		}
+
+		return nil
+	}
+
+	if err := forEachChunk(data, r.ChunkSize, store); err != nil {
+		return nil, fmt.Errorf("could not store %s: %w", name, err)
+	}
+
+	if len(digests) == 0 {
+		if err := store([]byte{}); err != nil {
+			return nil, err
+		}
+	}
+
+	return digests, nil
+}
+
+// load returns the modules in the order of the names, modules that were never written are empty.
+// The data of the modules is only read from their blobs while it is streamed.
+func (r *Modules) load(ctx context.Context, names []string) ([]service.Module, error) {
+	values, err := r.values(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+
+	sizes, err := r.sizes(ctx, values)
+	if err != nil {
+		return nil, err
+	}
+
+	modules := make([]service.Module, len(names))
+
+	for i, value := range values {
+		if digests, isReference := referencedBlobs(value); isReference {
+			modules[i] = ModuleFromReader(blobReader(ctx, r.Client, digests), int(sizes[i]))
+		} else {
+			modules[i] = ModuleFromBytes([]byte(value))
+		}
+	}
+
+	return modules, nil
+}
+
+// loadRange returns the range of the module. Only the blobs of the chunks overlapping the range are read,
+// the first and the last of them with GETRANGE, so that no data outside of the range is transferred.
+func (r *Modules) loadRange(ctx context.Context, name string, offset, length int64) (service.Module, error) {
+	values, err := r.values(ctx, []string{name})
+	if err != nil {
+		return nil, err
+	}
+
+	digests, isReference := referencedBlobs(values[0])
+	if !isReference {
+		start := min(offset, int64(len(values[0])))
+		end := min(start+length, int64(len(values[0])))
+
+		return ModuleFromBytes([]byte(values[0][start:end])), nil
+	}
+
+	sizes, err := chunkSizes(ctx, r.Client, digests)
+	if err != nil {
+		return nil, err
+	}
+
+	type blobRange struct {
+		digest     string
+		start, end int64
+	}
+
+	var (
+		ranges  []blobRange
+		size    int64
+		chunkAt int64
+	)
+
+	for i, digest := range digests {
+		// the overlap of the range with the chunk, relative to the start of the chunk
+		start := max(offset-chunkAt, 0)
+		end := min(offset+length-chunkAt, sizes[i])
+		chunkAt += sizes[i]
+
+		if start < end {
+			ranges = append(ranges, blobRange{digest, start, end})
+			size += end - start
+		}
+	}
+
+	return ModuleFromReader(&chunkReader{next: func() ([]byte, error) {
+		if len(ranges) == 0 {
+			return nil, io.EOF
+		}
+
+		current := ranges[0]
+		ranges = ranges[1:]
+
+		chunk, err := r.Client.GetRange(ctx, blobKey(current.digest), current.start, current.end-1).Bytes()
+		if err != nil {
+			return nil, fmt.Errorf("could not read blob %s: %w", current.digest, err)
+		}
+
+		return chunk, nil
+	}}, int(size)), nil
+}
+
+// sizes returns the size of the data of every module value, summing up the blobs of its chunks.
+func (r *Modules) sizes(ctx context.Context, values []string) ([]int64, error) {
+	sizes := make([]int64, len(values))
+	chunks := make(map[int][]*redis.IntCmd, len(values))
+
+	if _, err := r.Client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, value := range values {
+			digests, isReference := referencedBlobs(value)
+			if !isReference {
+				sizes[i] = int64(len(value))
+
+				continue
+			}
+
+			for _, digest := range digests {
+				chunks[i] = append(chunks[i], pipe.StrLen(ctx, blobKey(digest)))
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("could not read blob sizes: %w", err)
+	}
+
+	for i, cmds := range chunks {
+		for _, cmd := range cmds {
+			sizes[i] += cmd.Val()
+		}
+	}
+
+	return sizes, nil
+}
+
+// values reads the raw values of the module keys, either blob references or data of modules
+// written before blobs were introduced. Modules that were never written are empty.
+func (r *Modules) values(ctx context.Context, names []string) ([]string, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	cmds := make([]*redis.StringCmd, len(names))
+
+	// missing modules fail their command with redis.Nil, which is not an error for the batch
+	if _, err := r.Client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, name := range names {
+			cmds[i] = pipe.Get(ctx, name)
+		}
+
+		return nil
+	}); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("could not read modules: %w", err)
+	}
+
+	values := make([]string, len(names))
+
+	for i, cmd := range cmds {
+		value, err := cmd.Result()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return nil, fmt.Errorf("could not read %s: %w", names[i], err)
+		}
+
+		values[i] = value
+	}
+
+	return values, nil
+}
+
+func (r *Modules) List(ctx context.Context, opts service.ListOptions) ([]service.ModuleInfo, string, error) {
+	keys, err := scanPrefix(ctx, r.Client, opts.Prefix)
+	if err != nil {
+		return nil, "", err
+	}
+
+	page, next, err := service.Paginate(keys, opts)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not paginate modules: %w", err)
+	}
+
+	values := make([]*redis.StringCmd, len(page))
+	ttls := make([]*redis.DurationCmd, len(page))
+
+	if _, err := r.Client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range page {
+			values[i] = pipe.Get(ctx, key)
+			ttls[i] = pipe.PTTL(ctx, key)
+		}
+
+		return nil
+	}); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, "", fmt.Errorf("could not read module references and expiry: %w", err)
+	}
+
+	raw := make([]string, len(page))
+	for i := range page {
+		raw[i] = values[i].Val()
+	}
+
+	sizes, err := r.sizes(ctx, raw)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not read module sizes: %w", err)
 	}
 
-	return ModuleFromBytes(bytes), nil
+	now := time.Now()
+	infos := make([]service.ModuleInfo, 0, len(page))
+
+	for i, key := range page {
+		ttl := ttls[i].Val()
+		if ttl == -2 {
+			// expired or deleted between scanning and reading
+			continue
+		}
+
+		info := service.ModuleInfo{ID: key, Size: sizes[i]}
+
+		if ttl > 0 {
+			info.ExpiresAt = now.Add(ttl)
+		}
+
+		infos = append(infos, info)
+	}
+
+	return infos, next, nil
 }
 
 func (r *Modules) HealthCheck() service.HealthCheck {
@@ -72,10 +422,19 @@ func (r *Modules) DeleteByPattern(ctx context.Context, pattern string) error {
 }
 
 func (r *Modules) Delete(ctx context.Context, key string) error {
-	err := r.Client.Del(ctx, key).Err()
-	if err != nil {
+	value, err := r.Client.GetDel(ctx, key).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
 		return fmt.Errorf("error while deleting %s: %w", key, err)
 	}
 
+	// chunks with the same data share their blob, which is referenced by the module only once
+	digests, _ := referencedBlobs(value)
+
+	for _, digest := range uniqueDigests(digests) {
+		if _, err := releaseBlob(ctx, r.Client, digest, key); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
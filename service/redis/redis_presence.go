@@ -0,0 +1,87 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+const PresenceKeySpace = "octi:presence"
+
+// Presence keeps the connections of an account in a sorted set scored by their expiry,
+// so connections of crashed replicas go offline without being disconnected.
+type Presence struct {
+	Client redis.Cmdable
+}
+
+func (r *Presence) presenceKey(account service.Account) string {
+	return fmt.Sprintf("%s:%s", PresenceKeySpace, account.Username())
+}
+
+func (r *Presence) connectionMember(device service.DeviceID, connection string) string {
+	return fmt.Sprintf("%s:%s", device, connection)
+}
+
+func (r *Presence) Connected(
+	ctx context.Context, account service.Account, device service.DeviceID, connection string, until time.Time,
+) error {
+	key := r.presenceKey(account)
+
+	if _, err := r.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZAdd(ctx, key, redis.Z{Score: float64(until.UnixMilli()), Member: r.connectionMember(device, connection)})
+		// connections that expired long ago are dropped whenever a new one arrives
+		pipe.ZRemRangeByScore(ctx, key, "-inf", strconv.FormatInt(time.Now().UnixMilli(), 10))
+		pipe.ExpireAt(ctx, key, until)
+
+		return nil
+	}); err != nil {
+		return fmt.Errorf("marking connection of %s as online failed: %w", device, err)
+	}
+
+	return nil
+}
+
+func (r *Presence) Disconnected(
+	ctx context.Context, account service.Account, device service.DeviceID, connection string,
+) error {
+	if err := r.Client.ZRem(ctx, r.presenceKey(account), r.connectionMember(device, connection)).Err(); err != nil {
+		return fmt.Errorf("marking connection of %s as offline failed: %w", device, err)
+	}
+
+	return nil
+}
+
+func (r *Presence) Online(ctx context.Context, account service.Account) (map[service.DeviceID]bool, error) {
+	members, err := r.Client.ZRangeByScore(ctx, r.presenceKey(account), &redis.ZRangeBy{
+		Min: "(" + strconv.FormatInt(time.Now().UnixMilli(), 10),
+		Max: "+inf",
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("reading online devices of %s failed: %w", account.Username(), err)
+	}
+
+	online := make(map[service.DeviceID]bool, len(members))
+
+	for _, member := range members {
+		device, _, _ := strings.Cut(member, ":")
+
+		id, err := uuid.Parse(device)
+		if err != nil {
+			zerolog.Ctx(ctx).Warn().Err(err).Str("connection", member).Msg("could not parse device of connection")
+
+			continue
+		}
+
+		online[service.DeviceID(id)] = true
+	}
+
+	return online, nil
+}
@@ -0,0 +1,35 @@
+package redis_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+	"github.com/jakobmoellerdev/octi-sync-server/service/redis"
+	"github.com/jakobmoellerdev/octi-sync-server/service/redis/mock"
+)
+
+func TestPresence_Online(t *testing.T) {
+	t.Parallel()
+	assertions := assert.New(t)
+	ctrl := gomock.NewController(t)
+	clientMock := mock.NewMockUniversalClient(ctrl)
+	ctx := context.Background()
+	account := service.NewBaseAccount("presence", time.Now())
+	device := uuid.Must(uuid.NewRandom())
+
+	clientMock.EXPECT().ZRangeByScore(ctx, redis.PresenceKeySpace+":presence", gomock.Any()).
+		Return(goredis.NewStringSliceResult([]string{
+			device.String() + ":first", device.String() + ":second", "invalid:connection",
+		}, nil))
+
+	online, err := (&redis.Presence{Client: clientMock}).Online(ctx, account)
+	assertions.NoError(err)
+	assertions.Equal(map[service.DeviceID]bool{service.DeviceID(device): true}, online)
+}
@@ -0,0 +1,44 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ScanCount is the amount of keys redis is asked to inspect per SCAN call.
+const ScanCount = 100
+
+var globEscaper = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`, `]`, `\]`)
+
+// scanPrefix returns all keys starting with the given prefix without blocking redis like KEYS would.
+func scanPrefix(ctx context.Context, client redis.Cmdable, prefix string) ([]string, error) {
+	var (
+		keys   []string
+		cursor uint64
+	)
+
+	match := globEscaper.Replace(prefix) + "*"
+	seen := make(map[string]bool)
+
+	for {
+		batch, next, err := client.Scan(ctx, cursor, match, ScanCount).Result()
+		if err != nil {
+			return nil, fmt.Errorf("error while scanning keys with prefix %s: %w", prefix, err)
+		}
+
+		// SCAN may return a key more than once
+		for _, key := range batch {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+
+		if cursor = next; cursor == 0 {
+			return keys, nil
+		}
+	}
+}
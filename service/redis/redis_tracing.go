@@ -0,0 +1,20 @@
+package redis
+
+import (
+	"github.com/redis/go-redis/extra/redisotel/v9"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+)
+
+// TracingMutator registers hooks on the client that create a span for every redis command and pipeline.
+// Spans are children of the span stored in the context that is passed to the command.
+func TracingMutator(logger *zerolog.Logger) ClientMutator {
+	return func(client goredis.UniversalClient) goredis.UniversalClient {
+		if err := redisotel.InstrumentTracing(client, redisotel.WithDBStatement(false)); err != nil {
+			// tracing is optional, a client without hooks is still fully functional
+			logger.Warn().Err(err).Msg("could not instrument redis client for tracing")
+		}
+
+		return client
+	}
+}
@@ -0,0 +1,193 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+const UploadKeySpace = "octi:uploads"
+
+// appendUploadScript appends the chunk to the staged data only if the offset matches the data received so far
+// and the announced length is not exceeded, so that retried chunks of flaky clients are never applied twice.
+// It returns the status of the append and the offset of the upload afterwards.
+var appendUploadScript = redis.NewScript(`
+local length = redis.call("HGET", KEYS[1], "length")
+if not length then
+	return {0, 0}
+end
+local offset = redis.call("STRLEN", KEYS[2])
+if offset ~= tonumber(ARGV[1]) then
+	return {1, offset}
+end
+if offset + string.len(ARGV[2]) > tonumber(length) then
+	return {2, offset}
+end
+offset = redis.call("APPEND", KEYS[2], ARGV[2])
+redis.call("PEXPIRE", KEYS[2], redis.call("PTTL", KEYS[1]))
+return {3, offset}
+`)
+
+const (
+	appendStatusNotFound = iota
+	appendStatusOffsetMismatch
+	appendStatusTooLarge
+	appendStatusAppended
+)
+
+// Uploads stages the data of an upload in its own key next to a hash describing the upload,
+// both expire together if the upload is never finished.
+type Uploads struct {
+	Client     redis.Cmdable
+	Expiration time.Duration
+	// ChunkSize is the maximum amount of staged data held in memory while streaming it, DefaultChunkSize if 0
+	ChunkSize int
+}
+
+// uploadKey hash tags the account and upload id, so that the upload and its data share a cluster slot
+// and can be used together in scripts and multi-key commands.
+func (r *Uploads) uploadKey(account service.Account, id string) string {
+	return fmt.Sprintf("%s:{%s:%s}", UploadKeySpace, account.Username(), id)
+}
+
+func (r *Uploads) dataKey(account service.Account, id string) string {
+	return r.uploadKey(account, id) + ":data"
+}
+
+func (r *Uploads) Create(ctx context.Context, account service.Account, upload service.Upload) (service.Upload, error) {
+	expiration := r.Expiration
+	if expiration <= 0 {
+		expiration = service.DefaultUploadExpiration
+	}
+
+	upload.ID = uuid.NewString()
+	upload.Offset = 0
+	upload.ExpiresAt = time.Now().Add(expiration)
+	key := r.uploadKey(account, upload.ID)
+
+	if _, err := r.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key,
+			"module", upload.Module,
+			"device", upload.Device.String(),
+			"length", upload.Length,
+			"expiresAt", upload.ExpiresAt.UnixMilli(),
+			"contentType", upload.ContentType,
+			"filename", upload.Filename,
+		)
+		pipe.PExpireAt(ctx, key, upload.ExpiresAt)
+
+		return nil
+	}); err != nil {
+		return service.Upload{}, fmt.Errorf("creating upload of %s failed: %w", upload.Module, err)
+	}
+
+	return upload, nil
+}
+
+func (r *Uploads) Get(ctx context.Context, account service.Account, id string) (service.Upload, error) {
+	fields, err := r.Client.HGetAll(ctx, r.uploadKey(account, id)).Result()
+	if err != nil {
+		return service.Upload{}, fmt.Errorf("reading upload %s failed: %w", id, err)
+	}
+
+	if len(fields) == 0 {
+		return service.Upload{}, fmt.Errorf("%w: %s", service.ErrUploadNotFound, id)
+	}
+
+	offset, err := r.Client.StrLen(ctx, r.dataKey(account, id)).Result()
+	if err != nil {
+		return service.Upload{}, fmt.Errorf("reading offset of upload %s failed: %w", id, err)
+	}
+
+	return uploadFromFields(id, fields, offset)
+}
+
+func (r *Uploads) Append(
+	ctx context.Context, account service.Account, id string, offset int64, chunk []byte,
+) (service.Upload, error) {
+	result, err := appendUploadScript.Run(
+		ctx, r.Client, []string{r.uploadKey(account, id), r.dataKey(account, id)}, offset, chunk,
+	).Int64Slice()
+	if err != nil {
+		return service.Upload{}, fmt.Errorf("appending to upload %s failed: %w", id, err)
+	}
+
+	switch result[0] {
+	case appendStatusNotFound:
+		return service.Upload{}, fmt.Errorf("%w: %s", service.ErrUploadNotFound, id)
+	case appendStatusOffsetMismatch:
+		err = fmt.Errorf("%w: expected offset %d", service.ErrUploadOffsetMismatch, result[1])
+	case appendStatusTooLarge:
+		err = fmt.Errorf("%w: %s", service.ErrUploadTooLarge, id)
+	}
+
+	upload, getErr := r.Get(ctx, account, id)
+	if getErr != nil {
+		return service.Upload{}, getErr
+	}
+
+	return upload, err
+}
+
+// Data streams the staged data of the upload in chunks instead of reading it at once.
+func (r *Uploads) Data(ctx context.Context, account service.Account, id string) (service.Module, error) {
+	key := r.dataKey(account, id)
+
+	size, err := r.Client.StrLen(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("reading size of upload %s failed: %w", id, err)
+	}
+
+	if size == 0 {
+		// nothing was received yet, or the upload does not exist at all
+		if _, err := r.Get(ctx, account, id); err != nil {
+			return nil, err
+		}
+
+		return ModuleFromBytes([]byte{}), nil
+	}
+
+	return ModuleFromReader(rangeReader(ctx, r.Client, key, size, r.ChunkSize), int(size)), nil
+}
+
+func (r *Uploads) Delete(ctx context.Context, account service.Account, id string) error {
+	if err := r.Client.Del(ctx, r.uploadKey(account, id), r.dataKey(account, id)).Err(); err != nil {
+		return fmt.Errorf("deleting upload %s failed: %w", id, err)
+	}
+
+	return nil
+}
+
+func uploadFromFields(id string, fields map[string]string, offset int64) (service.Upload, error) {
+	device, err := uuid.Parse(fields["device"])
+	if err != nil {
+		return service.Upload{}, fmt.Errorf("could not parse device of upload %s: %w", id, err)
+	}
+
+	length, err := strconv.ParseInt(fields["length"], 10, 64)
+	if err != nil {
+		return service.Upload{}, fmt.Errorf("could not parse length of upload %s: %w", id, err)
+	}
+
+	expiresAt, err := strconv.ParseInt(fields["expiresAt"], 10, 64)
+	if err != nil {
+		return service.Upload{}, fmt.Errorf("could not parse expiry of upload %s: %w", id, err)
+	}
+
+	return service.Upload{
+		ID:          id,
+		Module:      fields["module"],
+		Device:      service.DeviceID(device),
+		Length:      length,
+		Offset:      offset,
+		ExpiresAt:   time.UnixMilli(expiresAt),
+		ContentType: fields["contentType"],
+		Filename:    fields["filename"],
+	}, nil
+}
@@ -0,0 +1,82 @@
+package redis_test
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+	"github.com/jakobmoellerdev/octi-sync-server/service/redis"
+	"github.com/jakobmoellerdev/octi-sync-server/service/redis/mock"
+)
+
+func TestUploads_Get(t *testing.T) {
+	t.Parallel()
+	assertions := assert.New(t)
+	ctrl := gomock.NewController(t)
+	clientMock := mock.NewMockUniversalClient(ctrl)
+	ctx := context.Background()
+	account := service.NewBaseAccount("uploads", time.Now())
+	device := uuid.Must(uuid.NewRandom())
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Millisecond)
+	key := redis.UploadKeySpace + ":{uploads:upload}"
+
+	clientMock.EXPECT().HGetAll(ctx, key).Return(goredis.NewMapStringStringResult(map[string]string{
+		"module":    "settings",
+		"device":    device.String(),
+		"length":    "100",
+		"expiresAt": strconv.FormatInt(expiresAt.UnixMilli(), 10),
+	}, nil))
+	clientMock.EXPECT().StrLen(ctx, key+":data").Return(goredis.NewIntResult(40, nil))
+
+	upload, err := (&redis.Uploads{Client: clientMock}).Get(ctx, account, "upload")
+	assertions.NoError(err)
+	assertions.Equal(service.Upload{
+		ID:        "upload",
+		Module:    "settings",
+		Device:    service.DeviceID(device),
+		Length:    100,
+		Offset:    40,
+		ExpiresAt: time.UnixMilli(expiresAt.UnixMilli()),
+	}, upload)
+
+	clientMock.EXPECT().HGetAll(ctx, redis.UploadKeySpace+":{uploads:missing}").
+		Return(goredis.NewMapStringStringResult(map[string]string{}, nil))
+
+	_, err = (&redis.Uploads{Client: clientMock}).Get(ctx, account, "missing")
+	assertions.ErrorIs(err, service.ErrUploadNotFound)
+}
+
+func TestUploads_Data(t *testing.T) {
+	t.Parallel()
+	assertions := assert.New(t)
+	ctrl := gomock.NewController(t)
+	clientMock := mock.NewMockUniversalClient(ctrl)
+	ctx := context.Background()
+	account := service.NewBaseAccount("uploads", time.Now())
+	key := redis.UploadKeySpace + ":{uploads:upload}:data"
+
+	// the staged data is only read chunk by chunk while it is streamed
+	clientMock.EXPECT().StrLen(ctx, key).Return(goredis.NewIntResult(10, nil))
+	gomock.InOrder(
+		clientMock.EXPECT().GetRange(ctx, key, int64(0), int64(3)).Return(goredis.NewStringResult("0123", nil)),
+		clientMock.EXPECT().GetRange(ctx, key, int64(4), int64(7)).Return(goredis.NewStringResult("4567", nil)),
+		clientMock.EXPECT().GetRange(ctx, key, int64(8), int64(9)).Return(goredis.NewStringResult("89", nil)),
+	)
+
+	module, err := (&redis.Uploads{Client: clientMock, ChunkSize: 4}).Data(ctx, account, "upload")
+	if assertions.NoError(err) {
+		assertions.Equal(10, module.Size())
+
+		data, err := io.ReadAll(module.Raw())
+		assertions.NoError(err)
+		assertions.Equal("0123456789", string(data))
+	}
+}
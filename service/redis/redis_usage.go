@@ -0,0 +1,132 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/jakobmoellerdev/octi-sync-server/service"
+)
+
+const UsageKeySpace = "octi:usage"
+
+// removeUsage drops a module from the sizes of an account and subtracts it from the totals.
+// It is shared by the scripts that remove modules, KEYS are the sizes, the totals and the expiries of the account.
+const removeUsage = `
+local function remove(module)
+	local size = redis.call("HGET", KEYS[1], module)
+	if size then
+		redis.call("HDEL", KEYS[1], module)
+		redis.call("HINCRBY", KEYS[2], "bytes", -tonumber(size))
+		redis.call("HINCRBY", KEYS[2], "modules", -1)
+	end
+	redis.call("ZREM", KEYS[3], module)
+end
+`
+
+// recordUsageScript replaces the size of the module and adjusts the totals by the difference,
+// so that rewriting a module is not counted twice. Modules that expire are tracked with their expiry in seconds.
+var recordUsageScript = redis.NewScript(`
+local previous = redis.call("HGET", KEYS[1], ARGV[1])
+redis.call("HSET", KEYS[1], ARGV[1], ARGV[2])
+if previous then
+	redis.call("HINCRBY", KEYS[2], "bytes", tonumber(ARGV[2]) - tonumber(previous))
+else
+	redis.call("HINCRBY", KEYS[2], "bytes", ARGV[2])
+	redis.call("HINCRBY", KEYS[2], "modules", 1)
+end
+if ARGV[3] == "0" then
+	redis.call("ZREM", KEYS[3], ARGV[1])
+else
+	redis.call("ZADD", KEYS[3], ARGV[3], ARGV[1])
+end
+return 0
+`)
+
+var removeUsageScript = redis.NewScript(removeUsage + `
+for _, module in ipairs(ARGV) do
+	remove(module)
+end
+return 0
+`)
+
+// getUsageScript removes the modules that expired until ARGV[1] and returns the totals afterwards.
+var getUsageScript = redis.NewScript(removeUsage + `
+for _, module in ipairs(redis.call("ZRANGEBYSCORE", KEYS[3], "-inf", ARGV[1])) do
+	remove(module)
+end
+return redis.call("HMGET", KEYS[2], "bytes", "modules")
+`)
+
+// Usage keeps the size of every module of an account in a hash, so that rewriting a module
+// replaces its size instead of counting it twice, and running totals of bytes and modules next to it,
+// so that reading the usage does not depend on the amount of modules. Expiring modules are kept in a sorted set
+// by their expiry and dropped from the totals once the usage is read after they expired.
+type Usage struct {
+	Client redis.Cmdable
+}
+
+// usageKeys are the sizes, totals and expiries of the account. The account is a hash tag so that
+// the keys share a cluster slot and can be updated together in a script.
+func (r *Usage) usageKeys(account service.Account) []string {
+	sizes := fmt.Sprintf("%s:{%s}", UsageKeySpace, account.Username())
+
+	return []string{sizes, sizes + ":totals", sizes + ":expiries"}
+}
+
+func (r *Usage) Record(
+	ctx context.Context, account service.Account, module string, size int64, expiresAt time.Time,
+) error {
+	var expiry int64
+	if !expiresAt.IsZero() {
+		expiry = expiresAt.Unix()
+	}
+
+	if err := recordUsageScript.Run(ctx, r.Client, r.usageKeys(account), module, size, expiry).Err(); err != nil {
+		return fmt.Errorf("recording usage of %s failed: %w", module, err)
+	}
+
+	return nil
+}
+
+func (r *Usage) Remove(ctx context.Context, account service.Account, modules ...string) error {
+	if len(modules) == 0 {
+		return nil
+	}
+
+	args := make([]interface{}, len(modules))
+	for i, module := range modules {
+		args[i] = module
+	}
+
+	if err := removeUsageScript.Run(ctx, r.Client, r.usageKeys(account), args...).Err(); err != nil {
+		return fmt.Errorf("removing usage of %d modules failed: %w", len(modules), err)
+	}
+
+	return nil
+}
+
+func (r *Usage) Get(ctx context.Context, account service.Account) (service.AccountUsage, error) {
+	totals, err := getUsageScript.Run(ctx, r.Client, r.usageKeys(account), time.Now().Unix()).Slice()
+	if err != nil {
+		return service.AccountUsage{}, fmt.Errorf("reading usage of %s failed: %w", account.Username(), err)
+	}
+
+	var usage service.AccountUsage
+
+	for i, total := range []*int64{&usage.Bytes, &usage.Modules} {
+		value, isSet := totals[i].(string)
+		if !isSet {
+			continue
+		}
+
+		if *total, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return service.AccountUsage{}, fmt.Errorf("could not parse usage of %s: %w", account.Username(), err)
+		}
+	}
+
+	return usage, nil
+}
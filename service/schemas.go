@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var ErrSchemaViolation = errors.New("module data does not validate against its schema")
+
+// Schema is a JSON Schema registered for all modules whose name matches the pattern.
+type Schema struct {
+	// Name identifies the schema, e.g. the file it was loaded from
+	Name string
+	// Pattern is matched against module names, * matches any sequence of characters
+	Pattern string
+	// Document is the JSON Schema itself
+	Document []byte
+}
+
+// SchemaViolation is a value in module data that does not validate against a keyword of the schema.
+type SchemaViolation struct {
+	// InstanceLocation is a JSON Pointer to the invalid value in the module data
+	InstanceLocation string
+	// KeywordLocation is a JSON Pointer to the violated keyword in the schema
+	KeywordLocation string
+	Message         string
+}
+
+// SchemaValidationError lists all violations of module data against the schema of the module.
+type SchemaValidationError struct {
+	Schema     string
+	Violations []SchemaViolation
+}
+
+func (e *SchemaValidationError) Error() string {
+	return fmt.Sprintf("%s: %s has %d violations", ErrSchemaViolation, e.Schema, len(e.Violations))
+}
+
+func (e *SchemaValidationError) Unwrap() error {
+	return ErrSchemaViolation
+}
+
+//go:generate mockgen -source schemas.go -package mock -destination mock/schemas.go Schemas
+type Schemas interface {
+	// List returns all registered schemas.
+	List(ctx context.Context) ([]Schema, error)
+	// Match returns the schema registered for the module, if any. Data of modules without a schema
+	// does not need to be validated and can be streamed without buffering it.
+	Match(ctx context.Context, module string) (Schema, bool, error)
+	// Validate returns a SchemaValidationError if the data does not validate against the schema
+	// registered for the module. Modules without a schema are not validated.
+	Validate(ctx context.Context, module string, data []byte) error
+}
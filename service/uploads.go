@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// DefaultUploadExpiration is the time an unfinished upload is kept if not configured otherwise.
+const DefaultUploadExpiration = 24 * time.Hour
+
+var (
+	ErrUploadNotFound       = errors.New("upload not found")
+	ErrUploadOffsetMismatch = errors.New("upload offset does not match the received data")
+	ErrUploadTooLarge       = errors.New("upload exceeds its announced length")
+)
+
+// Upload is a module write that is received in chunks across multiple requests.
+type Upload struct {
+	// ID identifies the upload within its account, it is assigned when the upload is created
+	ID string
+	// Module is the name of the module that is written when the upload is finished
+	Module string
+	Device DeviceID
+	// Length is the size of the module data announced by the client
+	Length int64
+	// Offset is the amount of bytes received so far
+	Offset int64
+	// ContentType and Filename describe the module data, they are stored with the module when the upload is finished
+	ContentType string
+	Filename    string
+	// ExpiresAt is the time after which an unfinished upload is dropped, it is assigned when the upload is created
+	ExpiresAt time.Time
+}
+
+// Done reports whether all announced data of the upload was received.
+func (u Upload) Done() bool {
+	return u.Offset == u.Length
+}
+
+//go:generate mockgen -source uploads.go -package mock -destination mock/uploads.go Uploads
+type Uploads interface {
+	// Create starts a new upload without data and returns it with its ID and expiry.
+	Create(ctx context.Context, account Account, upload Upload) (Upload, error)
+	// Get returns the upload, ErrUploadNotFound is returned for unknown or expired uploads.
+	Get(ctx context.Context, account Account, id string) (Upload, error)
+	// Append adds the chunk at the offset to the staged data of the upload and returns the updated upload.
+	// ErrUploadOffsetMismatch is returned if the offset is not the amount of bytes received so far
+	// and ErrUploadTooLarge if the chunk would exceed the announced length.
+	Append(ctx context.Context, account Account, id string, offset int64, chunk []byte) (Upload, error)
+	// Data returns the data received so far.
+	Data(ctx context.Context, account Account, id string) (Module, error)
+	// Delete drops the upload and its staged data.
+	Delete(ctx context.Context, account Account, id string) error
+}
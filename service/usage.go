@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+// AccountUsage is the storage used by an account.
+type AccountUsage struct {
+	// Bytes is the size of the data of all modules
+	Bytes int64
+	// Modules is the amount of modules
+	Modules int64
+	// Devices is the amount of registered devices
+	Devices int64
+}
+
+// Quota limits the storage of every account, limits of 0 or less are not enforced.
+type Quota struct {
+	Bytes   int64
+	Modules int64
+	Devices int64
+}
+
+//go:generate mockgen -source usage.go -package mock -destination mock/usage.go Usage
+type Usage interface {
+	// Record sets the size of a module of the account, the module is counted if it was not recorded before
+	// and until it expires at expiresAt, unless that is the zero time.
+	Record(ctx context.Context, account Account, module string, size int64, expiresAt time.Time) error
+	// Remove drops the modules of the account from its usage.
+	Remove(ctx context.Context, account Account, modules ...string) error
+	// Get returns the bytes and modules used by the account, devices are not tracked.
+	Get(ctx context.Context, account Account) (AccountUsage, error)
+}
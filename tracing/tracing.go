@@ -0,0 +1,81 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+
+	"github.com/jakobmoellerdev/octi-sync-server/config"
+)
+
+// InstrumentationName is the name of the tracer used for all spans created by the server.
+const InstrumentationName = "github.com/jakobmoellerdev/octi-sync-server"
+
+const ServiceName = "octi-sync-server"
+
+// Shutdown flushes all pending spans and stops the exporter.
+type Shutdown func(ctx context.Context) error
+
+// Setup installs a global tracer provider and propagator based on the given settings.
+// If no exporter is configured, the global no-op provider stays in place.
+// The stdout exporter writes spans as JSON to the given writer and is intended for local development.
+func Setup(ctx context.Context, settings config.TracingSettings, version string, writer io.Writer) (Shutdown, error) {
+	var (
+		exporter sdktrace.SpanExporter
+		err      error
+	)
+
+	switch settings.Exporter {
+	case config.TracingExporterOTLP:
+		opts := []otlptracehttp.Option{}
+		if settings.Endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(settings.Endpoint))
+		}
+
+		if settings.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+
+		exporter, err = otlptracehttp.New(ctx, opts...)
+	case config.TracingExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(writer))
+	case config.TracingExporterNone:
+		fallthrough
+	default:
+		return func(context.Context) error { return nil }, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("could not create %s trace exporter: %w", settings.Exporter, err)
+	}
+
+	sampler := sdktrace.AlwaysSample()
+	if settings.SampleRatio > 0 && settings.SampleRatio < 1 {
+		sampler = sdktrace.TraceIDRatioBased(settings.SampleRatio)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sampler)),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName(ServiceName),
+			semconv.ServiceVersion(version),
+		)),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{},
+	))
+
+	return provider.Shutdown, nil
+}
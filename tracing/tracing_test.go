@@ -0,0 +1,40 @@
+package tracing_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+
+	"github.com/jakobmoellerdev/octi-sync-server/config"
+	"github.com/jakobmoellerdev/octi-sync-server/tracing"
+)
+
+//nolint:paralleltest // modifies the global tracer provider
+func TestSetupStdout(t *testing.T) {
+	assertions := assert.New(t)
+	out := &bytes.Buffer{}
+
+	shutdown, err := tracing.Setup(context.Background(), config.TracingSettings{
+		Exporter: config.TracingExporterStdout,
+	}, "test", out)
+	assertions.NoError(err)
+
+	_, span := otel.Tracer(tracing.InstrumentationName).Start(context.Background(), "test-span")
+	span.End()
+
+	assertions.NoError(shutdown(context.Background()))
+	assertions.Contains(out.String(), `"Name":"test-span"`)
+	assertions.Contains(out.String(), tracing.ServiceName)
+}
+
+func TestSetupWithoutExporter(t *testing.T) {
+	t.Parallel()
+	assertions := assert.New(t)
+
+	shutdown, err := tracing.Setup(context.Background(), config.TracingSettings{}, "test", &bytes.Buffer{})
+	assertions.NoError(err)
+	assertions.NoError(shutdown(context.Background()))
+}